@@ -9,27 +9,42 @@ type AllRepositories struct {
 	AddressAreaRepository                 repository.AddressAreaRepository
 	CartItemRepository                    repository.CartItemRepository
 	CronRepository                        repository.CronRepository
+	ClinicalNoteRepository                repository.ClinicalNoteRepository
 	ConsultationMessageRepository         repository.ConsultationMessageRepository
 	ConsultationSessionRepository         repository.ConsultationSessionRepository
+	SessionExtensionRepository            repository.ConsultationSessionExtensionRepository
+	DependentRepository                   repository.DependentRepository
 	DoctorSpecializationRepository        repository.DoctorSpecializationRepository
 	DrugClassificationRepository          repository.DrugClassificationRepository
+	DrugInteractionRepository             repository.DrugInteractionRepository
 	ForgotTokenRepository                 repository.ForgotTokenRepository
 	ManufacturerRepository                repository.ManufacturerRepository
+	MedicalProfileRepository              repository.MedicalProfileRepository
+	MedicalRecordRepository               repository.MedicalRecordRepository
+	MedicationReminderRepository          repository.MedicationReminderRepository
 	OrderRepository                       repository.OrderRepository
 	PharmacyRepository                    repository.PharmacyRepository
 	PharmacyProductRepository             repository.PharmacyProductRepository
 	PrescriptionRepository                repository.PrescriptionRepository
+	PrescriptionTemplateRepository        repository.PrescriptionTemplateRepository
+	ProductAlertRepository                repository.ProductAlertRepository
 	ProductCategoryRepository             repository.ProductCategoryRepository
+	ProductImageRepository                repository.ProductImageRepository
+	ProductImportRepository               repository.ProductImportRepository
+	ProductRecommendationRepository       repository.ProductRecommendationRepository
 	ProductRepository                     repository.ProductRepository
+	ProductReviewRepository               repository.ProductReviewRepository
 	ProductStockMutationRepository        repository.ProductStockMutationRepository
 	ProductStockMutationRequestRepository repository.ProductStockMutationRequestRepository
 	ProfileRepository                     repository.ProfileRepository
 	RegisterTokenRepository               repository.RegisterTokenRepository
+	ReferralRepository                    repository.ReferralRepository
 	ReportRepository                      repository.ReportRepository
 	TransactionRepository                 repository.TransactionRepository
 	ShippingMethodRepository              repository.ShippingMethodRepository
 	SickLeaveFormRepository               repository.SickLeaveFormRepository
 	UserAddressRepository                 repository.UserAddressRepository
+	UserNotificationRepository            repository.UserNotificationRepository
 	UserRepository                        repository.UserRepository
 }
 
@@ -38,26 +53,41 @@ func InitializeRepositories(db *sql.DB) *AllRepositories {
 		AddressAreaRepository:                 repository.NewAddressAreaRepositoryImpl(db),
 		CartItemRepository:                    repository.NewCartItemRepositoryImpl(db),
 		CronRepository:                        repository.NewCronRepoImpl(db),
+		ClinicalNoteRepository:                repository.NewClinicalNoteRepositoryImpl(db),
 		ConsultationMessageRepository:         repository.NewConsultationMessageRepositoryImpl(db),
 		ConsultationSessionRepository:         repository.NewConsultationSessionRepositoryImpl(db),
+		SessionExtensionRepository:            repository.NewConsultationSessionExtensionRepositoryImpl(db),
+		DependentRepository:                   repository.NewDependentRepositoryImpl(db),
 		DoctorSpecializationRepository:        repository.NewDoctorSpecializationRepositoryImpl(db),
 		DrugClassificationRepository:          repository.NewDrugClassificationRepositoryImpl(db),
+		DrugInteractionRepository:             repository.NewDrugInteractionRepositoryImpl(db),
 		ForgotTokenRepository:                 repository.NewForgotTokenRepository(db),
 		ManufacturerRepository:                repository.NewManufacturerRepositoryImpl(db),
+		MedicalProfileRepository:              repository.NewMedicalProfileRepositoryImpl(db),
+		MedicalRecordRepository:               repository.NewMedicalRecordRepositoryImpl(db),
+		MedicationReminderRepository:          repository.NewMedicationReminderRepositoryImpl(db),
 		OrderRepository:                       repository.NewOrderRepositoryImpl(db),
 		PharmacyRepository:                    repository.NewPharmacyRepository(db),
 		PharmacyProductRepository:             repository.NewPharmacyProductRepository(db),
 		PrescriptionRepository:                repository.NewPrescriptionRepositoryImpl(db),
+		PrescriptionTemplateRepository:        repository.NewPrescriptionTemplateRepositoryImpl(db),
+		ProductAlertRepository:                repository.NewProductAlertRepositoryImpl(db),
 		ProductCategoryRepository:             repository.NewProductCategoryRepositoryImpl(db),
+		ProductImageRepository:                repository.NewProductImageRepositoryImpl(db),
+		ProductImportRepository:               repository.NewProductImportRepositoryImpl(db),
+		ProductRecommendationRepository:       repository.NewProductRecommendationRepositoryImpl(db),
 		ProductRepository:                     repository.NewProductRepositoryImpl(db),
+		ProductReviewRepository:               repository.NewProductReviewRepositoryImpl(db),
 		ProductStockMutationRepository:        repository.NewProductStockMutationRepositoryImpl(db),
 		ProductStockMutationRequestRepository: repository.NewProductStockMutationRequestRepositoryImpl(db),
 		ProfileRepository:                     repository.NewProfileRepository(db),
 		RegisterTokenRepository:               repository.NewRegisterTokenRepository(db),
+		ReferralRepository:                    repository.NewReferralRepositoryImpl(db),
 		ReportRepository:                      repository.NewReportRepositoryImpl(db),
 		TransactionRepository:                 repository.NewTransactionRepositoryImpl(db),
 		ShippingMethodRepository:              repository.NewShippingMethodRepositoryImpl(db),
 		SickLeaveFormRepository:               repository.NewSickLeaveFormRepositoryImpl(db),
+		UserNotificationRepository:            repository.NewUserNotificationRepositoryImpl(db),
 		UserRepository:                        repository.NewUserRepository(db),
 		UserAddressRepository:                 repository.NewUserAddressRepositoryImpl(db),
 	}
@@ -19,25 +19,38 @@ type RouterOpts struct {
 	AuthHandler                        *handler.AuthHandler
 	CartItemHandler                    *handler.CartItemHandler
 	ChatHandler                        *handler.ChatHandler
+	ClinicalNoteHandler                *handler.ClinicalNoteHandler
+	DependentHandler                   *handler.DependentHandler
 	DoctorSpecsHandler                 *handler.DoctorSpecializationHandler
 	DrugClassificationHandler          *handler.DrugClassificationHandler
+	DrugInteractionHandler             *handler.DrugInteractionHandler
 	ForgotTokenHandler                 *handler.ForgotTokenHandler
 	ManufacturerHandler                *handler.ManufacturerHandler
+	MedicalProfileHandler              *handler.MedicalProfileHandler
+	MedicalRecordHandler               *handler.MedicalRecordHandler
+	MedicationReminderHandler          *handler.MedicationReminderHandler
 	OrderHandler                       *handler.OrderHandler
 	PharmacyHandler                    *handler.PharmacyHandler
 	PharmacyProductsHandler            *handler.PharmacyProductHandler
 	PrescriptionHandler                *handler.PrescriptionHandler
+	PrescriptionTemplateHandler        *handler.PrescriptionTemplateHandler
+	ProductAlertHandler                *handler.ProductAlertHandler
 	ProductCategoryHandler             *handler.ProductCategoryHandler
 	ProductHandler                     *handler.ProductHandler
+	ProductImageHandler                *handler.ProductImageHandler
+	ProductImportHandler               *handler.ProductImportHandler
+	ProductReviewHandler               *handler.ProductReviewHandler
 	ProductStockMutationHandler        *handler.ProductStockMutationHandler
 	ProductStockMutationRequestHandler *handler.ProductStockMutationRequestHandler
 	ProfileHandler                     *handler.ProfileHandler
 	RegisterTokenHandler               *handler.RegisterTokenHandler
+	ReferralHandler                    *handler.ReferralHandler
 	ReportHandler                      *handler.ReportHandler
 	ShippingMethodHandler              *handler.ShippingMethodHandler
 	StockReportHandler                 *handler.StockReportHandler
 	TransactionHandler                 *handler.TransactionHandler
 	UserAddressHandler                 *handler.UserAddressHandler
+	UserNotificationHandler            *handler.UserNotificationHandler
 	UserHandler                        *handler.UserHandler
 	SickLeaveFormHandler               *handler.SickLeaveFormHandler
 }
@@ -47,26 +60,39 @@ func InitializeAllRouterOpts(allUC *AllUseCases, hub *ws.Hub) *RouterOpts {
 		AddressAreaHandler:                 handler.NewAddressAreaHandler(allUC.AddressAreaUseCase),
 		AuthHandler:                        handler.NewAuthHandler(allUC.AuthUseCase, appvalidator.Validator),
 		CartItemHandler:                    handler.NewCartItemHandler(allUC.CartItemUseCase, appvalidator.Validator),
-		ChatHandler:                        handler.NewChatHandler(hub, allUC.ConsultationSessionUseCase, allUC.ConsultationMessageUseCase, allUC.ProfileUseCase, appvalidator.Validator),
+		ChatHandler:                        handler.NewChatHandler(hub, allUC.ConsultationSessionUseCase, allUC.ConsultationMessageUseCase, allUC.SessionExtensionUseCase, allUC.ProfileUseCase, appvalidator.Validator),
+		ClinicalNoteHandler:                handler.NewClinicalNoteHandler(allUC.ClinicalNoteUseCase, appvalidator.Validator),
+		DependentHandler:                   handler.NewDependentHandler(allUC.DependentUseCase, appvalidator.Validator),
 		DoctorSpecsHandler:                 handler.NewDoctorSpecializationHandler(allUC.DoctorSpecializationUseCase, appvalidator.Validator),
 		DrugClassificationHandler:          handler.NewDrugClassificationHandler(allUC.DrugClassificationUseCase),
+		DrugInteractionHandler:             handler.NewDrugInteractionHandler(allUC.DrugInteractionUseCase, appvalidator.Validator),
 		ForgotTokenHandler:                 handler.NewForgotTokenHandler(allUC.ForgotTokenUseCase, appvalidator.Validator),
 		ManufacturerHandler:                handler.NewManufacturerHandler(allUC.ManufacturerUseCase, appvalidator.Validator),
+		MedicalProfileHandler:              handler.NewMedicalProfileHandler(allUC.MedicalProfileUseCase, appvalidator.Validator),
+		MedicalRecordHandler:               handler.NewMedicalRecordHandler(allUC.MedicalRecordUseCase, appvalidator.Validator),
+		MedicationReminderHandler:          handler.NewMedicationReminderHandler(allUC.MedicationReminderUseCase, appvalidator.Validator),
 		OrderHandler:                       handler.NewOrderHandler(allUC.OrderUseCase, appvalidator.Validator),
 		PharmacyHandler:                    handler.NewPharmacyHandler(allUC.PharmacyUseCase, appvalidator.Validator),
 		PharmacyProductsHandler:            handler.NewPharmacyProductHAndler(allUC.PharmacyProductUseCase, appvalidator.Validator),
 		PrescriptionHandler:                handler.NewPrescriptionHandler(allUC.PrescriptionUseCase, appvalidator.Validator),
+		PrescriptionTemplateHandler:        handler.NewPrescriptionTemplateHandler(allUC.PrescriptionTemplateUseCase, appvalidator.Validator),
+		ProductAlertHandler:                handler.NewProductAlertHandler(allUC.ProductAlertUseCase, appvalidator.Validator),
 		ProductCategoryHandler:             handler.NewProductCategoryHandler(allUC.ProductCategoryUseCase, appvalidator.Validator),
 		ProductHandler:                     handler.NewProductHandler(allUC.ProductUseCase, appvalidator.Validator),
+		ProductImageHandler:                handler.NewProductImageHandler(allUC.ProductImageUseCase, appvalidator.Validator),
+		ProductImportHandler:               handler.NewProductImportHandler(allUC.ProductImportUseCase, appvalidator.Validator),
+		ProductReviewHandler:               handler.NewProductReviewHandler(allUC.ProductReviewUseCase, appvalidator.Validator),
 		ProductStockMutationHandler:        handler.NewProductStockMutationHandler(allUC.ProductStockMutation, appvalidator.Validator),
 		ProductStockMutationRequestHandler: handler.NewProductStockMutationRequestHandler(allUC.ProductStockMutationRequest, appvalidator.Validator),
 		ProfileHandler:                     handler.NewProfileHandler(allUC.ProfileUseCase, appvalidator.Validator),
 		RegisterTokenHandler:               handler.NewRegisterTokenHandler(allUC.RegisterTokenUseCase, appvalidator.Validator),
+		ReferralHandler:                    handler.NewReferralHandler(allUC.ReferralUseCase, appvalidator.Validator),
 		ReportHandler:                      handler.NewReportHandler(allUC.ReportUseCase, appvalidator.Validator),
 		ShippingMethodHandler:              handler.NewShippingMethodHandler(allUC.ShippingMethodUseCase, appvalidator.Validator),
 		StockReportHandler:                 handler.NewStockReportHandler(allUC.ProductStockMutation, appvalidator.Validator),
 		TransactionHandler:                 handler.NewTransactionHandler(allUC.TransactionUseCase, appvalidator.Validator),
 		UserAddressHandler:                 handler.NewAddressHandler(allUC.UserAddressUseCase, appvalidator.Validator),
+		UserNotificationHandler:            handler.NewUserNotificationHandler(allUC.UserNotificationUseCase, appvalidator.Validator),
 		UserHandler:                        handler.NewUserHandler(allUC.UserUseCase, appvalidator.Validator),
 		SickLeaveFormHandler:               handler.NewSickLeaveFormHandler(allUC.SickLeaveFormUseCase, appvalidator.Validator),
 	}
@@ -109,7 +135,7 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 		ctx.JSON(http.StatusNotFound, resp)
 	})
 
-	v1 := router.Group("/v1")
+	v1 := router.Group(appconstant.ApiV1Path)
 	{
 		addressArea := v1.Group("/address-area")
 		{
@@ -192,6 +218,49 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 				middleware.AllowRoles(appconstant.UserRoleIdDoctor, appconstant.UserRoleIdUser),
 				rOpts.ChatHandler.EditStatusAsEnded,
 			)
+			chats.GET(
+				"/:id/extensions",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor, appconstant.UserRoleIdUser),
+				rOpts.ChatHandler.GetAllExtensions,
+			)
+			chats.POST(
+				"/:id/extensions",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdUser),
+				rOpts.ChatHandler.AddExtension,
+			)
+			chats.POST(
+				"/:id/extensions/:extensionId/accept",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+				rOpts.ChatHandler.AcceptExtension,
+			)
+			chats.POST(
+				"/:id/extensions/:extensionId/reject",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+				rOpts.ChatHandler.RejectExtension,
+			)
+		}
+
+		clinicalNotes := v1.Group("/clinical-notes", middleware.LoginMiddleware(), middleware.AllowRoles(appconstant.UserRoleIdDoctor))
+		{
+			clinicalNotes.GET("/:sessionId", rOpts.ClinicalNoteHandler.GetBySessionId)
+			clinicalNotes.PUT("/:sessionId", rOpts.ClinicalNoteHandler.EditBySessionId)
+			clinicalNotes.GET("/:sessionId/versions", rOpts.ClinicalNoteHandler.GetAllVersionsBySessionId)
+		}
+
+		dependents := v1.Group("/dependents", middleware.LoginMiddleware(), middleware.AllowRoles(appconstant.UserRoleIdUser))
+		{
+			dependents.GET("", rOpts.DependentHandler.GetAllMine)
+			dependents.POST("", rOpts.DependentHandler.Add)
+			dependents.GET("/:id", rOpts.DependentHandler.GetById)
+			dependents.PUT("/:id", rOpts.DependentHandler.Edit)
+			dependents.DELETE("/:id", rOpts.DependentHandler.Remove)
+			dependents.GET("/:id/medical", rOpts.MedicalProfileHandler.GetByDependentId)
+			dependents.PUT("/:id/medical", rOpts.MedicalProfileHandler.EditDependent)
+			dependents.POST("/:id/medical/measurements", rOpts.MedicalProfileHandler.AddDependentMeasurement)
 		}
 
 		drugClassifications := v1.Group("/drug-classifications")
@@ -199,6 +268,24 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 			drugClassifications.GET("/no-params", rOpts.DrugClassificationHandler.GetAllWithoutParams)
 		}
 
+		drugInteractions := v1.Group("/drug-interactions", middleware.LoginMiddleware())
+		{
+			drugInteractions.GET(
+				"",
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin, appconstant.UserRoleIdDoctor),
+				rOpts.DrugInteractionHandler.GetAll,
+			)
+			drugInteractions.GET(
+				"/:id",
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin, appconstant.UserRoleIdDoctor),
+				rOpts.DrugInteractionHandler.GetById,
+			)
+			drugInteractions.POST("", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.DrugInteractionHandler.Add)
+			drugInteractions.POST("/import", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.DrugInteractionHandler.Import)
+			drugInteractions.PUT("/:id", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.DrugInteractionHandler.Edit)
+			drugInteractions.DELETE("/:id", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.DrugInteractionHandler.Remove)
+		}
+
 		specs := v1.Group("/doctor-specs")
 		{
 			specs.GET("/:id", rOpts.DoctorSpecsHandler.GetById)
@@ -249,6 +336,48 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 			)
 		}
 
+		medicalRecords := v1.Group("/medical-records", middleware.LoginMiddleware())
+		{
+			medicalRecords.GET("", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.MedicalRecordHandler.GetAllMine)
+			medicalRecords.GET("/fhir", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.MedicalRecordHandler.ExportFhirMine)
+			medicalRecords.GET(
+				"/sessions/:sessionId",
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+				rOpts.MedicalRecordHandler.GetAllBySessionId,
+			)
+			medicalRecords.GET(
+				"/sessions/:sessionId/fhir",
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+				rOpts.MedicalRecordHandler.ExportFhirBySessionId,
+			)
+			medicalRecords.GET(
+				"/sessions/:sessionId/consent",
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor, appconstant.UserRoleIdUser),
+				rOpts.MedicalRecordHandler.GetConsentBySessionId,
+			)
+			medicalRecords.PUT(
+				"/sessions/:sessionId/consent",
+				middleware.AllowRoles(appconstant.UserRoleIdUser),
+				rOpts.MedicalRecordHandler.EditConsentBySessionId,
+			)
+		}
+
+		medicationReminders := v1.Group("/medication-reminders", middleware.LoginMiddleware(), middleware.AllowRoles(appconstant.UserRoleIdUser))
+		{
+			medicationReminders.GET("", rOpts.MedicationReminderHandler.GetAllMine)
+			medicationReminders.POST("", rOpts.MedicationReminderHandler.Add)
+			medicationReminders.POST("/prescription-products/:id", rOpts.MedicationReminderHandler.AddFromPrescriptionProduct)
+			medicationReminders.PUT("/doses/:id", rOpts.MedicationReminderHandler.LogDose)
+			medicationReminders.GET("/:id", rOpts.MedicationReminderHandler.GetById)
+			medicationReminders.DELETE("/:id", rOpts.MedicationReminderHandler.Remove)
+		}
+
+		notifications := v1.Group("/notifications", middleware.LoginMiddleware(), middleware.AllowRoles(appconstant.UserRoleIdUser))
+		{
+			notifications.GET("", rOpts.UserNotificationHandler.GetAllMine)
+			notifications.PUT("/:id/read", rOpts.UserNotificationHandler.EditAsRead)
+		}
+
 		order := v1.Group("/orders", middleware.LoginMiddleware())
 		{
 			order.GET("/pharmacy-admin", middleware.AllowRoles(appconstant.UserRoleIdPharmacyAdmin), rOpts.OrderHandler.GetAllPharmacyAdminOrders)
@@ -291,10 +420,13 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 		)
 		{
 			pharmacyProducts.GET("", rOpts.PharmacyProductsHandler.GetAllByPharmacy)
+			pharmacyProducts.GET("/export", rOpts.PharmacyProductsHandler.Export)
+			pharmacyProducts.POST("/import", rOpts.PharmacyProductsHandler.Import)
 			pharmacyProducts.GET("/:id", rOpts.PharmacyProductsHandler.GetById)
 			pharmacyProducts.POST("", rOpts.PharmacyProductsHandler.Add)
 			pharmacyProducts.PUT("/:id", rOpts.PharmacyProductsHandler.Edit)
 			pharmacyProducts.GET("/:id/request", rOpts.PharmacyProductsHandler.GetAllByProductId)
+			pharmacyProducts.GET("/:id/price-histories", rOpts.PharmacyProductsHandler.GetAllPriceHistories)
 		}
 
 		prescriptions := v1.Group("/prescriptions")
@@ -317,12 +449,43 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
 				rOpts.PrescriptionHandler.EditBySessionId,
 			)
+			prescriptions.POST(
+				"/:sessionId/redeem",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdUser),
+				rOpts.PrescriptionHandler.RedeemBySessionId,
+			)
+		}
+
+		prescriptionTemplates := v1.Group(
+			"/prescription-templates",
+			middleware.LoginMiddleware(),
+			middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+		)
+		{
+			prescriptionTemplates.GET("", rOpts.PrescriptionTemplateHandler.GetAllMine)
+			prescriptionTemplates.POST("", rOpts.PrescriptionTemplateHandler.Add)
+			prescriptionTemplates.GET("/favorite-products", rOpts.PrescriptionTemplateHandler.GetAllFavoriteProducts)
+			prescriptionTemplates.POST("/favorite-products/:id", rOpts.PrescriptionTemplateHandler.AddFavoriteProduct)
+			prescriptionTemplates.DELETE("/favorite-products/:id", rOpts.PrescriptionTemplateHandler.RemoveFavoriteProduct)
+			prescriptionTemplates.GET("/:id", rOpts.PrescriptionTemplateHandler.GetById)
+			prescriptionTemplates.PUT("/:id", rOpts.PrescriptionTemplateHandler.Edit)
+			prescriptionTemplates.DELETE("/:id", rOpts.PrescriptionTemplateHandler.Remove)
+			prescriptionTemplates.POST("/:id/apply", rOpts.PrescriptionTemplateHandler.Apply)
+		}
+
+		productAlerts := v1.Group("/product-alerts", middleware.LoginMiddleware(), middleware.AllowRoles(appconstant.UserRoleIdUser))
+		{
+			productAlerts.GET("", rOpts.ProductAlertHandler.GetAllMine)
+			productAlerts.POST("", rOpts.ProductAlertHandler.Add)
+			productAlerts.DELETE("/:id", rOpts.ProductAlertHandler.Remove)
 		}
 
 		productCategories := v1.Group("/product-categories")
 		{
 			productCategories.GET("/:id", rOpts.ProductCategoryHandler.GetById)
 			productCategories.GET("/no-params", rOpts.ProductCategoryHandler.GetAllWithoutParams)
+			productCategories.GET("/tree", rOpts.ProductCategoryHandler.GetTree)
 			productCategories.GET("", rOpts.ProductCategoryHandler.GetAll)
 			productCategories.POST(
 				"",
@@ -336,6 +499,12 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
 				rOpts.ProductCategoryHandler.Edit,
 			)
+			productCategories.PUT(
+				"/:id/parent",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
+				rOpts.ProductCategoryHandler.Move,
+			)
 			productCategories.DELETE(
 				"/:id",
 				middleware.LoginMiddleware(),
@@ -344,6 +513,17 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 			)
 		}
 
+		productImports := v1.Group(
+			"/product-imports",
+			middleware.LoginMiddleware(),
+			middleware.AllowRoles(appconstant.UserRoleIdAdmin),
+		)
+		{
+			productImports.GET("", rOpts.ProductImportHandler.GetAllMine)
+			productImports.POST("", rOpts.ProductImportHandler.Add)
+			productImports.GET("/:id", rOpts.ProductImportHandler.GetById)
+		}
+
 		products := v1.Group("/products")
 		{
 			products.GET(
@@ -378,6 +558,49 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
 				rOpts.ProductHandler.Remove,
 			)
+			products.POST(
+				"/:id/images",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
+				rOpts.ProductImageHandler.Add,
+			)
+			products.PUT(
+				"/:id/images/order",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
+				rOpts.ProductImageHandler.Reorder,
+			)
+			products.DELETE(
+				"/:id/images/:imageId",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdAdmin),
+				rOpts.ProductImageHandler.Remove,
+			)
+			products.GET("/:id/reviews", rOpts.ProductReviewHandler.GetAllByProductId)
+		}
+
+		productReviews := v1.Group("/product-reviews", middleware.LoginMiddleware())
+		{
+			productReviews.POST("", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.ProductReviewHandler.Add)
+			productReviews.GET("", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.ProductReviewHandler.GetAll)
+			productReviews.PUT("/:id/status", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.ProductReviewHandler.EditStatus)
+		}
+
+		referrals := v1.Group("/referrals", middleware.LoginMiddleware())
+		{
+			referrals.GET("", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.ReferralHandler.GetAllMine)
+			referrals.POST("", middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.ReferralHandler.Add)
+			referrals.GET(
+				"/sessions/:sessionId",
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor),
+				rOpts.ReferralHandler.GetByReferredSessionId,
+			)
+			referrals.GET(
+				"/:id",
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor, appconstant.UserRoleIdUser),
+				rOpts.ReferralHandler.GetById,
+			)
+			referrals.POST("/:id/sessions", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.ReferralHandler.AddSession)
 		}
 
 		report := v1.Group(
@@ -398,6 +621,9 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 			sellReport.GET("/sells/monthly", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.ReportHandler.GetAllSellPharmacyMonthly)
 			sellReport.GET("/sells/pharmacy-admin", middleware.AllowRoles(appconstant.UserRoleIdPharmacyAdmin), rOpts.ReportHandler.GetAllSellsPharmacyAdmin)
 			sellReport.GET("/sells/monthly/pharmacy-admin", middleware.AllowRoles(appconstant.UserRoleIdPharmacyAdmin), rOpts.ReportHandler.GetAllSellPharmacyAdminMonthly)
+			sellReport.GET("/sell-prices", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.ReportHandler.GetAllSellPrice)
+			sellReport.GET("/sell-prices/pharmacy-admin", middleware.AllowRoles(appconstant.UserRoleIdPharmacyAdmin), rOpts.ReportHandler.GetAllSellPricePharmacyAdmin)
+			sellReport.GET("/pharmacy-products/:id/price-histories", middleware.AllowRoles(appconstant.UserRoleIdAdmin), rOpts.PharmacyProductsHandler.GetAllPriceHistories)
 		}
 
 		shippingMethod := v1.Group(
@@ -476,12 +702,17 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 					middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.ProfileHandler.GetProfile)
 				profileDoctor.PUT("", middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.ProfileHandler.EditDoctorProfile)
 				profileDoctor.POST("/set-online", middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.ProfileHandler.EditDoctorIsOnline)
+				profileDoctor.GET("/patients/:userId/medical", middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.MedicalProfileHandler.GetByUserId)
+				profileDoctor.GET("/dependents/:id/medical", middleware.AllowRoles(appconstant.UserRoleIdDoctor), rOpts.MedicalProfileHandler.GetByDependentId)
 			}
 			profileUser := profile.Group("/user")
 			{
 				profileUser.GET("",
 					middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.ProfileHandler.GetProfile)
 				profileUser.PUT("", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.ProfileHandler.EditUserProfile)
+				profileUser.GET("/medical", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.MedicalProfileHandler.GetMine)
+				profileUser.PUT("/medical", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.MedicalProfileHandler.Edit)
+				profileUser.POST("/medical/measurements", middleware.AllowRoles(appconstant.UserRoleIdUser), rOpts.MedicalProfileHandler.AddMeasurement)
 			}
 			addressProfile := profile.Group("/addresses", middleware.AllowRoles(appconstant.UserRoleIdUser))
 			{
@@ -495,8 +726,15 @@ func NewRouter(rOpts *RouterOpts, ginMode string) *gin.Engine {
 			}
 		}
 
-		sickLeave := v1.Group("/sick-leave-forms")
+		sickLeave := v1.Group(appconstant.SickLeaveFormPath)
 		{
+			sickLeave.GET(appconstant.SickLeaveFormVerificationPath+":code", rOpts.SickLeaveFormHandler.Verify)
+			sickLeave.GET(
+				"/:sessionId/certificate",
+				middleware.LoginMiddleware(),
+				middleware.AllowRoles(appconstant.UserRoleIdDoctor, appconstant.UserRoleIdUser),
+				rOpts.SickLeaveFormHandler.GetCertificateBySessionId,
+			)
 			sickLeave.GET(
 				"/:sessionId",
 				middleware.LoginMiddleware(),
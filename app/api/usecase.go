@@ -9,28 +9,42 @@ type AllUseCases struct {
 	AddressAreaUseCase          usecase.AddressAreaUseCase
 	AuthUseCase                 usecase.AuthUsecase
 	CartItemUseCase             usecase.CartItemUseCase
+	ClinicalNoteUseCase         usecase.ClinicalNoteUseCase
 	ConsultationMessageUseCase  usecase.ConsultationMessageUseCase
 	ConsultationSessionUseCase  usecase.ConsultationSessionUseCase
+	SessionExtensionUseCase     usecase.ConsultationSessionExtensionUseCase
 	CronUseCase                 usecase.CronUseCase
+	DependentUseCase            usecase.DependentUseCase
 	DoctorSpecializationUseCase usecase.DoctorSpecializationUseCase
 	DrugClassificationUseCase   usecase.DrugClassificationUseCase
+	DrugInteractionUseCase      usecase.DrugInteractionUseCase
 	ForgotTokenUseCase          usecase.ForgotTokenUseCase
 	ManufacturerUseCase         usecase.ManufacturerUseCase
+	MedicalProfileUseCase       usecase.MedicalProfileUseCase
+	MedicalRecordUseCase        usecase.MedicalRecordUseCase
+	MedicationReminderUseCase   usecase.MedicationReminderUseCase
 	OrderUseCase                usecase.OrderUseCase
 	PharmacyUseCase             usecase.PharmacyUseCase
 	PharmacyProductUseCase      usecase.PharmacyProductUseCase
 	PrescriptionUseCase         usecase.PrescriptionUseCase
+	PrescriptionTemplateUseCase usecase.PrescriptionTemplateUseCase
 	ProductCategoryUseCase      usecase.ProductCategoryUseCase
+	ProductImageUseCase         usecase.ProductImageUseCase
+	ProductAlertUseCase         usecase.ProductAlertUseCase
+	ProductImportUseCase        usecase.ProductImportUseCase
 	ProductStockMutation        usecase.ProductStockMutationUseCase
 	ProductStockMutationRequest usecase.ProductStockMutationRequestUseCase
 	ProductUseCase              usecase.ProductUseCase
+	ProductReviewUseCase        usecase.ProductReviewUseCase
 	ProfileUseCase              usecase.ProfileUseCase
 	RegisterTokenUseCase        usecase.RegisterTokenUseCase
+	ReferralUseCase             usecase.ReferralUseCase
 	ReportUseCase               usecase.ReportUseCase
 	TransactionUseCase          usecase.TransactionUseCase
 	ShippingMethodUseCase       usecase.ShippingMethodUseCase
 	SickLeaveFormUseCase        usecase.SickLeaveFormUseCase
 	UserAddressUseCase          usecase.AddressUseCase
+	UserNotificationUseCase     usecase.UserNotificationUseCase
 	UserUseCase                 usecase.UserUseCase
 }
 
@@ -45,33 +59,50 @@ func InitializeUseCases(allRepo *AllRepositories, allUtil *AllUtil) *AllUseCases
 		ProfileRepo:   allRepo.ProfileRepository,
 	}
 	authCases := usecase.AuthUseCases{TForgotUseCase: forgotTokenUseCase, TRegisterUseCase: registerTokenUseCase}
+	consultationSessionUseCase := usecase.NewConsultationSessionUseCaseImpl(allRepo.ConsultationSessionRepository, allRepo.PrescriptionRepository, allRepo.SickLeaveFormRepository, allRepo.UserRepository, allRepo.DependentRepository, allRepo.ClinicalNoteRepository)
+	prescriptionUseCase := usecase.NewPrescriptionUseCaseImpl(allRepo.PrescriptionRepository, allRepo.ConsultationSessionRepository, allRepo.CartItemRepository, allRepo.ProductRepository, allRepo.DrugInteractionRepository, allRepo.MedicalProfileRepository)
+	productAlertUseCase := usecase.NewProductAlertUseCaseImpl(allRepo.ProductAlertRepository, allRepo.ProductRepository, allRepo.UserAddressRepository, allUtil.AlertNotifier)
 
 	return &AllUseCases{
 		AddressAreaUseCase:          usecase.NewAddressAreaUseCaseImpl(allRepo.AddressAreaRepository, allUtil.LocUtil),
 		AuthUseCase:                 usecase.NewAuthUsecase(authRepos, allUtil.AuthUtil, appcloud.AppFileUploader, authCases),
-		CartItemUseCase:             usecase.NewCartItemUseCaseImpl(allRepo.CartItemRepository, allRepo.ProductRepository, allRepo.PharmacyProductRepository),
-		CronUseCase:                 usecase.NewCronUseCase(allRepo.CronRepository),
+		CartItemUseCase:             usecase.NewCartItemUseCaseImpl(allRepo.CartItemRepository, allRepo.ProductRepository, allRepo.PharmacyProductRepository, allRepo.PrescriptionRepository, allRepo.ProductRecommendationRepository),
+		ClinicalNoteUseCase:         usecase.NewClinicalNoteUseCaseImpl(allRepo.ClinicalNoteRepository, allRepo.ConsultationSessionRepository),
+		CronUseCase:                 usecase.NewCronUseCase(allRepo.CronRepository, allRepo.MedicationReminderRepository, allRepo.ProductRecommendationRepository, allUtil.ReminderNotifier, consultationSessionUseCase, productAlertUseCase),
+		DependentUseCase:            usecase.NewDependentUseCaseImpl(allRepo.DependentRepository),
 		ConsultationMessageUseCase:  usecase.NewConsultationMessageUseCaseImpl(allRepo.ConsultationMessageRepository),
-		ConsultationSessionUseCase:  usecase.NewConsultationSessionUseCaseImpl(allRepo.ConsultationSessionRepository, allRepo.PrescriptionRepository, allRepo.SickLeaveFormRepository, allRepo.UserRepository),
+		ConsultationSessionUseCase:  consultationSessionUseCase,
+		SessionExtensionUseCase:     usecase.NewConsultationSessionExtensionUseCaseImpl(allRepo.SessionExtensionRepository, allRepo.ConsultationSessionRepository, appcloud.AppFileUploader),
 		DrugClassificationUseCase:   usecase.NewDrugClassificationUseCaseImpl(allRepo.DrugClassificationRepository),
+		DrugInteractionUseCase:      usecase.NewDrugInteractionUseCaseImpl(allRepo.DrugInteractionRepository),
 		DoctorSpecializationUseCase: usecase.NewDoctorSpecializationUseCaseImpl(allRepo.DoctorSpecializationRepository, appcloud.AppFileUploader),
 		ForgotTokenUseCase:          forgotTokenUseCase,
 		ManufacturerUseCase:         usecase.NewManufacturerUseCaseImpl(allRepo.ManufacturerRepository, appcloud.AppFileUploader),
+		MedicalProfileUseCase:       usecase.NewMedicalProfileUseCaseImpl(allRepo.MedicalProfileRepository, allRepo.ConsultationSessionRepository, allRepo.DependentRepository),
+		MedicalRecordUseCase:        usecase.NewMedicalRecordUseCaseImpl(allRepo.MedicalRecordRepository, allRepo.ConsultationSessionRepository, allRepo.ProfileRepository, allRepo.PrescriptionRepository),
+		MedicationReminderUseCase:   usecase.NewMedicationReminderUseCaseImpl(allRepo.MedicationReminderRepository, allRepo.PrescriptionRepository, allRepo.ProductRepository, allRepo.DependentRepository),
 		OrderUseCase:                usecase.NewOrderUseCaseImpl(allRepo.OrderRepository),
 		PharmacyUseCase:             usecase.NewPharmacyUseCaseImpl(allRepo.PharmacyRepository, allRepo.AddressAreaRepository),
 		PharmacyProductUseCase:      usecase.NewPharmacyProductUseCaseImpl(allRepo.PharmacyProductRepository, allRepo.PharmacyRepository, allRepo.ProductRepository),
-		PrescriptionUseCase:         usecase.NewPrescriptionUseCaseImpl(allRepo.PrescriptionRepository, allRepo.ConsultationSessionRepository),
+		PrescriptionUseCase:         prescriptionUseCase,
+		PrescriptionTemplateUseCase: usecase.NewPrescriptionTemplateUseCaseImpl(allRepo.PrescriptionTemplateRepository, allRepo.ProductRepository, prescriptionUseCase),
 		ProductCategoryUseCase:      usecase.NewProductCategoryUseCaseImpl(allRepo.ProductCategoryRepository),
-		ProductUseCase:              usecase.NewProductUseCaseImpl(allRepo.ProductRepository, allRepo.PharmacyRepository, appcloud.AppFileUploader),
+		ProductImageUseCase:         usecase.NewProductImageUseCaseImpl(allRepo.ProductImageRepository, allRepo.ProductRepository, appcloud.AppFileUploader, allUtil.ImageUtil),
+		ProductAlertUseCase:         productAlertUseCase,
+		ProductImportUseCase:        usecase.NewProductImportUseCaseImpl(allRepo.ProductImportRepository, allRepo.ProductRepository, allRepo.ManufacturerRepository, allRepo.DrugClassificationRepository, allRepo.ProductCategoryRepository),
+		ProductUseCase:              usecase.NewProductUseCaseImpl(allRepo.ProductRepository, allRepo.PharmacyRepository, allRepo.ProductCategoryRepository, allRepo.ProductImageRepository, allRepo.ProductRecommendationRepository, appcloud.AppFileUploader, allUtil.ImageUtil),
+		ProductReviewUseCase:        usecase.NewProductReviewUseCaseImpl(allRepo.ProductReviewRepository, allRepo.ProductRepository, appcloud.AppFileUploader, allUtil.ImageUtil),
 		ProductStockMutation:        usecase.NewProductStockMutationUseCaseImpl(allRepo.ProductStockMutationRepository, allRepo.PharmacyProductRepository, allRepo.PharmacyRepository),
 		ProductStockMutationRequest: usecase.NewProductStockMutationRequestUseCaseImpl(allRepo.ProductStockMutationRequestRepository, allRepo.PharmacyProductRepository, allRepo.PharmacyRepository),
 		ProfileUseCase:              usecase.NewProfileUseCaseImpl(allRepo.ProfileRepository, appcloud.AppFileUploader),
 		ShippingMethodUseCase:       usecase.NewShippingMethodUseCaseImpl(allRepo.ShippingMethodRepository, allRepo.UserAddressRepository, allRepo.AddressAreaRepository, allRepo.PharmacyProductRepository, allUtil.OngkirUtil),
-		SickLeaveFormUseCase:        usecase.NewSickLeaveFormUseCaseImpl(allRepo.SickLeaveFormRepository, allRepo.ConsultationSessionRepository, allRepo.PrescriptionRepository, allRepo.ConsultationMessageRepository),
+		SickLeaveFormUseCase:        usecase.NewSickLeaveFormUseCaseImpl(allRepo.SickLeaveFormRepository, allRepo.ConsultationSessionRepository, allRepo.PrescriptionRepository, allRepo.ConsultationMessageRepository, allUtil.SignUtil, allUtil.PdfUtil),
 		RegisterTokenUseCase:        registerTokenUseCase,
+		ReferralUseCase:             usecase.NewReferralUseCaseImpl(allRepo.ReferralRepository, allRepo.ConsultationSessionRepository, allRepo.ProfileRepository, allRepo.DoctorSpecializationRepository, allRepo.PrescriptionRepository, allRepo.ClinicalNoteRepository),
 		ReportUseCase:               usecase.NewReportUseCaseImpl(allRepo.ReportRepository),
-		TransactionUseCase:          usecase.NewTransactionUseCaseImpl(allRepo.TransactionRepository, allRepo.UserAddressRepository, allRepo.PharmacyProductRepository, appcloud.AppFileUploader),
+		TransactionUseCase:          usecase.NewTransactionUseCaseImpl(allRepo.TransactionRepository, allRepo.UserAddressRepository, allRepo.PharmacyProductRepository, allRepo.PrescriptionRepository, allRepo.DependentRepository, appcloud.AppFileUploader),
 		UserUseCase:                 usecase.NewUserUseCaseImpl(allRepo.UserRepository, allRepo.PharmacyRepository, allUtil.AuthUtil),
 		UserAddressUseCase:          usecase.NewAddressUseCaseImpl(allRepo.UserAddressRepository, allRepo.AddressAreaRepository, allUtil.LocUtil),
+		UserNotificationUseCase:     usecase.NewUserNotificationUseCaseImpl(allRepo.UserNotificationRepository),
 	}
 }
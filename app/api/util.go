@@ -1,21 +1,39 @@
 package api
 
 import (
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/util"
 )
 
 type AllUtil struct {
-	AuthUtil   util.AuthUtil
-	MailUtil   util.EmailUtil
-	LocUtil    util.LocationUtil
-	OngkirUtil util.OngkirUtil
+	AuthUtil         util.AuthUtil
+	MailUtil         util.EmailUtil
+	LocUtil          util.LocationUtil
+	OngkirUtil       util.OngkirUtil
+	SignUtil         util.SignatureUtil
+	PdfUtil          util.PdfUtil
+	ImageUtil        util.ImageUtil
+	ReminderNotifier util.ReminderNotifier
+	AlertNotifier    util.ProductAlertNotifier
 }
 
-func InitializeUtil() *AllUtil {
-	return &AllUtil{
-		AuthUtil:   util.NewAuthUtil(),
-		MailUtil:   util.NewEmailUtil(),
-		LocUtil:    util.NewLocationUtil("id"),
-		OngkirUtil: util.NewRajaOngkirUtil(),
+func InitializeUtil() (*AllUtil, error) {
+	signUtil, err := util.NewSignatureUtil(appconfig.Config.DocumentSigningKey)
+	if err != nil {
+		return nil, err
 	}
+
+	mailUtil := util.NewEmailUtil()
+	return &AllUtil{
+		AuthUtil:         util.NewAuthUtil(),
+		MailUtil:         mailUtil,
+		LocUtil:          util.NewLocationUtil("id"),
+		OngkirUtil:       util.NewRajaOngkirUtil(),
+		SignUtil:         signUtil,
+		PdfUtil:          util.NewPdfUtil(),
+		ImageUtil:        util.NewImageUtil(appconstant.ProductImageMaxPixels, appconstant.ProductImageJpegQuality),
+		ReminderNotifier: util.NewEmailReminderNotifier(mailUtil),
+		AlertNotifier:    util.NewEmailProductAlertNotifier(mailUtil),
+	}, nil
 }
@@ -20,6 +20,7 @@ type FileUploader interface {
 	SendToBucket(ctx context.Context, file multipart.File, object, path string) error
 	UploadFromFile(ctx context.Context, file *os.File, path, name string) (string, error)
 	UploadFromFileHeader(ctx context.Context, fileHeader any, folderName string) (string, error)
+	UploadFromBytes(ctx context.Context, data []byte, folderName, fileName, contentType string) (string, error)
 }
 
 func SetAppFileUploader(uploader FileUploader) {
@@ -116,3 +117,27 @@ func (f *FileUploaderImpl) UploadFromFileHeader(ctx context.Context, fileHeader
 	url := fmt.Sprintf("%s/%s/%s", f.cloudUrl, folderName, fileName)
 	return url, nil
 }
+
+func (f *FileUploaderImpl) UploadFromBytes(ctx context.Context, data []byte, folderName, fileName, contentType string) (string, error) {
+	bucketObject := f.client.Bucket(f.bucketName).Object(
+		fmt.Sprintf("%s/%s", folderName, fileName),
+	)
+	wc := bucketObject.NewWriter(ctx)
+	wc.ContentType = contentType
+	wc.ACL = []storage.ACLRule{
+		{
+			Entity: storage.AllUsers,
+			Role:   storage.RoleReader,
+		},
+	}
+
+	if _, err := wc.Write(data); err != nil {
+		return "", fmt.Errorf("Writer.Write: %v", err)
+	}
+	if err := wc.Close(); err != nil {
+		return "", fmt.Errorf("Writer.Close: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", f.cloudUrl, folderName, fileName)
+	return url, nil
+}
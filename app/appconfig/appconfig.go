@@ -30,6 +30,7 @@ type AppConfig struct {
 	MailSmtpPort string
 
 	FrontendUrl string
+	ApiUrl      string
 
 	RegisterTokenExpired string
 	LoginTokenExpired    string
@@ -53,6 +54,11 @@ type AppConfig struct {
 
 	JwtSecret string
 
+	DocumentSigningKey string
+
+	FieldEncryptionKeys        string
+	FieldEncryptionActiveKeyId string
+
 	RequestTimeout        string
 	ServerShutdownTimeout string
 }
@@ -81,6 +87,7 @@ func LoadConfig() error {
 		MailSmtpHost:                            os.Getenv("MAIL_SMTP_HOST"),
 		MailSmtpPort:                            os.Getenv("MAIL_SMTP_PORT"),
 		FrontendUrl:                             os.Getenv("FRONTEND_URL"),
+		ApiUrl:                                  os.Getenv("API_URL"),
 		RegisterTokenExpired:                    os.Getenv("REGISTER_TOKEN_EXPIRED_MINUTE"),
 		LoginTokenExpired:                       os.Getenv("LOGIN_TOKEN_EXPIRED_MINUTE"),
 		ForgotTokenExpired:                      os.Getenv("FORGOT_TOKEN_EXPIRED_MINUTE"),
@@ -99,6 +106,9 @@ func LoadConfig() error {
 		RajaongkirUrl:                           os.Getenv("RAJAONGKIR_URL"),
 		RajaongkirKey:                           os.Getenv("RAJAONGKIR_API_KEY"),
 		JwtSecret:                               os.Getenv("SECRET_JWT_KEY"),
+		DocumentSigningKey:                      os.Getenv("DOCUMENT_SIGNING_KEY"),
+		FieldEncryptionKeys:                     os.Getenv("FIELD_ENCRYPTION_KEYS"),
+		FieldEncryptionActiveKeyId:              os.Getenv("FIELD_ENCRYPTION_ACTIVE_KEY_ID"),
 		RequestTimeout:                          os.Getenv("REQUEST_TIMEOUT"),
 		ServerShutdownTimeout:                   os.Getenv("SERVER_SHUTDOWN_TIMEOUT"),
 	}
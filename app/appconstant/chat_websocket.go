@@ -10,8 +10,9 @@ const (
 	DataTypeImage       = "image"
 	DataTypeApplication = "application"
 
-	MessageTypeRegular = 1
-	MessageTypeAlert   = 2
+	MessageTypeRegular   = 1
+	MessageTypeAlert     = 2
+	MessageTypeCountdown = 3
 
 	MessageDoctorCreateLeaveSick = "Sick leave certificate has been issued"
 	MessageDoctorUpdateLeaveSick = "Sick leave certificate has been updated"
@@ -0,0 +1,25 @@
+package appconstant
+
+import "time"
+
+const (
+	ConsultationSessionExtensionStatusWaiting  = "waiting"
+	ConsultationSessionExtensionStatusAccepted = "accepted"
+	ConsultationSessionExtensionStatusRejected = "rejected"
+
+	DefaultConsultationSessionDurationMinutes = 30
+
+	// ConsultationSessionCountdownInterval is how often the remaining time is pushed to the connected clients
+	ConsultationSessionCountdownInterval = 30 * time.Second
+	// ConsultationSessionExpiryWarning is how long before the expiry both sides are warned once
+	ConsultationSessionExpiryWarning = 5 * time.Minute
+	// ConsultationSessionExtensionWaitingWindow is how long a waiting extension keeps an expired session open before
+	// it is rejected on the doctor's behalf
+	ConsultationSessionExtensionWaitingWindow = 15 * time.Minute
+
+	MessageConsultationSessionExpiring          = "Consultation session ends in 5 minutes, the patient can request a paid extension"
+	MessageConsultationSessionExpired           = "Consultation session time is up"
+	MessageConsultationSessionExtensionRequest  = "Patient requested a paid session extension"
+	MessageConsultationSessionExtensionAccepted = "Session extension has been accepted"
+	MessageConsultationSessionExtensionRejected = "Session extension has been rejected"
+)
@@ -1,5 +1,6 @@
 package appconstant
 
 const (
-	CronDailyTimer = "@daily"
+	CronDailyTimer       = "@daily"
+	CronEveryMinuteTimer = "@every 1m"
 )
@@ -0,0 +1,13 @@
+package appconstant
+
+const (
+	SickLeaveCertificateNumberFormat        = "SLF/%s/%06d"
+	SickLeaveCertificateNumberDateFormat    = "20060102"
+	SickLeaveCertificateVerificationSep     = "."
+	SickLeaveCertificateVerificationUrlPath = "%s" + ApiV1Path + SickLeaveFormPath + SickLeaveFormVerificationPath + "%s"
+	SickLeaveCertificateFileName            = "sick-leave-certificate-%d.pdf"
+
+	ContentTypePdf  = "application/pdf"
+	ContentTypeCsv  = "text/csv"
+	ContentTypeJpeg = "image/jpeg"
+)
@@ -0,0 +1,19 @@
+package appconstant
+
+const (
+	DrugInteractionSeverityIdMinor    = 1
+	DrugInteractionSeverityIdModerate = 2
+	DrugInteractionSeverityIdSevere   = 3
+
+	PrescriptionWarningTypeInteraction      = "interaction"
+	PrescriptionWarningTypeDuplicateGeneric = "duplicate_generic"
+	PrescriptionWarningTypeAllergy          = "allergy"
+
+	DrugInteractionImportColumnSize = 4
+)
+
+var DrugInteractionSeverityNames = map[int64]string{
+	DrugInteractionSeverityIdMinor:    "Minor",
+	DrugInteractionSeverityIdModerate: "Moderate",
+	DrugInteractionSeverityIdSevere:   "Severe",
+}
@@ -0,0 +1,29 @@
+package appconstant
+
+const (
+	ContentTypeFhirJson = "application/fhir+json"
+
+	FhirBundleTypeCollection = "collection"
+
+	FhirCodeSystemActCode           = "http://terminology.hl7.org/CodeSystem/v3-ActCode"
+	FhirCodeSystemConditionClinical = "http://terminology.hl7.org/CodeSystem/condition-clinical"
+	FhirCodeSystemConditionVerStat  = "http://terminology.hl7.org/CodeSystem/condition-ver-status"
+	FhirCodeSystemConditionCategory = "http://terminology.hl7.org/CodeSystem/condition-category"
+	FhirCodeSystemUcum              = "http://unitsofmeasure.org"
+
+	FhirEncounterStatusInProgress = "in-progress"
+	FhirEncounterStatusFinished   = "finished"
+	FhirEncounterClassVirtual     = "VR"
+
+	FhirMedicationRequestStatusActive    = "active"
+	FhirMedicationRequestStatusCompleted = "completed"
+	FhirMedicationRequestStatusStopped   = "stopped"
+	FhirMedicationRequestIntentOrder     = "order"
+)
+
+// FhirMealTimingEvents maps prescription meal timings to FHIR event timing codes
+var FhirMealTimingEvents = map[string]string{
+	PrescriptionMealTimingBefore: "AC",
+	PrescriptionMealTimingAfter:  "PC",
+	PrescriptionMealTimingWith:   "C",
+}
@@ -0,0 +1,13 @@
+package appconstant
+
+const FieldReEncryptionBatchSize = 500
+
+// EncryptedColumns lists every column that is stored with field encryption, keyed by table name
+var EncryptedColumns = map[string][]string{
+	"prescriptions":          {"symptoms", "diagnosis"},
+	"sick_leave_forms":       {"description"},
+	"consultation_messages":  {"message"},
+	"clinical_notes":         {"subjective", "objective", "assessment", "plan"},
+	"clinical_note_versions": {"subjective", "objective", "assessment", "plan"},
+	"referrals":              {"clinical_summary"},
+}
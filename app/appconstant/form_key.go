@@ -5,4 +5,5 @@ const (
 	FormCertificate  = "certificate"
 	FormProfilePhoto = "profile_photo"
 	FormPaymentProof = "payment_proof"
+	FormFile         = "file"
 )
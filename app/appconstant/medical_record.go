@@ -0,0 +1,15 @@
+package appconstant
+
+const (
+	MedicalRecordTypeConsultation = "consultation"
+	MedicalRecordTypePrescription = "prescription"
+	MedicalRecordTypeSickLeave    = "sick_leave"
+	MedicalRecordTypeOrder        = "order"
+)
+
+var MedicalRecordTypes = map[string]bool{
+	MedicalRecordTypeConsultation: true,
+	MedicalRecordTypePrescription: true,
+	MedicalRecordTypeSickLeave:    true,
+	MedicalRecordTypeOrder:        true,
+}
@@ -0,0 +1,22 @@
+package appconstant
+
+import "time"
+
+const (
+	MedicationReminderDoseStatusPending = "pending"
+	MedicationReminderDoseStatusTaken   = "taken"
+	MedicationReminderDoseStatusSkipped = "skipped"
+
+	MedicationReminderTimeFormat      = "15:04"
+	MedicationReminderTimesSeparator  = ","
+	MedicationReminderMaxDurationDays = 90
+
+	// doses from a prescription are spread evenly between the first and last dose of the day
+	MedicationReminderFirstDoseMinute = 8 * 60
+	MedicationReminderLastDoseMinute  = 22 * 60
+
+	// MedicationReminderNotifyWindow stops the cron from sending reminders for doses that are long overdue
+	MedicationReminderNotifyWindow = time.Hour
+	// MedicationReminderEarlyLogWindow is how long before its schedule a dose can already be logged
+	MedicationReminderEarlyLogWindow = time.Hour
+)
@@ -0,0 +1,15 @@
+package appconstant
+
+const (
+	PharmacyProductImportRowStatusCreated   = "created"
+	PharmacyProductImportRowStatusUpdated   = "updated"
+	PharmacyProductImportRowStatusUnchanged = "unchanged"
+	PharmacyProductImportRowStatusFailed    = "failed"
+
+	PharmacyProductImportMaxRows = 5000
+
+	PharmacyInventoryFileName = "pharmacy-%d-inventory.csv"
+)
+
+// PharmacyInventoryColumns are the columns of an exported inventory, the same file can be imported back
+var PharmacyInventoryColumns = []string{"product_id", "product_code", "product_name", "price", "stock", "is_active"}
@@ -0,0 +1,7 @@
+package appconstant
+
+const (
+	PharmacyProductPriceSourceInitial = "initial"
+	PharmacyProductPriceSourceManual  = "manual"
+	PharmacyProductPriceSourceImport  = "import"
+)
@@ -0,0 +1,46 @@
+package appconstant
+
+const (
+	PrescriptionRouteOral       = "oral"
+	PrescriptionRouteSublingual = "sublingual"
+	PrescriptionRouteTopical    = "topical"
+	PrescriptionRouteInhalation = "inhalation"
+	PrescriptionRouteNasal      = "nasal"
+	PrescriptionRouteOphthalmic = "ophthalmic"
+	PrescriptionRouteOtic       = "otic"
+	PrescriptionRouteRectal     = "rectal"
+	PrescriptionRouteVaginal    = "vaginal"
+	PrescriptionRouteInjection  = "injection"
+
+	PrescriptionMealTimingBefore = "before_meal"
+	PrescriptionMealTimingAfter  = "after_meal"
+	PrescriptionMealTimingWith   = "with_meal"
+	PrescriptionMealTimingAny    = "any"
+
+	PrescriptionMaxFrequencyPerDay = 24
+	PrescriptionValidityDays       = 30
+
+	// PrescriptionDefaultQuantity is how many a product prescribed without a quantity can be redeemed for, the same as
+	// the default of the column
+	PrescriptionDefaultQuantity = 1
+)
+
+var PrescriptionRouteInstructions = map[string]string{
+	PrescriptionRouteOral:       "by mouth",
+	PrescriptionRouteSublingual: "under the tongue",
+	PrescriptionRouteTopical:    "on the skin",
+	PrescriptionRouteInhalation: "by inhalation",
+	PrescriptionRouteNasal:      "in the nose",
+	PrescriptionRouteOphthalmic: "in the eye",
+	PrescriptionRouteOtic:       "in the ear",
+	PrescriptionRouteRectal:     "rectally",
+	PrescriptionRouteVaginal:    "vaginally",
+	PrescriptionRouteInjection:  "by injection",
+}
+
+var PrescriptionMealTimingInstructions = map[string]string{
+	PrescriptionMealTimingBefore: "before meals",
+	PrescriptionMealTimingAfter:  "after meals",
+	PrescriptionMealTimingWith:   "with meals",
+	PrescriptionMealTimingAny:    "",
+}
@@ -0,0 +1,13 @@
+package appconstant
+
+const (
+	DrugClassificationIdObatBebas         = 1
+	DrugClassificationIdObatKeras         = 2
+	DrugClassificationIdObatBebasTerbatas = 3
+	DrugClassificationIdNonObat           = 4
+)
+
+const ProductCodeUniqueIndex = "products_code_idx"
+
+// ProductPriceFacetBounds splits pharmacy product prices, in rupiah, into the buckets returned as search facets
+var ProductPriceFacetBounds = []int64{25000, 50000, 100000, 250000}
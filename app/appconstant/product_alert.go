@@ -0,0 +1,10 @@
+package appconstant
+
+const (
+	ProductAlertBackInStockTitle = "%s is available near you"
+	ProductAlertBackInStockBody  = "%s is back in stock at pharmacies around your main address, starting from Rp %s."
+	ProductAlertPriceDropTitle   = "%s is cheaper near you"
+	ProductAlertPriceDropBody    = "The lowest price of %s around your main address dropped from Rp %s to Rp %s."
+
+	UserNotificationsLimit = 50
+)
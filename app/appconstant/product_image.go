@@ -0,0 +1,13 @@
+package appconstant
+
+const (
+	// ProductImageMaxPixels bounds the memory of an upload, the decoded image and its flattened copy take 4 bytes a pixel each
+	ProductImageMaxPixels       = 16_000_000
+	ProductImageJpegQuality     = 85
+	ProductImageOriginalMaxSize = 2048
+	ProductImageMediumMaxSize   = 600
+	ProductImageThumbnailSize   = 200
+	ProductImageExtension       = ".jpg"
+	ProductImageMediumSuffix    = "-medium"
+	ProductImageThumbnailSuffix = "-thumbnail"
+)
@@ -0,0 +1,24 @@
+package appconstant
+
+import "time"
+
+const (
+	ProductImportStatusProcessing = "processing"
+	ProductImportStatusCompleted  = "completed"
+	ProductImportStatusFailed     = "failed"
+
+	ProductImportRowStatusCreated = "created"
+	ProductImportRowStatusValid   = "valid"
+	ProductImportRowStatusFailed  = "failed"
+
+	ProductImportMaxRows = 5000
+	// ProductImportProgressBatchSize is how many rows are processed between two progress updates of an import
+	ProductImportProgressBatchSize  = 25
+	ProductImportRowErrorsSeparator = "\n"
+	// ProductImportCategoryPathSeparator joins the names of a category and its ancestors, e.g. "Obat > Vitamin"
+	ProductImportCategoryPathSeparator = " > "
+	// ProductImportStaleAfter is how long a processing import may go without progress before it is marked as failed,
+	// e.g. after the server restarted in the middle of it
+	ProductImportStaleAfter        = time.Hour
+	ProductImportStaleErrorMessage = "import stopped making progress before it finished"
+)
@@ -0,0 +1,8 @@
+package appconstant
+
+const (
+	ProductCoPurchaseMinCount      = 2
+	ProductCoPurchaseMaxPerProduct = 20
+	RelatedProductsLimit           = 8
+	CartSuggestionsLimit           = 8
+)
@@ -0,0 +1,9 @@
+package appconstant
+
+const (
+	ProductReviewStatusPublished = "published"
+	ProductReviewStatusHidden    = "hidden"
+
+	ProductReviewPhotoMaxCount = 3
+	ProductReviewPhotoMaxSize  = 1024
+)
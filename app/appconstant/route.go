@@ -0,0 +1,8 @@
+package appconstant
+
+const (
+	ApiV1Path = "/v1"
+
+	SickLeaveFormPath             = "/sick-leave-forms"
+	SickLeaveFormVerificationPath = "/verify/"
+)
@@ -0,0 +1,191 @@
+package appcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var AppFieldEncryptor FieldEncryptor
+
+// FieldEncryptor encrypts single column values with envelope encryption, every value gets its own data key which is
+// wrapped by a versioned key encryption key so keys can be rotated without losing access to older values
+type FieldEncryptor interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(value string) (string, error)
+	IsEncryptedWithActiveKey(value string) bool
+}
+
+func SetAppFieldEncryptor(encryptor FieldEncryptor) {
+	AppFieldEncryptor = encryptor
+}
+
+const (
+	envelopePrefix    = "enc"
+	envelopeVersion   = "v1"
+	envelopeParts     = 5
+	envelopeSeparator = ":"
+	dataKeySize       = 32
+)
+
+var (
+	ErrFieldEncryptionNoKeys        = errors.New("field encryption keys are not configured")
+	ErrFieldEncryptionInvalidKey    = errors.New("field encryption key must be a base64 encoded 32 bytes key")
+	ErrFieldEncryptionUnknownKeyId  = errors.New("field encryption key id is not configured")
+	ErrFieldEncryptionMalformedData = errors.New("encrypted field value is malformed")
+)
+
+type FieldEncryptorImpl struct {
+	keys        map[string][]byte
+	activeKeyId string
+}
+
+// NewFieldEncryptorImpl parses keys in the "keyId:base64Key,keyId:base64Key" format, new values are always encrypted
+// with the active key id
+func NewFieldEncryptorImpl(keys string, activeKeyId string) (*FieldEncryptorImpl, error) {
+	parsedKeys := make(map[string][]byte)
+	for _, keyPair := range strings.Split(keys, ",") {
+		keyPair = strings.TrimSpace(keyPair)
+		if keyPair == "" {
+			continue
+		}
+
+		keyId, encodedKey, found := strings.Cut(keyPair, envelopeSeparator)
+		if !found || keyId == "" {
+			return nil, ErrFieldEncryptionInvalidKey
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil || len(key) != dataKeySize {
+			return nil, ErrFieldEncryptionInvalidKey
+		}
+		parsedKeys[keyId] = key
+	}
+
+	if len(parsedKeys) == 0 {
+		return nil, ErrFieldEncryptionNoKeys
+	}
+	if _, ok := parsedKeys[activeKeyId]; !ok {
+		return nil, ErrFieldEncryptionUnknownKeyId
+	}
+
+	return &FieldEncryptorImpl{keys: parsedKeys, activeKeyId: activeKeyId}, nil
+}
+
+// Encrypt returns "enc:v1:<keyId>:<wrapped data key>:<ciphertext>", empty values are kept empty
+func (e *FieldEncryptorImpl) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	wrappedDataKey, err := seal(e.keys[e.activeKeyId], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		envelopePrefix,
+		envelopeVersion,
+		e.activeKeyId,
+		base64.StdEncoding.EncodeToString(wrappedDataKey),
+		base64.StdEncoding.EncodeToString(ciphertext),
+	}, envelopeSeparator), nil
+}
+
+// Decrypt returns values that were stored before encryption was enabled as they are
+func (e *FieldEncryptorImpl) Decrypt(value string) (string, error) {
+	if !isEnvelope(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(value, envelopeSeparator)
+	if len(parts) != envelopeParts {
+		return "", ErrFieldEncryptionMalformedData
+	}
+
+	keyEncryptionKey, ok := e.keys[parts[2]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrFieldEncryptionUnknownKeyId, parts[2])
+	}
+
+	wrappedDataKey, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", ErrFieldEncryptionMalformedData
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return "", ErrFieldEncryptionMalformedData
+	}
+
+	dataKey, err := open(keyEncryptionKey, wrappedDataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func (e *FieldEncryptorImpl) IsEncryptedWithActiveKey(value string) bool {
+	if value == "" {
+		return true
+	}
+	return strings.HasPrefix(value, strings.Join([]string{envelopePrefix, envelopeVersion, e.activeKeyId}, envelopeSeparator)+envelopeSeparator)
+}
+
+func isEnvelope(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix+envelopeSeparator+envelopeVersion+envelopeSeparator)
+}
+
+// seal encrypts with AES-256-GCM and prepends the random nonce to the ciphertext
+func seal(key []byte, plaintext []byte) ([]byte, error) {
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key []byte, sealed []byte) ([]byte, error) {
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrFieldEncryptionMalformedData
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+func newAead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
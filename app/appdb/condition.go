@@ -17,7 +17,11 @@ const (
 	NotLike          DBCondition = "NOT LIKE"
 	ILike            DBCondition = "ILIKE"
 	NotILike         DBCondition = "NOT ILIKE"
+	Overlap          DBCondition = "&&"
 	OrderAsc         DBCondition = "ASC"
 	OrderDesc        DBCondition = "DESC"
 	Null             DBCondition = "NULL"
+
+	// OrderDescNullsLast keeps rows without a value at the end, postgres puts nulls first when sorting descending
+	OrderDescNullsLast DBCondition = "DESC NULLS LAST"
 )
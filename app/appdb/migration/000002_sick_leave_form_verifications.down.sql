@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sick_leave_form_verifications;
@@ -0,0 +1,11 @@
+CREATE TABLE sick_leave_form_verifications
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    sick_leave_form_id BIGINT                    NOT NULL REFERENCES sick_leave_forms (id),
+    is_valid           BOOL                      NOT NULL,
+    ip_address         VARCHAR                   NOT NULL,
+    user_agent         VARCHAR                   NOT NULL,
+    created_at         TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at         TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at         TIMESTAMPTZ DEFAULT NULL
+);
@@ -0,0 +1,8 @@
+ALTER TABLE prescription_products
+    DROP COLUMN IF EXISTS quantity,
+    DROP COLUMN IF EXISTS dose_amount,
+    DROP COLUMN IF EXISTS dose_unit,
+    DROP COLUMN IF EXISTS frequency,
+    DROP COLUMN IF EXISTS route,
+    DROP COLUMN IF EXISTS duration_days,
+    DROP COLUMN IF EXISTS meal_timing;
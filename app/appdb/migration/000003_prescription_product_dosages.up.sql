@@ -0,0 +1,8 @@
+ALTER TABLE prescription_products
+    ADD COLUMN quantity      INT     DEFAULT 1     NOT NULL,
+    ADD COLUMN dose_amount   NUMERIC DEFAULT 1     NOT NULL,
+    ADD COLUMN dose_unit     VARCHAR DEFAULT ''    NOT NULL,
+    ADD COLUMN frequency     INT     DEFAULT 1     NOT NULL, -- times per day
+    ADD COLUMN route         VARCHAR DEFAULT 'oral' NOT NULL,
+    ADD COLUMN duration_days INT     DEFAULT 1     NOT NULL,
+    ADD COLUMN meal_timing   VARCHAR DEFAULT 'any' NOT NULL;
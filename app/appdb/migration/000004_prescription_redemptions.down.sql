@@ -0,0 +1,11 @@
+ALTER TABLE order_details
+    DROP COLUMN IF EXISTS prescription_product_id;
+
+ALTER TABLE cart_items
+    DROP COLUMN IF EXISTS prescription_product_id;
+
+ALTER TABLE prescription_products
+    DROP COLUMN IF EXISTS redeemed_quantity;
+
+ALTER TABLE prescriptions
+    DROP COLUMN IF EXISTS expired_at;
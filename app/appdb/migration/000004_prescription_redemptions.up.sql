@@ -0,0 +1,11 @@
+ALTER TABLE prescriptions
+    ADD COLUMN expired_at TIMESTAMPTZ DEFAULT (now() + INTERVAL '30 day') NOT NULL;
+
+ALTER TABLE prescription_products
+    ADD COLUMN redeemed_quantity INT DEFAULT 0 NOT NULL;
+
+ALTER TABLE cart_items
+    ADD COLUMN prescription_product_id BIGINT NULL REFERENCES prescription_products (id) ON DELETE SET NULL;
+
+ALTER TABLE order_details
+    ADD COLUMN prescription_product_id BIGINT NULL REFERENCES prescription_products (id) ON DELETE SET NULL;
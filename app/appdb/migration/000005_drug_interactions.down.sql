@@ -0,0 +1,5 @@
+ALTER TABLE prescriptions
+    DROP COLUMN IF EXISTS interaction_override_reason;
+
+DROP TABLE IF EXISTS drug_interactions;
+DROP TABLE IF EXISTS drug_interaction_severities;
@@ -0,0 +1,32 @@
+CREATE TABLE drug_interaction_severities
+(
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR UNIQUE            NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+INSERT INTO drug_interaction_severities (name)
+values ('Minor'),
+       ('Moderate'),
+       ('Severe');
+
+-- generic names are stored lowercased with generic_name_a sorted before generic_name_b
+CREATE TABLE drug_interactions
+(
+    id                           BIGSERIAL PRIMARY KEY,
+    generic_name_a               VARCHAR                   NOT NULL,
+    generic_name_b               VARCHAR                   NOT NULL,
+    drug_interaction_severity_id BIGINT                    NOT NULL REFERENCES drug_interaction_severities (id),
+    description                  VARCHAR                   NOT NULL,
+    created_at                   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at                   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at                   TIMESTAMPTZ DEFAULT NULL,
+    CHECK (generic_name_a < generic_name_b)
+);
+
+CREATE UNIQUE INDEX drug_interactions_generic_names_idx ON drug_interactions (generic_name_a, generic_name_b) WHERE deleted_at IS NULL;
+
+ALTER TABLE prescriptions
+    ADD COLUMN interaction_override_reason VARCHAR DEFAULT '' NOT NULL;
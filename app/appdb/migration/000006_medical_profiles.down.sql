@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS medical_profile_measurements;
+DROP TABLE IF EXISTS medical_profile_medications;
+DROP TABLE IF EXISTS medical_profile_conditions;
+DROP TABLE IF EXISTS medical_profile_allergies;
+DROP TABLE IF EXISTS medical_profiles;
@@ -0,0 +1,57 @@
+CREATE TABLE medical_profiles
+(
+    user_id    BIGINT PRIMARY KEY REFERENCES user_profiles (user_id),
+    blood_type VARCHAR     DEFAULT ''    NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+-- generic_name is stored lowercased so it can be matched against products.generic_name
+CREATE TABLE medical_profile_allergies
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                    NOT NULL REFERENCES medical_profiles (user_id),
+    allergen     VARCHAR                   NOT NULL,
+    generic_name VARCHAR     DEFAULT ''    NOT NULL,
+    reaction     VARCHAR     DEFAULT ''    NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at   TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE TABLE medical_profile_conditions
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                    NOT NULL REFERENCES medical_profiles (user_id),
+    name         VARCHAR                   NOT NULL,
+    diagnosed_at DATE        DEFAULT NULL,
+    note         VARCHAR     DEFAULT ''    NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at   TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE TABLE medical_profile_medications
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                    NOT NULL REFERENCES medical_profiles (user_id),
+    name         VARCHAR                   NOT NULL,
+    generic_name VARCHAR     DEFAULT ''    NOT NULL,
+    dosage       VARCHAR     DEFAULT ''    NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at   TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at   TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE TABLE medical_profile_measurements
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT                    NOT NULL REFERENCES medical_profiles (user_id),
+    height_cm   NUMERIC                   NOT NULL,
+    weight_kg   NUMERIC                   NOT NULL,
+    measured_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    created_at  TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at  TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at  TIMESTAMPTZ DEFAULT NULL
+);
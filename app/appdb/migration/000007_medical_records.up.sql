@@ -0,0 +1,9 @@
+-- patients grant the doctor of a session access to their medical record timeline
+CREATE TABLE medical_record_consents
+(
+    session_id BIGINT PRIMARY KEY REFERENCES consultation_sessions (id),
+    is_granted BOOL                      NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
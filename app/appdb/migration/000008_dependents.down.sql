@@ -0,0 +1,40 @@
+DELETE FROM medical_profile_measurements WHERE dependent_id IS NOT NULL;
+DELETE FROM medical_profile_medications WHERE dependent_id IS NOT NULL;
+DELETE FROM medical_profile_conditions WHERE dependent_id IS NOT NULL;
+DELETE FROM medical_profile_allergies WHERE dependent_id IS NOT NULL;
+DELETE FROM medical_profiles WHERE dependent_id IS NOT NULL;
+
+DROP INDEX IF EXISTS medical_profiles_patient_idx;
+
+ALTER TABLE medical_profiles
+    DROP COLUMN dependent_id,
+    DROP COLUMN id,
+    ADD PRIMARY KEY (user_id);
+
+ALTER TABLE medical_profile_measurements
+    DROP COLUMN dependent_id,
+    DROP CONSTRAINT medical_profile_measurements_user_id_fkey,
+    ADD CONSTRAINT medical_profile_measurements_user_id_fkey FOREIGN KEY (user_id) REFERENCES medical_profiles (user_id);
+
+ALTER TABLE medical_profile_medications
+    DROP COLUMN dependent_id,
+    DROP CONSTRAINT medical_profile_medications_user_id_fkey,
+    ADD CONSTRAINT medical_profile_medications_user_id_fkey FOREIGN KEY (user_id) REFERENCES medical_profiles (user_id);
+
+ALTER TABLE medical_profile_conditions
+    DROP COLUMN dependent_id,
+    DROP CONSTRAINT medical_profile_conditions_user_id_fkey,
+    ADD CONSTRAINT medical_profile_conditions_user_id_fkey FOREIGN KEY (user_id) REFERENCES medical_profiles (user_id);
+
+ALTER TABLE medical_profile_allergies
+    DROP COLUMN dependent_id,
+    DROP CONSTRAINT medical_profile_allergies_user_id_fkey,
+    ADD CONSTRAINT medical_profile_allergies_user_id_fkey FOREIGN KEY (user_id) REFERENCES medical_profiles (user_id);
+
+ALTER TABLE orders
+    DROP COLUMN dependent_id;
+
+ALTER TABLE consultation_sessions
+    DROP COLUMN dependent_id;
+
+DROP TABLE IF EXISTS dependents;
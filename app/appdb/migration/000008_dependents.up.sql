@@ -0,0 +1,48 @@
+-- dependents are family members who are treated under the account holder's login
+CREATE TABLE dependents
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT                    NOT NULL REFERENCES user_profiles (user_id),
+    name          VARCHAR                   NOT NULL,
+    date_of_birth TIMESTAMPTZ               NOT NULL,
+    relationship  VARCHAR                   NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at    TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at    TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX dependents_user_id_idx ON dependents (user_id);
+
+-- a NULL dependent_id means the account holder is the patient
+ALTER TABLE consultation_sessions
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE orders
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE medical_profile_allergies
+    DROP CONSTRAINT medical_profile_allergies_user_id_fkey,
+    ADD CONSTRAINT medical_profile_allergies_user_id_fkey FOREIGN KEY (user_id) REFERENCES user_profiles (user_id),
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE medical_profile_conditions
+    DROP CONSTRAINT medical_profile_conditions_user_id_fkey,
+    ADD CONSTRAINT medical_profile_conditions_user_id_fkey FOREIGN KEY (user_id) REFERENCES user_profiles (user_id),
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE medical_profile_medications
+    DROP CONSTRAINT medical_profile_medications_user_id_fkey,
+    ADD CONSTRAINT medical_profile_medications_user_id_fkey FOREIGN KEY (user_id) REFERENCES user_profiles (user_id),
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE medical_profile_measurements
+    DROP CONSTRAINT medical_profile_measurements_user_id_fkey,
+    ADD CONSTRAINT medical_profile_measurements_user_id_fkey FOREIGN KEY (user_id) REFERENCES user_profiles (user_id),
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+ALTER TABLE medical_profiles
+    DROP CONSTRAINT medical_profiles_pkey,
+    ADD COLUMN id BIGSERIAL PRIMARY KEY,
+    ADD COLUMN dependent_id BIGINT DEFAULT NULL REFERENCES dependents (id);
+
+CREATE UNIQUE INDEX medical_profiles_patient_idx ON medical_profiles (user_id, COALESCE(dependent_id, 0));
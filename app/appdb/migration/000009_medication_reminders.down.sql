@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS medication_reminder_doses;
+DROP TABLE IF EXISTS medication_reminders;
@@ -0,0 +1,35 @@
+-- a reminder schedule is either copied from a redeemed prescription product or entered by the patient
+CREATE TABLE medication_reminders
+(
+    id                      BIGSERIAL PRIMARY KEY,
+    user_id                 BIGINT                    NOT NULL REFERENCES user_profiles (user_id),
+    dependent_id            BIGINT      DEFAULT NULL REFERENCES dependents (id),
+    prescription_product_id BIGINT      DEFAULT NULL REFERENCES prescription_products (id),
+    medication_name         VARCHAR                   NOT NULL,
+    instruction             VARCHAR     DEFAULT ''    NOT NULL,
+    times_of_day            VARCHAR                   NOT NULL,
+    start_date              TIMESTAMPTZ               NOT NULL,
+    duration_days           INT                       NOT NULL,
+    created_at              TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at              TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at              TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX medication_reminders_user_id_idx ON medication_reminders (user_id);
+
+-- every dose of the schedule is stored up front so taken/skipped logs and adherence are plain aggregates
+CREATE TABLE medication_reminder_doses
+(
+    id                     BIGSERIAL PRIMARY KEY,
+    medication_reminder_id BIGINT                        NOT NULL REFERENCES medication_reminders (id),
+    scheduled_at           TIMESTAMPTZ                   NOT NULL,
+    status                 VARCHAR     DEFAULT 'pending' NOT NULL,
+    logged_at              TIMESTAMPTZ DEFAULT NULL,
+    notified_at            TIMESTAMPTZ DEFAULT NULL,
+    created_at             TIMESTAMPTZ DEFAULT now()     NOT NULL,
+    updated_at             TIMESTAMPTZ DEFAULT now()     NOT NULL
+);
+
+CREATE INDEX medication_reminder_doses_reminder_id_idx ON medication_reminder_doses (medication_reminder_id);
+CREATE INDEX medication_reminder_doses_due_idx ON medication_reminder_doses (scheduled_at)
+    WHERE status = 'pending' AND notified_at IS NULL;
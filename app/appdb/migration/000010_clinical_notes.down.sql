@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clinical_note_versions;
+DROP TABLE IF EXISTS clinical_notes;
@@ -0,0 +1,29 @@
+-- private SOAP notes of the treating doctor, never shown to the patient
+CREATE TABLE clinical_notes
+(
+    id         BIGSERIAL PRIMARY KEY,
+    session_id BIGINT                    NOT NULL UNIQUE REFERENCES consultation_sessions (id),
+    doctor_id  BIGINT                    NOT NULL REFERENCES doctor_profiles (user_id),
+    subjective VARCHAR     DEFAULT ''    NOT NULL,
+    objective  VARCHAR     DEFAULT ''    NOT NULL,
+    assessment VARCHAR     DEFAULT ''    NOT NULL,
+    plan       VARCHAR     DEFAULT ''    NOT NULL,
+    version    INT         DEFAULT 1     NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+-- every saved version of a note is kept, including the current one
+CREATE TABLE clinical_note_versions
+(
+    id               BIGSERIAL PRIMARY KEY,
+    clinical_note_id BIGINT                    NOT NULL REFERENCES clinical_notes (id),
+    version          INT                       NOT NULL,
+    subjective       VARCHAR     DEFAULT ''    NOT NULL,
+    objective        VARCHAR     DEFAULT ''    NOT NULL,
+    assessment       VARCHAR     DEFAULT ''    NOT NULL,
+    plan             VARCHAR     DEFAULT ''    NOT NULL,
+    edited_by        BIGINT                    NOT NULL REFERENCES doctor_profiles (user_id),
+    created_at       TIMESTAMPTZ DEFAULT now() NOT NULL,
+    UNIQUE (clinical_note_id, version)
+);
@@ -0,0 +1,20 @@
+-- a referral names a specialization, a specific doctor or both, the patient opens the referred session from it
+CREATE TABLE referrals
+(
+    id                       BIGSERIAL PRIMARY KEY,
+    session_id               BIGINT                    NOT NULL REFERENCES consultation_sessions (id),
+    referring_doctor_id      BIGINT                    NOT NULL REFERENCES doctor_profiles (user_id),
+    user_id                  BIGINT                    NOT NULL REFERENCES user_profiles (user_id),
+    dependent_id             BIGINT      DEFAULT NULL REFERENCES dependents (id),
+    doctor_specialization_id BIGINT      DEFAULT NULL REFERENCES doctor_specializations (id),
+    target_doctor_id         BIGINT      DEFAULT NULL REFERENCES doctor_profiles (user_id),
+    clinical_summary         VARCHAR                   NOT NULL,
+    referred_session_id      BIGINT      DEFAULT NULL UNIQUE REFERENCES consultation_sessions (id),
+    created_at               TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at               TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at               TIMESTAMPTZ DEFAULT NULL,
+    CHECK (doctor_specialization_id IS NOT NULL OR target_doctor_id IS NOT NULL)
+);
+
+CREATE INDEX referrals_session_id_idx ON referrals (session_id);
+CREATE INDEX referrals_user_id_idx ON referrals (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS doctor_favorite_products;
+DROP TABLE IF EXISTS prescription_template_products;
+DROP TABLE IF EXISTS prescription_templates;
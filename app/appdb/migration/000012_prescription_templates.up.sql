@@ -0,0 +1,40 @@
+-- templates are private to the doctor who saved them
+CREATE TABLE prescription_templates
+(
+    id         BIGSERIAL PRIMARY KEY,
+    doctor_id  BIGINT                    NOT NULL REFERENCES doctor_profiles (user_id),
+    name       VARCHAR                   NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX prescription_templates_doctor_id_name_idx ON prescription_templates (doctor_id, lower(name))
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE prescription_template_products
+(
+    id                       BIGSERIAL PRIMARY KEY,
+    prescription_template_id BIGINT                    NOT NULL REFERENCES prescription_templates (id),
+    product_id               BIGINT                    NOT NULL REFERENCES products (id),
+    note                     VARCHAR     DEFAULT ''    NOT NULL,
+    quantity                 INT                       NOT NULL,
+    dose_amount              NUMERIC                   NOT NULL,
+    dose_unit                VARCHAR                   NOT NULL,
+    frequency                INT                       NOT NULL,
+    route                    VARCHAR                   NOT NULL,
+    duration_days            INT                       NOT NULL,
+    meal_timing              VARCHAR                   NOT NULL,
+    created_at               TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at               TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX prescription_template_products_template_id_idx ON prescription_template_products (prescription_template_id);
+
+CREATE TABLE doctor_favorite_products
+(
+    doctor_id  BIGINT                    NOT NULL REFERENCES doctor_profiles (user_id),
+    product_id BIGINT                    NOT NULL REFERENCES products (id),
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    PRIMARY KEY (doctor_id, product_id)
+);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS consultation_session_extensions;
+
+DROP INDEX IF EXISTS consultation_sessions_expires_at_idx;
+
+ALTER TABLE consultation_sessions
+    DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE doctor_specializations
+    DROP COLUMN IF EXISTS session_duration_minutes;
@@ -0,0 +1,36 @@
+-- every specialization sets how long one paid session lasts
+ALTER TABLE doctor_specializations
+    ADD COLUMN session_duration_minutes INT DEFAULT 30 NOT NULL CHECK (session_duration_minutes > 0);
+
+ALTER TABLE consultation_sessions
+    ADD COLUMN expires_at TIMESTAMPTZ DEFAULT NULL;
+
+-- sessions that are already ongoing get a full session from the time of the migration
+UPDATE consultation_sessions
+SET expires_at = now() + doctor_specializations.session_duration_minutes * INTERVAL '1 minute'
+FROM doctor_profiles
+         INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id
+WHERE consultation_sessions.doctor_id = doctor_profiles.user_id
+  AND consultation_sessions.consultation_session_status_id = 1;
+
+CREATE INDEX consultation_sessions_expires_at_idx ON consultation_sessions (expires_at)
+    WHERE consultation_session_status_id = 1;
+
+-- the patient pays the doctor's fee with a payment proof, the doctor accepts or rejects it
+CREATE TABLE consultation_session_extensions
+(
+    id               BIGSERIAL PRIMARY KEY,
+    session_id       BIGINT                    NOT NULL REFERENCES consultation_sessions (id),
+    duration_minutes INT                       NOT NULL,
+    fee              NUMERIC                   NOT NULL,
+    payment_proof    VARCHAR                   NOT NULL,
+    status           VARCHAR                   NOT NULL,
+    created_at       TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at       TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX consultation_session_extensions_session_id_idx ON consultation_session_extensions (session_id);
+
+-- a session has at most one extension waiting for the doctor
+CREATE UNIQUE INDEX consultation_session_extensions_waiting_idx ON consultation_session_extensions (session_id)
+    WHERE status = 'waiting';
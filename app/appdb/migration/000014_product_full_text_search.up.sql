@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- names are matched verbatim, free text is stemmed so "obat demam" finds "meredakan demam"
+ALTER TABLE products
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', name || ' ' || generic_name), 'A') ||
+        setweight(to_tsvector('indonesian', content), 'B') ||
+        setweight(to_tsvector('indonesian', description), 'C')
+    ) STORED;
+
+CREATE INDEX products_search_vector_idx ON products USING GIN (search_vector);
+
+-- trigram indexes back the fuzzy fallback for misspelled names
+CREATE INDEX products_name_trgm_idx ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX products_generic_name_trgm_idx ON products USING GIN (generic_name gin_trgm_ops);
@@ -0,0 +1,28 @@
+CREATE TABLE product_imports
+(
+    id             BIGSERIAL PRIMARY KEY,
+    admin_id       BIGINT                    NOT NULL REFERENCES users (id),
+    file_name      VARCHAR                   NOT NULL,
+    is_dry_run     BOOLEAN                   NOT NULL,
+    status         VARCHAR                   NOT NULL,
+    total_rows     INT                       NOT NULL,
+    processed_rows INT         DEFAULT 0     NOT NULL,
+    succeeded_rows INT         DEFAULT 0     NOT NULL,
+    failed_rows    INT         DEFAULT 0     NOT NULL,
+    created_at     TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at     TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+-- one line of the report per csv row, errors are newline separated
+CREATE TABLE product_import_rows
+(
+    id                BIGSERIAL PRIMARY KEY,
+    product_import_id BIGINT                    NOT NULL REFERENCES product_imports (id),
+    row_number        INT                       NOT NULL,
+    status            VARCHAR                   NOT NULL,
+    product_id        BIGINT REFERENCES products (id),
+    errors            TEXT        DEFAULT ''    NOT NULL,
+    created_at        TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX product_import_rows_product_import_id_idx ON product_import_rows (product_import_id, row_number);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS products_code_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS code;
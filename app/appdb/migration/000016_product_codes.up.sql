@@ -0,0 +1,6 @@
+-- an optional code of the product, e.g. its barcode, for matching rows of inventory files
+ALTER TABLE products
+    ADD COLUMN code VARCHAR DEFAULT NULL;
+
+CREATE UNIQUE INDEX products_code_idx ON products (code)
+    WHERE code IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+DROP FUNCTION IF EXISTS product_category_path_ids(BIGINT);
+
+DROP INDEX IF EXISTS product_categories_parent_id_idx;
+
+ALTER TABLE product_categories
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,21 @@
+ALTER TABLE product_categories
+    ADD COLUMN parent_id BIGINT DEFAULT NULL REFERENCES product_categories (id);
+
+CREATE INDEX product_categories_parent_id_idx ON product_categories (parent_id);
+
+-- ids of the category followed by the ids of its ancestors up to the root,
+-- a product belongs to a category when the category is in the path of the product's own category
+CREATE OR REPLACE FUNCTION product_category_path_ids(category_id BIGINT) RETURNS BIGINT[]
+    LANGUAGE sql
+    STABLE AS
+$$
+WITH RECURSIVE path AS (SELECT id, parent_id, 1 AS depth
+                        FROM product_categories
+                        WHERE id = category_id
+                        UNION ALL
+                        SELECT pc.id, pc.parent_id, path.depth + 1
+                        FROM product_categories pc
+                                 INNER JOIN path ON pc.id = path.parent_id)
+SELECT array_agg(id ORDER BY depth)
+FROM path
+$$;
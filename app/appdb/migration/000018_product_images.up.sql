@@ -0,0 +1,20 @@
+CREATE TABLE product_images
+(
+    id            BIGSERIAL PRIMARY KEY,
+    product_id    BIGINT                    NOT NULL REFERENCES products (id),
+    position      INT                       NOT NULL,
+    original_url  VARCHAR                   NOT NULL,
+    medium_url    VARCHAR                   NOT NULL,
+    thumbnail_url VARCHAR                   NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at    TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at    TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX product_images_product_id_idx ON product_images (product_id, position)
+    WHERE deleted_at IS NULL;
+
+-- images uploaded before the gallery have no renditions, every rendition points to the uploaded image
+INSERT INTO product_images (product_id, position, original_url, medium_url, thumbnail_url)
+SELECT id, 1, image, image, image
+FROM products;
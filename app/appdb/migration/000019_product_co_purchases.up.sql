@@ -0,0 +1,12 @@
+-- item to item co-purchase scores, recomputed periodically from the confirmed orders
+CREATE TABLE product_co_purchases
+(
+    product_id         BIGINT                    NOT NULL REFERENCES products (id),
+    related_product_id BIGINT                    NOT NULL REFERENCES products (id),
+    co_purchase_count  INTEGER                   NOT NULL,
+    score              FLOAT                     NOT NULL,
+    computed_at        TIMESTAMPTZ DEFAULT now() NOT NULL,
+    PRIMARY KEY (product_id, related_product_id)
+);
+
+CREATE INDEX product_co_purchases_product_id_score_idx ON product_co_purchases (product_id, score DESC);
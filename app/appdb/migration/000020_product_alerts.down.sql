@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS pharmacy_products_update_change ON pharmacy_products;
+DROP TRIGGER IF EXISTS pharmacy_products_insert_change ON pharmacy_products;
+DROP FUNCTION IF EXISTS queue_pharmacy_product_change;
+DROP TABLE IF EXISTS pharmacy_product_changes;
+DROP TABLE IF EXISTS user_notifications;
+DROP TABLE IF EXISTS product_alerts;
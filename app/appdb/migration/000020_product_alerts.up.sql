@@ -0,0 +1,61 @@
+-- products users want to be told about once they are available or cheaper around their main address,
+-- is_available and lowest_price are what the user was last told about
+CREATE TABLE product_alerts
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT                    NOT NULL REFERENCES users (id),
+    product_id      BIGINT                    NOT NULL REFERENCES products (id),
+    notify_by_email BOOL                      NOT NULL,
+    is_available    BOOL                      NOT NULL,
+    lowest_price    NUMERIC DEFAULT NULL,
+    created_at      TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at      TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at      TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX product_alerts_user_id_product_id_idx ON product_alerts (user_id, product_id) WHERE deleted_at IS NULL;
+CREATE INDEX product_alerts_product_id_idx ON product_alerts (product_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE user_notifications
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT                    NOT NULL REFERENCES users (id),
+    product_id BIGINT                    NULL REFERENCES products (id),
+    title      VARCHAR                   NOT NULL,
+    message    VARCHAR                   NOT NULL,
+    is_read    BOOL        DEFAULT false NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX user_notifications_user_id_idx ON user_notifications (user_id, created_at DESC);
+
+-- every stock or price change is queued by the database so orders, mutations and imports are all matched
+CREATE TABLE pharmacy_product_changes
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    pharmacy_product_id BIGINT                    NOT NULL REFERENCES pharmacy_products (id),
+    created_at          TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE FUNCTION queue_pharmacy_product_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO pharmacy_product_changes(pharmacy_product_id) VALUES (NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pharmacy_products_insert_change
+    AFTER INSERT
+    ON pharmacy_products
+    FOR EACH ROW
+EXECUTE FUNCTION queue_pharmacy_product_change();
+
+CREATE TRIGGER pharmacy_products_update_change
+    AFTER UPDATE OF stock, price, is_active, deleted_at
+    ON pharmacy_products
+    FOR EACH ROW
+    WHEN (OLD.stock IS DISTINCT FROM NEW.stock OR OLD.price IS DISTINCT FROM NEW.price OR
+          OLD.is_active IS DISTINCT FROM NEW.is_active OR OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION queue_pharmacy_product_change();
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_review_photos;
+DROP TABLE IF EXISTS product_reviews;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_average;
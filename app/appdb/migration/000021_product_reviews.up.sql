@@ -0,0 +1,35 @@
+-- published reviews are aggregated into the products so listings can show and sort by rating without joining
+ALTER TABLE products
+    ADD COLUMN rating_average FLOAT   DEFAULT 0 NOT NULL,
+    ADD COLUMN rating_count   INTEGER DEFAULT 0 NOT NULL;
+
+CREATE TABLE product_reviews
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT                    NOT NULL REFERENCES users (id),
+    product_id      BIGINT                    NOT NULL REFERENCES products (id),
+    order_detail_id BIGINT                    NOT NULL UNIQUE REFERENCES order_details (id),
+    rating          SMALLINT                  NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment         VARCHAR                   NOT NULL,
+    status          VARCHAR                   NOT NULL,
+    moderation_note VARCHAR     DEFAULT ''    NOT NULL,
+    moderated_at    TIMESTAMPTZ DEFAULT NULL,
+    created_at      TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at      TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at      TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX product_reviews_product_id_idx ON product_reviews (product_id, status, created_at DESC);
+
+CREATE TABLE product_review_photos
+(
+    id                BIGSERIAL PRIMARY KEY,
+    product_review_id BIGINT                    NOT NULL REFERENCES product_reviews (id),
+    position          INTEGER                   NOT NULL,
+    url               VARCHAR                   NOT NULL,
+    created_at        TIMESTAMPTZ DEFAULT now() NOT NULL,
+    updated_at        TIMESTAMPTZ DEFAULT now() NOT NULL,
+    deleted_at        TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX product_review_photos_product_review_id_idx ON product_review_photos (product_review_id);
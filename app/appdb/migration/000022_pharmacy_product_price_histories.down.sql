@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pharmacy_product_price_histories;
@@ -0,0 +1,18 @@
+-- every price a pharmacy product had, old_price is null for the price it was added with and changed_by is null for
+-- the prices that were already set before the history was kept
+CREATE TABLE pharmacy_product_price_histories
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    pharmacy_product_id BIGINT                    NOT NULL REFERENCES pharmacy_products (id),
+    old_price           NUMERIC DEFAULT NULL,
+    new_price           NUMERIC                   NOT NULL,
+    changed_by          BIGINT  DEFAULT NULL REFERENCES users (id),
+    source              VARCHAR                   NOT NULL,
+    created_at          TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX pharmacy_product_price_histories_pharmacy_product_id_idx ON pharmacy_product_price_histories (pharmacy_product_id, created_at DESC);
+
+INSERT INTO pharmacy_product_price_histories(pharmacy_product_id, old_price, new_price, changed_by, source, created_at)
+SELECT id, NULL, price, NULL, 'initial', created_at
+FROM pharmacy_products;
@@ -0,0 +1,13 @@
+-- set once the prescription quantities redeemed by the order are given back, so they are never given back twice
+ALTER TABLE orders
+    ADD COLUMN prescription_released BOOL DEFAULT false NOT NULL;
+
+UPDATE orders
+SET prescription_released = true
+FROM transactions
+WHERE orders.transaction_id = transactions.id
+  AND (transactions.transaction_status_id = 5 OR EXISTS(SELECT 1
+                                                         FROM order_status_logs
+                                                         WHERE order_status_logs.order_id = orders.id
+                                                           AND order_status_logs.is_latest = true
+                                                           AND order_status_logs.order_status_id IN (5, 6)));
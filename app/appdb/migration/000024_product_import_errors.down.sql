@@ -0,0 +1,2 @@
+ALTER TABLE product_imports
+    DROP COLUMN IF EXISTS error_message;
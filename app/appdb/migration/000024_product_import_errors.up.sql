@@ -0,0 +1,3 @@
+-- why an import failed as a whole, row level problems stay in product_import_rows
+ALTER TABLE product_imports
+    ADD COLUMN error_message TEXT DEFAULT '' NOT NULL;
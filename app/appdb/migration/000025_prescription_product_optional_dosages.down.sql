@@ -0,0 +1,32 @@
+UPDATE prescription_template_products
+SET dose_amount   = COALESCE(dose_amount, 1),
+    frequency     = COALESCE(frequency, 1),
+    route         = COALESCE(route, 'oral'),
+    duration_days = COALESCE(duration_days, 1),
+    meal_timing   = COALESCE(meal_timing, 'any');
+
+ALTER TABLE prescription_template_products
+    ALTER COLUMN dose_amount SET NOT NULL,
+    ALTER COLUMN frequency SET NOT NULL,
+    ALTER COLUMN route SET NOT NULL,
+    ALTER COLUMN duration_days SET NOT NULL,
+    ALTER COLUMN meal_timing SET NOT NULL;
+
+UPDATE prescription_products
+SET dose_amount   = COALESCE(dose_amount, 1),
+    frequency     = COALESCE(frequency, 1),
+    route         = COALESCE(route, 'oral'),
+    duration_days = COALESCE(duration_days, 1),
+    meal_timing   = COALESCE(meal_timing, 'any');
+
+ALTER TABLE prescription_products
+    ALTER COLUMN dose_amount SET DEFAULT 1,
+    ALTER COLUMN dose_amount SET NOT NULL,
+    ALTER COLUMN frequency SET DEFAULT 1,
+    ALTER COLUMN frequency SET NOT NULL,
+    ALTER COLUMN route SET DEFAULT 'oral',
+    ALTER COLUMN route SET NOT NULL,
+    ALTER COLUMN duration_days SET DEFAULT 1,
+    ALTER COLUMN duration_days SET NOT NULL,
+    ALTER COLUMN meal_timing SET DEFAULT 'any',
+    ALTER COLUMN meal_timing SET NOT NULL;
@@ -0,0 +1,20 @@
+-- the structured dosage is optional, a product prescribed with only a note keeps its dosage NULL instead of
+-- a made up default
+ALTER TABLE prescription_products
+    ALTER COLUMN dose_amount DROP NOT NULL,
+    ALTER COLUMN dose_amount DROP DEFAULT,
+    ALTER COLUMN frequency DROP NOT NULL,
+    ALTER COLUMN frequency DROP DEFAULT,
+    ALTER COLUMN route DROP NOT NULL,
+    ALTER COLUMN route DROP DEFAULT,
+    ALTER COLUMN duration_days DROP NOT NULL,
+    ALTER COLUMN duration_days DROP DEFAULT,
+    ALTER COLUMN meal_timing DROP NOT NULL,
+    ALTER COLUMN meal_timing DROP DEFAULT;
+
+ALTER TABLE prescription_template_products
+    ALTER COLUMN dose_amount DROP NOT NULL,
+    ALTER COLUMN frequency DROP NOT NULL,
+    ALTER COLUMN route DROP NOT NULL,
+    ALTER COLUMN duration_days DROP NOT NULL,
+    ALTER COLUMN meal_timing DROP NOT NULL;
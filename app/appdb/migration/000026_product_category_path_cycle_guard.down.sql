@@ -0,0 +1,14 @@
+CREATE OR REPLACE FUNCTION product_category_path_ids(category_id BIGINT) RETURNS BIGINT[]
+    LANGUAGE sql
+    STABLE AS
+$$
+WITH RECURSIVE path AS (SELECT id, parent_id, 1 AS depth
+                        FROM product_categories
+                        WHERE id = category_id
+                        UNION ALL
+                        SELECT pc.id, pc.parent_id, path.depth + 1
+                        FROM product_categories pc
+                                 INNER JOIN path ON pc.id = path.parent_id)
+SELECT array_agg(id ORDER BY depth)
+FROM path
+$$;
@@ -0,0 +1,19 @@
+-- stops walking up at a category that was already visited, so a cycle in the tree can never make the recursion
+-- run forever
+CREATE OR REPLACE FUNCTION product_category_path_ids(category_id BIGINT) RETURNS BIGINT[]
+    LANGUAGE sql
+    STABLE AS
+$$
+WITH RECURSIVE path AS (SELECT id, parent_id, ARRAY [id] AS ids
+                        FROM product_categories
+                        WHERE id = category_id
+                        UNION ALL
+                        SELECT pc.id, pc.parent_id, path.ids || pc.id
+                        FROM product_categories pc
+                                 INNER JOIN path ON pc.id = path.parent_id
+                        WHERE NOT pc.id = ANY (path.ids))
+SELECT ids
+FROM path
+ORDER BY array_length(ids, 1) DESC
+LIMIT 1
+$$;
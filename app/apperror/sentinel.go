@@ -36,8 +36,14 @@ var (
 
 	ErrProductUniqueConstraint            = errors.New("name, generic_name, content, and manufacturer_id combinations violate unique constraint")
 	ErrProductImageDoesNotExistInContext  = errors.New("product image does not exist in context")
+	ErrProductImageLastImage              = errors.New("product must keep at least one image")
+	ErrProductImageOrderMismatch          = errors.New("image order must list every image of the product exactly once")
+	ErrInvalidImage                       = errors.New("image cannot be read, make sure it is a valid png or jpeg")
+	ErrImageTooLarge                      = errors.New("image dimensions are too large")
 	ErrProductCategoryUniqueConstraint    = errors.New("name violates unique constraint")
 	ErrProductCategoryStillUsedByProducts = errors.New("product category still used by products")
+	ErrProductCategoryStillHasChildren    = errors.New("product category still has child categories")
+	ErrProductCategoryInvalidParent       = errors.New("product category cannot be moved under itself or its descendants")
 
 	ErrInsufficientProductStock             = errors.New("insufficient product stock")
 	ErrProductStockNotEnoughToAddToCart     = errors.New("product stock is not enough to add to cart")
@@ -67,4 +73,49 @@ var (
 	ErrSickLeaveStartingDateShouldBeBeforeEndingDate                  = errors.New("sick leave starting date should be before ending date")
 	ErrConsultationSessionPrescriptionMustExistBeforeIssuingSickLeave = errors.New("prescription must be issued first before issuing a sick leave certificate")
 	ErrConsultationSessionAlreadyHasPrescription                      = errors.New("prescription has been issued for this consultation session")
+	ErrSickLeaveCertificateInvalid                                    = errors.New("sick leave certificate is invalid or has been modified")
+	ErrMedicalRecordConsentNotGranted                                 = errors.New("patient has not granted access to their medical records for this consultation session")
+	ErrClinicalNoteVersionConflict                                    = errors.New("clinical note has been changed since the given version, reload it before saving")
+	ErrReferralAlreadyUsed                                            = errors.New("a consultation session has already been opened from this referral")
+	ErrReferralToSameDoctor                                           = errors.New("referral cannot target the referring doctor")
+	ErrReferralDoctorSpecializationMismatch                           = errors.New("doctor does not have the specialization named in the referral")
+	ErrReferralDoctorRequired                                         = errors.New("referral names no doctor, a doctor of the referred specialization must be chosen")
+	ErrPrescriptionTemplateNameAlreadyUsed                            = errors.New("prescription template with the same name already exists")
+	ErrConsultationSessionExtensionWaiting                            = errors.New("consultation session already has an extension waiting for the doctor")
+	ErrConsultationSessionExtensionNotWaiting                         = errors.New("consultation session extension has already been accepted or rejected")
+	ErrConsultationSessionExtensionPaymentProofRequired               = errors.New("payment proof is required to extend a consultation session")
+
+	ErrPrescriptionMustHaveAtLeastOneProduct = errors.New("prescription must have at least one product")
+	ErrPrescriptionProductInvalidDosage      = errors.New("prescription product quantity, dose, frequency and duration must be greater than zero")
+	ErrPrescriptionProductFrequencyTooHigh   = errors.New("prescription product frequency cannot exceed 24 times a day")
+	ErrPrescriptionProductInvalidRoute       = errors.New("prescription product route must be one of oral, sublingual, topical, inhalation, nasal, ophthalmic, otic, rectal, vaginal, injection")
+	ErrPrescriptionProductInvalidMealTiming  = errors.New("prescription product meal timing must be one of before_meal, after_meal, with_meal, any")
+	ErrPrescriptionExpired                   = errors.New("prescription has expired")
+	ErrPrescriptionAlreadyRedeemed           = errors.New("prescription has already been redeemed")
+	ErrPrescriptionProductQuantityExceeded   = errors.New("quantity exceeds the remaining prescribed quantity")
+	ErrPrescriptionProductMismatch           = errors.New("product does not match the linked prescription product")
+	ErrPrescriptionRequired                  = errors.New("product can only be bought with a valid prescription")
+	ErrPrescriptionPatientMismatch           = errors.New("prescription was issued for a different patient than the order")
+	ErrPrescriptionSevereInteraction         = errors.New("prescription contains severe drug interactions or patient allergies, an override reason is required")
+
+	ErrMedicationReminderPrescriptionNotRedeemed = errors.New("medication reminder can only be made from a redeemed prescription product")
+	ErrMedicationReminderDoseNotDue              = errors.New("dose cannot be logged more than an hour before its schedule")
+	ErrMedicationReminderPrescriptionNoSchedule  = errors.New("prescription product has no duration, or no frequency and times of day, to schedule a medication reminder from")
+
+	ErrDrugInteractionUniqueConstraint  = errors.New("drug interaction for the generic name pair already exists")
+	ErrDrugInteractionSameGenericName   = errors.New("drug interaction must be between two different generic names")
+	ErrDrugInteractionInvalidSeverity   = errors.New("drug interaction severity must be one of minor, moderate, severe")
+	ErrDrugInteractionImportInvalidFile = errors.New("drug interaction import file must be a csv with generic_name_a, generic_name_b, severity, description columns")
+
+	ErrProductImportInvalidFile = errors.New("product import file must be a csv with name, generic_name, content, manufacturer, description, drug_classification, product_category, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image columns")
+	ErrProductImportTooManyRows = errors.New("product import file cannot have more than 5000 rows")
+	ErrProductCodeAlreadyUsed   = errors.New("product code is already used by another product")
+
+	ErrPharmacyProductImportInvalidFile = errors.New("pharmacy product import file must be a csv with product_id or product_code, price, stock, is_active columns")
+	ErrPharmacyProductImportTooManyRows = errors.New("pharmacy product import file cannot have more than 5000 rows")
+
+	ErrProductAlertAlreadyExists = errors.New("already subscribed to alerts for this product")
+
+	ErrProductReviewNotAllowed    = errors.New("only buyers of a confirmed order containing the product can review it")
+	ErrProductReviewAlreadyExists = errors.New("order detail has already been reviewed")
 )
@@ -5,6 +5,7 @@ import (
 	"halodeksik-be/app/api"
 	"halodeksik-be/app/appcloud"
 	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appcrypto"
 	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/appencoder"
 	"halodeksik-be/app/applogger"
@@ -56,13 +57,24 @@ func main() {
 	fileUploader := appcloud.NewFileUploaderImpl()
 	appcloud.SetAppFileUploader(fileUploader)
 
+	fieldEncryptor, err := appcrypto.NewFieldEncryptorImpl(appconfig.Config.FieldEncryptionKeys, appconfig.Config.FieldEncryptionActiveKeyId)
+	if err != nil {
+		applogger.Log.Errorf("failed to initialize field encryption: %v", err)
+		return
+	}
+	appcrypto.SetAppFieldEncryptor(fieldEncryptor)
+
 	db, dbErr := appdb.Connect()
 	if dbErr != nil {
 		applogger.Log.Error(dbErr)
 	}
 
 	allRepositories := api.InitializeRepositories(db)
-	allUtil := api.InitializeUtil()
+	allUtil, err := api.InitializeUtil()
+	if err != nil {
+		applogger.Log.Errorf("failed to initialize utils: %v", err)
+		return
+	}
 	allUseCases := api.InitializeUseCases(allRepositories, allUtil)
 	hub := ws.NewHub()
 	routerOpts := api.InitializeAllRouterOpts(allUseCases, hub)
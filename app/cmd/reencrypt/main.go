@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appcrypto"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/applogger"
+	"halodeksik-be/app/repository"
+)
+
+// re-encrypts every encrypted column with the active key, run it after rotating FIELD_ENCRYPTION_ACTIVE_KEY_ID and
+// before removing the old key from FIELD_ENCRYPTION_KEYS
+func main() {
+	err := appconfig.LoadConfig()
+	if err != nil {
+		applogger.Log.Error(err)
+		return
+	}
+
+	logger, logFile := applogger.NewLogger()
+	if logFile != nil {
+		defer logFile.Close()
+	}
+	applogger.SetLogger(logger)
+
+	fieldEncryptor, err := appcrypto.NewFieldEncryptorImpl(appconfig.Config.FieldEncryptionKeys, appconfig.Config.FieldEncryptionActiveKeyId)
+	if err != nil {
+		applogger.Log.Errorf("failed to initialize field encryption: %v", err)
+		return
+	}
+	appcrypto.SetAppFieldEncryptor(fieldEncryptor)
+
+	db, err := appdb.Connect()
+	if err != nil {
+		applogger.Log.Error(err)
+		return
+	}
+	defer db.Close()
+
+	repo := repository.NewFieldEncryptionRepositoryImpl(db)
+	for table, columns := range appconstant.EncryptedColumns {
+		for _, column := range columns {
+			reEncrypted, err := repo.ReEncryptColumn(context.Background(), table, column, appconstant.FieldReEncryptionBatchSize)
+			if err != nil {
+				applogger.Log.Errorf("failed to re-encrypt %s.%s: %v", table, column, err)
+				return
+			}
+			applogger.Log.Infof("re-encrypted %d values of %s.%s", reEncrypted, table, column)
+		}
+	}
+}
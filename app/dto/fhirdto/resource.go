@@ -0,0 +1,116 @@
+package fhirdto
+
+const (
+	ResourceTypeBundle            = "Bundle"
+	ResourceTypePatient           = "Patient"
+	ResourceTypePractitioner      = "Practitioner"
+	ResourceTypeEncounter         = "Encounter"
+	ResourceTypeCondition         = "Condition"
+	ResourceTypeMedicationRequest = "MedicationRequest"
+)
+
+type Patient struct {
+	ResourceType string         `json:"resourceType"`
+	Id           string         `json:"id"`
+	Identifier   []Identifier   `json:"identifier,omitempty"`
+	Name         []HumanName    `json:"name,omitempty"`
+	Telecom      []ContactPoint `json:"telecom,omitempty"`
+	BirthDate    string         `json:"birthDate,omitempty"`
+}
+
+type PractitionerQualification struct {
+	Code CodeableConcept `json:"code"`
+}
+
+type Practitioner struct {
+	ResourceType  string                      `json:"resourceType"`
+	Id            string                      `json:"id"`
+	Identifier    []Identifier                `json:"identifier,omitempty"`
+	Name          []HumanName                 `json:"name,omitempty"`
+	Telecom       []ContactPoint              `json:"telecom,omitempty"`
+	Qualification []PractitionerQualification `json:"qualification,omitempty"`
+}
+
+type EncounterParticipant struct {
+	Individual Reference `json:"individual"`
+}
+
+type Encounter struct {
+	ResourceType string                 `json:"resourceType"`
+	Id           string                 `json:"id"`
+	Status       string                 `json:"status"`
+	Class        Coding                 `json:"class"`
+	Subject      Reference              `json:"subject"`
+	Participant  []EncounterParticipant `json:"participant,omitempty"`
+	Period       *Period                `json:"period,omitempty"`
+}
+
+type Condition struct {
+	ResourceType       string            `json:"resourceType"`
+	Id                 string            `json:"id"`
+	ClinicalStatus     *CodeableConcept  `json:"clinicalStatus,omitempty"`
+	VerificationStatus *CodeableConcept  `json:"verificationStatus,omitempty"`
+	Category           []CodeableConcept `json:"category,omitempty"`
+	Code               CodeableConcept   `json:"code"`
+	Subject            Reference         `json:"subject"`
+	Encounter          *Reference        `json:"encounter,omitempty"`
+	RecordedDate       string            `json:"recordedDate,omitempty"`
+	Recorder           *Reference        `json:"recorder,omitempty"`
+	Note               []Annotation      `json:"note,omitempty"`
+}
+
+type TimingRepeat struct {
+	BoundsDuration *Quantity `json:"boundsDuration,omitempty"`
+	Frequency      int32     `json:"frequency,omitempty"`
+	Period         float64   `json:"period,omitempty"`
+	PeriodUnit     string    `json:"periodUnit,omitempty"`
+	When           []string  `json:"when,omitempty"`
+}
+
+type Timing struct {
+	Repeat TimingRepeat `json:"repeat"`
+}
+
+type DoseAndRate struct {
+	DoseQuantity Quantity `json:"doseQuantity"`
+}
+
+type Dosage struct {
+	Text        string           `json:"text,omitempty"`
+	Timing      *Timing          `json:"timing,omitempty"`
+	Route       *CodeableConcept `json:"route,omitempty"`
+	DoseAndRate []DoseAndRate    `json:"doseAndRate,omitempty"`
+}
+
+type MedicationRequestDispenseRequest struct {
+	ValidityPeriod *Period   `json:"validityPeriod,omitempty"`
+	Quantity       *Quantity `json:"quantity,omitempty"`
+}
+
+type MedicationRequest struct {
+	ResourceType              string                            `json:"resourceType"`
+	Id                        string                            `json:"id"`
+	Status                    string                            `json:"status"`
+	Intent                    string                            `json:"intent"`
+	MedicationCodeableConcept CodeableConcept                   `json:"medicationCodeableConcept"`
+	Subject                   Reference                         `json:"subject"`
+	Encounter                 *Reference                        `json:"encounter,omitempty"`
+	AuthoredOn                string                            `json:"authoredOn,omitempty"`
+	Requester                 *Reference                        `json:"requester,omitempty"`
+	ReasonReference           []Reference                       `json:"reasonReference,omitempty"`
+	Note                      []Annotation                      `json:"note,omitempty"`
+	DosageInstruction         []Dosage                          `json:"dosageInstruction,omitempty"`
+	DispenseRequest           *MedicationRequestDispenseRequest `json:"dispenseRequest,omitempty"`
+}
+
+type BundleEntry struct {
+	FullUrl  string      `json:"fullUrl,omitempty"`
+	Resource interface{} `json:"resource"`
+}
+
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type"`
+	Timestamp    string        `json:"timestamp"`
+	Entry        []BundleEntry `json:"entry"`
+}
@@ -0,0 +1,12 @@
+package queryparamdto
+
+import "strconv"
+
+type ExportPharmacyProductsQuery struct {
+	PharmacyId string `form:"pharmacy_id" validate:"required,number"`
+}
+
+func (q *ExportPharmacyProductsQuery) GetPharmacyId() int64 {
+	pharmacyId, _ := strconv.ParseInt(q.PharmacyId, 10, 64)
+	return pharmacyId
+}
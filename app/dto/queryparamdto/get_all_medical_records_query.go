@@ -0,0 +1,89 @@
+package queryparamdto
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GetAllMedicalRecordsQuery struct {
+	Types     string `form:"type"`
+	StartDate string `form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Sort      string `form:"sort"`
+	Limit     string `form:"limit"`
+	Page      string `form:"page"`
+}
+
+func (q *GetAllMedicalRecordsQuery) ToGetAllParams() (*GetAllParams, error) {
+	param := NewGetAllParams()
+	record := new(entity.MedicalRecord)
+
+	if !util.IsEmptyString(q.Types) {
+		types := make([]string, 0)
+		for _, recordType := range strings.Split(q.Types, ",") {
+			recordType = strings.TrimSpace(recordType)
+			if appconstant.MedicalRecordTypes[recordType] {
+				types = append(types, recordType)
+			}
+		}
+		if len(types) > 0 {
+			param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(record.GetSqlColumnFromField("Type"), appdb.In, strings.Join(types, ",")))
+		}
+	}
+
+	startDate := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := util.GetCurrentDate()
+
+	if !util.IsEmptyString(q.StartDate) {
+		startDate, _ = util.ParseDateTime(q.StartDate)
+		column := record.GetSqlColumnFromField("OccurredAt")
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.GreaterOrEqualTo, startDate))
+	}
+
+	if !util.IsEmptyString(q.EndDate) {
+		endDate, _ = util.ParseDateTime(q.EndDate)
+		column := record.GetSqlColumnFromField("OccurredAt")
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.LessThan, endDate.AddDate(0, 0, 1)))
+	}
+
+	if !util.IsEmptyString(q.StartDate) && !util.IsEmptyString(q.EndDate) {
+		if startDate.After(endDate) {
+			return nil, apperror.ErrStartDateAfterEndDate
+		}
+	}
+
+	sortClause := appdb.NewSort(record.GetSqlColumnFromField("OccurredAt"))
+	switch q.Sort {
+	case strings.ToLower(string(appdb.OrderAsc)):
+		sortClause.Order = appdb.OrderAsc
+	default:
+		sortClause.Order = appdb.OrderDesc
+	}
+	param.SortClauses = append(param.SortClauses, sortClause)
+
+	pageSize := appconstant.DefaultGetAllPageSize
+	if !util.IsEmptyString(q.Limit) {
+		noPageSize, err := strconv.Atoi(q.Limit)
+		if err == nil && noPageSize > 0 {
+			pageSize = noPageSize
+		}
+	}
+	param.PageSize = &pageSize
+
+	pageId := 1
+	if !util.IsEmptyString(q.Page) {
+		noPageId, err := strconv.Atoi(q.Page)
+		if err == nil && noPageId > 0 {
+			pageId = noPageId
+		}
+	}
+	param.PageId = &pageId
+
+	return param, nil
+}
@@ -0,0 +1,55 @@
+package queryparamdto
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strconv"
+	"strings"
+)
+
+type GetAllPharmacyProductPriceHistoriesQuery struct {
+	Source string `form:"source" validate:"omitempty,oneof=manual import initial"`
+	Sort   string `form:"sort"`
+	Limit  string `form:"limit"`
+	Page   string `form:"page"`
+}
+
+func (q GetAllPharmacyProductPriceHistoriesQuery) ToGetAllParams() *GetAllParams {
+	param := NewGetAllParams()
+	history := new(entity.PharmacyProductPriceHistory)
+
+	if !util.IsEmptyString(q.Source) {
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(history.GetSqlColumnFromField("Source"), appdb.EqualTo, q.Source))
+	}
+
+	sortClause := appdb.NewSort(history.GetSqlColumnFromField("CreatedAt"))
+	switch q.Sort {
+	case strings.ToLower(string(appdb.OrderAsc)):
+		sortClause.Order = appdb.OrderAsc
+	default:
+		sortClause.Order = appdb.OrderDesc
+	}
+	param.SortClauses = append(param.SortClauses, sortClause)
+
+	pageSize := appconstant.DefaultGetAllPageSize
+	if !util.IsEmptyString(q.Limit) {
+		noPageSize, err := strconv.Atoi(q.Limit)
+		if err == nil && noPageSize > 0 {
+			pageSize = noPageSize
+		}
+	}
+	param.PageSize = &pageSize
+
+	pageId := 1
+	if !util.IsEmptyString(q.Page) {
+		noPageId, err := strconv.Atoi(q.Page)
+		if err == nil && noPageId > 0 {
+			pageId = noPageId
+		}
+	}
+	param.PageId = &pageId
+
+	return param
+}
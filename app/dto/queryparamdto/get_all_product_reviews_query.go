@@ -0,0 +1,84 @@
+package queryparamdto
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strconv"
+	"strings"
+)
+
+type GetAllProductReviewsQuery struct {
+	Ratings  string `form:"rating" validate:"omitempty,comma_separated=number"`
+	Status   string `form:"status" validate:"omitempty,oneof=published hidden"`
+	Products string `form:"product" validate:"omitempty,comma_separated=number"`
+	SortBy   string `form:"sort_by"`
+	Sort     string `form:"sort"`
+	Limit    string `form:"limit"`
+	Page     string `form:"page"`
+}
+
+func (q *GetAllProductReviewsQuery) ToGetAllParams() *GetAllParams {
+	const (
+		sortByDate   = "date"
+		sortByRating = "rating"
+	)
+
+	param := NewGetAllParams()
+	review := new(entity.ProductReview)
+
+	if !util.IsEmptyString(q.Ratings) {
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(review.GetSqlColumnFromField("Rating"), appdb.In, q.Ratings))
+	}
+	if !util.IsEmptyString(q.Status) {
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(review.GetSqlColumnFromField("Status"), appdb.EqualTo, q.Status))
+	}
+	if !util.IsEmptyString(q.Products) {
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(review.GetSqlColumnFromField("ProductId"), appdb.In, q.Products))
+	}
+
+	switch q.SortBy {
+	case sortByRating:
+		q.SortBy = review.GetSqlColumnFromField("Rating")
+	case sortByDate:
+		q.SortBy = review.GetSqlColumnFromField("CreatedAt")
+	default:
+		q.SortBy = review.GetSqlColumnFromField("CreatedAt")
+	}
+	sortClause := appdb.NewSort(q.SortBy)
+	switch q.Sort {
+	case strings.ToLower(string(appdb.OrderAsc)):
+		sortClause.Order = appdb.OrderAsc
+	default:
+		sortClause.Order = appdb.OrderDesc
+	}
+	param.SortClauses = append(param.SortClauses, sortClause)
+
+	pageSize := appconstant.DefaultGetAllPageSize
+	if !util.IsEmptyString(q.Limit) {
+		noPageSize, err := strconv.Atoi(q.Limit)
+		if err == nil && noPageSize > 0 {
+			pageSize = noPageSize
+		}
+	}
+	param.PageSize = &pageSize
+
+	pageId := 1
+	if !util.IsEmptyString(q.Page) {
+		noPageId, err := strconv.Atoi(q.Page)
+		if err == nil && noPageId > 0 {
+			pageId = noPageId
+		}
+	}
+	param.PageId = &pageId
+
+	return param
+}
+
+// ToGetAllPublishedParams lists the published reviews of one product, whatever status or product was asked for
+func (q *GetAllProductReviewsQuery) ToGetAllPublishedParams(productId int64) *GetAllParams {
+	q.Status = appconstant.ProductReviewStatusPublished
+	q.Products = strconv.FormatInt(productId, 10)
+	return q.ToGetAllParams()
+}
@@ -33,20 +33,7 @@ func (q *GetAllProductsForAdminQuery) ToGetAllParams() (*GetAllParams, error) {
 	product := new(entity.Product)
 	pharmacyProduct := new(entity.PharmacyProduct)
 
-	if q.Search != "" {
-		words := strings.Split(q.Search, " ")
-		wordToSearch := ""
-		for _, word := range words {
-			wordToSearch += "%" + word + "%"
-		}
-		param.WhereClauses = append(
-			param.WhereClauses,
-			appdb.NewWhereParenthesis(product.GetSqlColumnFromField("Name"), appdb.ILike, wordToSearch, true, false, appdb.OR),
-			appdb.NewWhere(product.GetSqlColumnFromField("GenericName"), appdb.ILike, wordToSearch, appdb.OR),
-			appdb.NewWhere(product.GetSqlColumnFromField("Description"), appdb.ILike, wordToSearch, appdb.OR),
-			appdb.NewWhereParenthesis(product.GetSqlColumnFromField("Content"), appdb.ILike, wordToSearch, false, true),
-		)
-	}
+	param.Search = strings.TrimSpace(q.Search)
 
 	switch q.SortBy {
 	case sortByName:
@@ -15,41 +15,55 @@ type GetAllProductsQuery struct {
 	SortBy              string `form:"sort_by"`
 	Sort                string `form:"sort"`
 	DrugClassifications string `form:"drug_class" validate:"omitempty,comma_separated=number"`
+	ProductCategories   string `form:"category" validate:"omitempty,comma_separated=number"`
+	Manufacturers       string `form:"manufacturer" validate:"omitempty,comma_separated=number"`
+	DrugForms           string `form:"drug_form" validate:"omitempty,comma_separated"`
+	MinPrice            string `form:"min_price" validate:"omitempty,numeric,min=0"`
+	MaxPrice            string `form:"max_price" validate:"omitempty,numeric,min=0"`
 	Latitude            string `form:"latitude" validate:"omitempty,latitude"`
 	Longitude           string `form:"longitude" validate:"omitempty,longitude"`
 	Limit               string `form:"limit"`
 	Page                string `form:"page"`
 }
 
+// ProductFacetParams holds one set of filters per facet. Each facet ignores its own filter,
+// so the counts tell how many products every other value of that facet would return.
+type ProductFacetParams struct {
+	DrugClassification *GetAllParams
+	ProductCategory    *GetAllParams
+	Manufacturer       *GetAllParams
+	DrugForm           *GetAllParams
+	PriceRange         *GetAllParams
+}
+
+const (
+	productFacetNone               = ""
+	productFacetDrugClassification = "drug_class"
+	productFacetProductCategory    = "category"
+	productFacetManufacturer       = "manufacturer"
+	productFacetDrugForm           = "drug_form"
+	productFacetPriceRange         = "price"
+)
+
 func (q *GetAllProductsQuery) ToGetAllParams() (*GetAllParams, string, string, error) {
 	const (
-		sortByName = "name"
-		sortByDate = "date"
+		sortByName   = "name"
+		sortByDate   = "date"
+		sortByRating = "rating"
 	)
 
 	param := NewGetAllParams()
 	product := new(entity.Product)
 
-	if q.Search != "" {
-		words := strings.Split(q.Search, " ")
-		wordToSearch := ""
-		for _, word := range words {
-			wordToSearch += "%" + word + "%"
-		}
-		param.WhereClauses = append(
-			param.WhereClauses,
-			appdb.NewWhereParenthesis(product.GetSqlColumnFromField("Name"), appdb.ILike, wordToSearch, true, false, appdb.OR),
-			appdb.NewWhere(product.GetSqlColumnFromField("GenericName"), appdb.ILike, wordToSearch, appdb.OR),
-			appdb.NewWhere(product.GetSqlColumnFromField("Description"), appdb.ILike, wordToSearch, appdb.OR),
-			appdb.NewWhereParenthesis(product.GetSqlColumnFromField("Content"), appdb.ILike, wordToSearch, false, true),
-		)
-	}
+	param.Search = strings.TrimSpace(q.Search)
 
 	switch q.SortBy {
 	case sortByName:
 		q.SortBy = product.GetSqlColumnFromField("Name")
 	case sortByDate:
 		q.SortBy = product.GetSqlColumnFromField("CreatedAt")
+	case sortByRating:
+		q.SortBy = product.GetSqlColumnFromField("RatingAverage")
 	default:
 		q.SortBy = ""
 	}
@@ -63,12 +77,13 @@ func (q *GetAllProductsQuery) ToGetAllParams() (*GetAllParams, string, string, e
 	if !util.IsEmptyString(q.SortBy) {
 		param.SortClauses = append(param.SortClauses, sortClause)
 	}
-
-	if !util.IsEmptyString(q.DrugClassifications) {
-		column := fmt.Sprintf("%s.%s", product.GetEntityName(), product.GetFieldStructTag("DrugClassificationId", appconstant.JsonStructTag))
-		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.In, q.DrugClassifications))
+	if q.SortBy == product.GetSqlColumnFromField("RatingAverage") {
+		// among products with the same average, the one rated by more buyers comes first
+		param.SortClauses = append(param.SortClauses, appdb.NewSort(product.GetSqlColumnFromField("RatingCount"), appdb.OrderDesc))
 	}
 
+	param.WhereClauses = append(param.WhereClauses, q.toFilterClauses(productFacetNone)...)
+
 	param.GroupClauses = append(
 		param.GroupClauses,
 		appdb.NewGroupClause(product.GetSqlColumnFromField("Id")),
@@ -95,6 +110,56 @@ func (q *GetAllProductsQuery) ToGetAllParams() (*GetAllParams, string, string, e
 	return param, q.Latitude, q.Longitude, nil
 }
 
+func (q *GetAllProductsQuery) ToFacetParams() *ProductFacetParams {
+	newFacetParam := func(facet string) *GetAllParams {
+		param := NewGetAllParams()
+		param.Search = strings.TrimSpace(q.Search)
+		param.WhereClauses = append(param.WhereClauses, q.toFilterClauses(facet)...)
+		return param
+	}
+
+	return &ProductFacetParams{
+		DrugClassification: newFacetParam(productFacetDrugClassification),
+		ProductCategory:    newFacetParam(productFacetProductCategory),
+		Manufacturer:       newFacetParam(productFacetManufacturer),
+		DrugForm:           newFacetParam(productFacetDrugForm),
+		PriceRange:         newFacetParam(productFacetPriceRange),
+	}
+}
+
+// toFilterClauses builds the where clauses of every filter except the one belonging to the given facet
+func (q *GetAllProductsQuery) toFilterClauses(exceptFacet string) []appdb.WhereClause {
+	product := new(entity.Product)
+	pharmacyProduct := new(entity.PharmacyProduct)
+	clauses := make([]appdb.WhereClause, 0)
+
+	if !util.IsEmptyString(q.DrugClassifications) && exceptFacet != productFacetDrugClassification {
+		column := fmt.Sprintf("%s.%s", product.GetEntityName(), product.GetFieldStructTag("DrugClassificationId", appconstant.JsonStructTag))
+		clauses = append(clauses, appdb.NewWhere(column, appdb.In, q.DrugClassifications))
+	}
+	if !util.IsEmptyString(q.ProductCategories) && exceptFacet != productFacetProductCategory {
+		// a category also matches the products of all of its descendant categories
+		column := fmt.Sprintf("product_category_path_ids(%s)", product.GetSqlColumnFromField("ProductCategoryId"))
+		clauses = append(clauses, appdb.NewWhere(column, appdb.Overlap, fmt.Sprintf("{%s}", q.ProductCategories)))
+	}
+	if !util.IsEmptyString(q.Manufacturers) && exceptFacet != productFacetManufacturer {
+		clauses = append(clauses, appdb.NewWhere(product.GetSqlColumnFromField("ManufacturerId"), appdb.In, q.Manufacturers))
+	}
+	if !util.IsEmptyString(q.DrugForms) && exceptFacet != productFacetDrugForm {
+		clauses = append(clauses, appdb.NewWhere(product.GetSqlColumnFromField("DrugForm"), appdb.In, q.DrugForms))
+	}
+	if exceptFacet != productFacetPriceRange {
+		if !util.IsEmptyString(q.MinPrice) {
+			clauses = append(clauses, appdb.NewWhere(pharmacyProduct.GetSqlColumnFromField("Price"), appdb.GreaterOrEqualTo, q.MinPrice))
+		}
+		if !util.IsEmptyString(q.MaxPrice) {
+			clauses = append(clauses, appdb.NewWhere(pharmacyProduct.GetSqlColumnFromField("Price"), appdb.LessThan, q.MaxPrice))
+		}
+	}
+
+	return clauses
+}
+
 func (q *GetAllProductsQuery) GetCurrentLocation() (string, string) {
 	return q.Latitude, q.Longitude
 }
@@ -0,0 +1,76 @@
+package queryparamdto
+
+import (
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strconv"
+	"strings"
+)
+
+type GetAllSellPricesQuery struct {
+	PharmacyId string `form:"pharmacy_id" validate:"omitempty,number"`
+	ProductId  string `form:"product_id" validate:"omitempty,number"`
+	Year       string `form:"year" validate:"omitempty,number"`
+	SortBy     string `form:"sort_by" validate:"omitempty,oneof=date difference"`
+	Sort       string `form:"sort"`
+	Limit      string `form:"limit"`
+	Page       string `form:"page"`
+}
+
+func (q GetAllSellPricesQuery) ToGetAllParams() *GetAllParams {
+	const sortByDifference = "difference"
+
+	param := NewGetAllParams()
+	order := new(entity.Order)
+	orderDetail := new(entity.OrderDetail)
+	pharmacyProduct := new(entity.PharmacyProduct)
+
+	if !util.IsEmptyString(q.PharmacyId) {
+		column := order.GetSqlColumnFromField("PharmacyId")
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.EqualTo, q.PharmacyId))
+	}
+	if !util.IsEmptyString(q.ProductId) {
+		column := orderDetail.GetSqlColumnFromField("ProductId")
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.EqualTo, q.ProductId))
+	}
+	if !util.IsEmptyString(q.Year) {
+		column := fmt.Sprintf("extract(YEAR FROM %s)", order.GetSqlColumnFromField("Date"))
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.EqualTo, q.Year))
+	}
+
+	sortBy := order.GetSqlColumnFromField("Date")
+	if q.SortBy == sortByDifference {
+		sortBy = fmt.Sprintf("%s - %s", pharmacyProduct.GetSqlColumnFromField("Price"), orderDetail.GetSqlColumnFromField("Price"))
+	}
+	sortClause := appdb.NewSort(sortBy)
+	switch q.Sort {
+	case strings.ToLower(string(appdb.OrderAsc)):
+		sortClause.Order = appdb.OrderAsc
+	default:
+		sortClause.Order = appdb.OrderDesc
+	}
+	param.SortClauses = append(param.SortClauses, sortClause)
+
+	pageSize := appconstant.DefaultGetAllPageSize
+	if !util.IsEmptyString(q.Limit) {
+		noPageSize, err := strconv.Atoi(q.Limit)
+		if err == nil && noPageSize > 0 {
+			pageSize = noPageSize
+		}
+	}
+	param.PageSize = &pageSize
+
+	pageId := 1
+	if !util.IsEmptyString(q.Page) {
+		noPageId, err := strconv.Atoi(q.Page)
+		if err == nil && noPageId > 0 {
+			pageId = noPageId
+		}
+	}
+	param.PageId = &pageId
+
+	return param
+}
@@ -1,6 +1,7 @@
 package queryparamdto
 
 import (
+	"fmt"
 	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/entity"
@@ -33,8 +34,8 @@ func (q GetAllPharmacySellsMonthlyForAdminQuery) ToGetAllParams() (*GetAllParams
 	}
 
 	if !util.IsEmptyString(q.ProductCategoryId) {
-		column := product.GetSqlColumnFromField("ProductCategoryId")
-		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.EqualTo, q.ProductCategoryId))
+		column := fmt.Sprintf("product_category_path_ids(%s)", product.GetSqlColumnFromField("ProductCategoryId"))
+		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.Overlap, fmt.Sprintf("{%s}", q.ProductCategoryId)))
 	}
 	monthPageSize := appconstant.MonthInAYearPageSize
 	param.PageSize = &monthPageSize
@@ -45,3 +45,7 @@ func (q *GetByIdProductQuery) ToGetAllParams() *GetAllParams {
 
 	return param
 }
+
+func (q *GetByIdProductQuery) ToRelatedProductParams() *GetAllParams {
+	return newNearbyProductParams(q.Latitude, q.Longitude)
+}
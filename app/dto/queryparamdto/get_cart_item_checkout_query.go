@@ -56,6 +56,10 @@ func (q *GetCartItemCheckoutQuery) ToGetAllParams() *GetAllParams {
 	return param
 }
 
+func (q *GetCartItemCheckoutQuery) ToSuggestionParams() *GetAllParams {
+	return newNearbyProductParams(q.Latitude, q.Longitude)
+}
+
 func (q *GetCartItemCheckoutQuery) GetCartItemIds() ([]int64, error) {
 	valuesInStr := strings.TrimSpace(q.CartItemIds)
 
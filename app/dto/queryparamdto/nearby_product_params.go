@@ -0,0 +1,35 @@
+package queryparamdto
+
+import (
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+)
+
+// newNearbyProductParams lists products once with their prices in the pharmacies around the location,
+// every pharmacy is used when the location is not given
+func newNearbyProductParams(latitude, longitude string) *GetAllParams {
+	param := NewGetAllParams()
+	product := new(entity.Product)
+	pharmacy := new(entity.Pharmacy)
+
+	if !util.IsEmptyString(latitude) && !util.IsEmptyString(longitude) {
+		latColName := pharmacy.GetSqlColumnFromField("Latitude")
+		lonColName := pharmacy.GetSqlColumnFromField("Longitude")
+
+		param.WhereClauses = append(
+			param.WhereClauses,
+			appdb.NewWhere(
+				fmt.Sprintf("distance(%s, %s, '%s', '%s')", latColName, lonColName, latitude, longitude),
+				appdb.LessOrEqualTo,
+				appconstant.ClosestPharmacyRangeRadius,
+			),
+		)
+	}
+
+	param.GroupClauses = append(param.GroupClauses, appdb.NewGroupClause(product.GetSqlColumnFromField("Id")))
+
+	return param
+}
@@ -1,13 +1,21 @@
 package requestdto
 
-import "halodeksik-be/app/entity"
+import (
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+)
 
 type AddConsultationSession struct {
-	DoctorId int64 `json:"doctor_id" validate:"required"`
+	DoctorId    int64 `json:"doctor_id" validate:"required"`
+	DependentId int64 `json:"dependent_id" validate:"omitempty,min=1"`
 }
 
 func (r *AddConsultationSession) ToConsultationSessionUseCase() entity.ConsultationSession {
-	return entity.ConsultationSession{
+	session := entity.ConsultationSession{
 		DoctorId: r.DoctorId,
 	}
+	if r.DependentId != 0 {
+		session.DependentId = appdb.NewSqlNullInt64(r.DependentId)
+	}
+	return session
 }
@@ -1,15 +1,23 @@
 package requestdto
 
-import "halodeksik-be/app/entity"
+import (
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+)
 
 type AddEditCartItem struct {
 	ProductId int64 `json:"product_id" validate:"required"`
 	Quantity  *int32 `json:"quantity" validate:"required"`
+	PrescriptionProductId int64 `json:"prescription_product_id" validate:"omitempty,min=1"`
 }
 
 func (r AddEditCartItem) ToCartItem() entity.CartItem {
-	return entity.CartItem{
+	cartItem := entity.CartItem{
 		ProductId: r.ProductId,
 		Quantity:  *r.Quantity,
 	}
+	if r.PrescriptionProductId != 0 {
+		cartItem.PrescriptionProductId = appdb.NewSqlNullInt64(r.PrescriptionProductId)
+	}
+	return cartItem
 }
@@ -0,0 +1,23 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strings"
+)
+
+type AddEditDependent struct {
+	Name         string `json:"name" validate:"required"`
+	DateOfBirth  string `json:"date_of_birth" validate:"required,datetime=2006-01-02"`
+	Relationship string `json:"relationship" validate:"required,oneof=child spouse parent sibling other"`
+}
+
+func (r AddEditDependent) ToDependent() entity.Dependent {
+	dateOfBirth, _ := util.ParseDateTime(r.DateOfBirth, appconstant.TimeFormatQueryParam)
+	return entity.Dependent{
+		Name:         strings.TrimSpace(r.Name),
+		DateOfBirth:  dateOfBirth,
+		Relationship: r.Relationship,
+	}
+}
@@ -5,9 +5,10 @@ import (
 )
 
 type AddEditDoctorSpecialization struct {
-	Name string `form:"name" validate:"required"`
+	Name                   string `form:"name" validate:"required"`
+	SessionDurationMinutes int32  `form:"session_duration_minutes" validate:"omitempty,min=1,max=1440"`
 }
 
 func (r AddEditDoctorSpecialization) ToDoctorSpecialization() entity.DoctorSpecialization {
-	return entity.DoctorSpecialization{Name: r.Name}
+	return entity.DoctorSpecialization{Name: r.Name, SessionDurationMinutes: r.SessionDurationMinutes}
 }
@@ -0,0 +1,19 @@
+package requestdto
+
+import "halodeksik-be/app/entity"
+
+type AddEditDrugInteraction struct {
+	GenericNameA              string `json:"generic_name_a" validate:"required"`
+	GenericNameB              string `json:"generic_name_b" validate:"required"`
+	DrugInteractionSeverityId int64  `json:"drug_interaction_severity_id" validate:"required,oneof=1 2 3"`
+	Description               string `json:"description" validate:"required"`
+}
+
+func (r AddEditDrugInteraction) ToDrugInteraction() entity.DrugInteraction {
+	return entity.DrugInteraction{
+		GenericNameA:              r.GenericNameA,
+		GenericNameB:              r.GenericNameB,
+		DrugInteractionSeverityId: r.DrugInteractionSeverityId,
+		Description:               r.Description,
+	}
+}
@@ -1,15 +1,44 @@
 package requestdto
 
-import "halodeksik-be/app/entity"
+import (
+	"database/sql"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+)
 
 type AddEditPrescriptionProduct struct {
-	ProductId int64  `json:"product_id" validate:"required"`
-	Note      string `json:"note" validate:"required"`
+	ProductId    int64  `json:"product_id" validate:"required"`
+	Note         string `json:"note" validate:"required"`
+	Quantity     int32  `json:"quantity" validate:"omitempty,min=1"`
+	DoseAmount   string `json:"dose_amount" validate:"omitempty,numeric,numericgt=0"`
+	DoseUnit     string `json:"dose_unit"`
+	Frequency    int32  `json:"frequency" validate:"omitempty,min=1"`
+	Route        string `json:"route"`
+	DurationDays int32  `json:"duration_days" validate:"omitempty,min=1"`
+	MealTiming   string `json:"meal_timing"`
 }
 
+// ToPrescriptionProduct leaves the dosage left out of the request empty, the note is then the only instruction.
+// Only the quantity to dispense falls back to a default
 func (r AddEditPrescriptionProduct) ToPrescriptionProduct() *entity.PrescriptionProduct {
-	return &entity.PrescriptionProduct{
-		ProductId: r.ProductId,
-		Note:      r.Note,
+	prescriptionProduct := &entity.PrescriptionProduct{
+		ProductId:    r.ProductId,
+		Note:         r.Note,
+		Quantity:     r.Quantity,
+		DoseUnit:     r.DoseUnit,
+		Frequency:    sql.NullInt32{Int32: r.Frequency, Valid: r.Frequency != 0},
+		Route:        sql.NullString{String: r.Route, Valid: !util.IsEmptyString(r.Route)},
+		DurationDays: sql.NullInt32{Int32: r.DurationDays, Valid: r.DurationDays != 0},
+		MealTiming:   sql.NullString{String: r.MealTiming, Valid: !util.IsEmptyString(r.MealTiming)},
 	}
+	if !util.IsEmptyString(r.DoseAmount) {
+		doseAmount, _ := decimal.NewFromString(r.DoseAmount)
+		prescriptionProduct.DoseAmount = decimal.NewNullDecimal(doseAmount)
+	}
+	if prescriptionProduct.Quantity == 0 {
+		prescriptionProduct.Quantity = appconstant.PrescriptionDefaultQuantity
+	}
+	return prescriptionProduct
 }
@@ -0,0 +1,51 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type AddEditPrescriptionTemplate struct {
+	Name     string                       `json:"name" validate:"required"`
+	Products []AddEditPrescriptionProduct `json:"products" validate:"required,min=1,dive"`
+}
+
+func (r AddEditPrescriptionTemplate) ToPrescriptionTemplate() entity.PrescriptionTemplate {
+	products := make([]*entity.PrescriptionTemplateProduct, 0)
+	for _, product := range r.Products {
+		prescriptionProduct := product.ToPrescriptionProduct()
+		products = append(products, &entity.PrescriptionTemplateProduct{
+			ProductId:    prescriptionProduct.ProductId,
+			Note:         prescriptionProduct.Note,
+			Quantity:     prescriptionProduct.Quantity,
+			DoseAmount:   prescriptionProduct.DoseAmount,
+			DoseUnit:     prescriptionProduct.DoseUnit,
+			Frequency:    prescriptionProduct.Frequency,
+			Route:        prescriptionProduct.Route,
+			DurationDays: prescriptionProduct.DurationDays,
+			MealTiming:   prescriptionProduct.MealTiming,
+		})
+	}
+
+	return entity.PrescriptionTemplate{
+		Name:     strings.TrimSpace(r.Name),
+		Products: products,
+	}
+}
+
+// ApplyPrescriptionTemplate carries what a template cannot know in advance, the products come from the template
+type ApplyPrescriptionTemplate struct {
+	SessionId                 int64  `json:"session_id" validate:"required"`
+	Symptoms                  string `json:"symptoms" validate:"required"`
+	Diagnosis                 string `json:"diagnosis" validate:"required"`
+	InteractionOverrideReason string `json:"interaction_override_reason"`
+}
+
+func (r ApplyPrescriptionTemplate) ToPrescription() entity.Prescription {
+	return entity.Prescription{
+		SessionId:                 r.SessionId,
+		Symptoms:                  r.Symptoms,
+		Diagnosis:                 r.Diagnosis,
+		InteractionOverrideReason: strings.TrimSpace(r.InteractionOverrideReason),
+	}
+}
@@ -1,17 +1,21 @@
 package requestdto
 
 import (
+	"database/sql"
 	"halodeksik-be/app/entity"
 	"strings"
 )
 
 type AddEditProductCategory struct {
 	Name string `json:"name" validate:"required"`
+	// ParentId is only used when adding, an existing category is moved with MoveProductCategory
+	ParentId int64 `json:"parent_id" validate:"omitempty,min=1"`
 }
 
 func (r AddEditProductCategory) ToProductCategory() entity.ProductCategory {
 	r.Name = strings.TrimSpace(r.Name)
 	return entity.ProductCategory{
-		Name: r.Name,
+		Name:     r.Name,
+		ParentId: sql.NullInt64{Int64: r.ParentId, Valid: r.ParentId != 0},
 	}
 }
@@ -0,0 +1,29 @@
+package requestdto
+
+import (
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/entity"
+	"time"
+)
+
+type AddMedicalProfileMeasurement struct {
+	HeightCm   string `json:"height_cm" validate:"required,numeric,numericgt=0"`
+	WeightKg   string `json:"weight_kg" validate:"required,numeric,numericgt=0"`
+	MeasuredAt string `json:"measured_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+func (r AddMedicalProfileMeasurement) ToMedicalProfileMeasurement() entity.MedicalProfileMeasurement {
+	heightCm, _ := decimal.NewFromString(r.HeightCm)
+	weightKg, _ := decimal.NewFromString(r.WeightKg)
+
+	measuredAt := time.Now()
+	if r.MeasuredAt != "" {
+		measuredAt, _ = time.Parse(time.RFC3339, r.MeasuredAt)
+	}
+
+	return entity.MedicalProfileMeasurement{
+		HeightCm:   heightCm,
+		WeightKg:   weightKg,
+		MeasuredAt: measuredAt,
+	}
+}
@@ -0,0 +1,52 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strings"
+)
+
+type AddMedicationReminder struct {
+	DependentId    int64    `json:"dependent_id" validate:"omitempty,min=1"`
+	MedicationName string   `json:"medication_name" validate:"required"`
+	Instruction    string   `json:"instruction"`
+	StartDate      string   `json:"start_date" validate:"required,datetime=2006-01-02"`
+	DurationDays   int32    `json:"duration_days" validate:"required,min=1,max=90"`
+	TimesOfDay     []string `json:"times_of_day" validate:"required,min=1,max=24,unique,dive,datetime=15:04"`
+}
+
+func (r AddMedicationReminder) ToMedicationReminder() entity.MedicationReminder {
+	startDate, _ := util.ParseDateTime(r.StartDate, appconstant.TimeFormatQueryParam)
+	reminder := entity.MedicationReminder{
+		MedicationName: strings.TrimSpace(r.MedicationName),
+		Instruction:    strings.TrimSpace(r.Instruction),
+		StartDate:      startDate,
+		DurationDays:   r.DurationDays,
+		TimesOfDay:     r.TimesOfDay,
+	}
+	if r.DependentId != 0 {
+		reminder.DependentId = appdb.NewSqlNullInt64(r.DependentId)
+	}
+	return reminder
+}
+
+// AddMedicationReminderFromPrescription takes the medication, dose and duration from the prescription product,
+// the start date defaults to today and the times of day to an even spread over the prescribed frequency
+type AddMedicationReminderFromPrescription struct {
+	StartDate  string   `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	TimesOfDay []string `json:"times_of_day" validate:"omitempty,max=24,unique,dive,datetime=15:04"`
+}
+
+func (r AddMedicationReminderFromPrescription) ToMedicationReminder() entity.MedicationReminder {
+	reminder := entity.MedicationReminder{TimesOfDay: r.TimesOfDay}
+	if r.StartDate != "" {
+		reminder.StartDate, _ = util.ParseDateTime(r.StartDate, appconstant.TimeFormatQueryParam)
+	}
+	return reminder
+}
+
+type EditMedicationReminderDose struct {
+	Status string `json:"status" validate:"required,oneof=taken skipped"`
+}
@@ -1,12 +1,16 @@
 package requestdto
 
-import "halodeksik-be/app/entity"
+import (
+	"halodeksik-be/app/entity"
+	"strings"
+)
 
 type AddPrescription struct {
-	SessionId            int64                        `json:"session_id" validate:"required"`
-	Symptoms             string                       `json:"symptoms" validate:"required"`
-	Diagnosis            string                       `json:"diagnosis" validate:"required"`
-	PrescriptionProducts []AddEditPrescriptionProduct `json:"prescription_products" validate:"required,dive"`
+	SessionId                 int64                        `json:"session_id" validate:"required"`
+	Symptoms                  string                       `json:"symptoms" validate:"required"`
+	Diagnosis                 string                       `json:"diagnosis" validate:"required"`
+	PrescriptionProducts      []AddEditPrescriptionProduct `json:"prescription_products" validate:"required,dive"`
+	InteractionOverrideReason string                       `json:"interaction_override_reason"`
 }
 
 func (r AddPrescription) ToPrescription() entity.Prescription {
@@ -16,9 +20,10 @@ func (r AddPrescription) ToPrescription() entity.Prescription {
 	}
 
 	return entity.Prescription{
-		SessionId:            r.SessionId,
-		Symptoms:             r.Symptoms,
-		Diagnosis:            r.Diagnosis,
-		PrescriptionProducts: prescriptionProducts,
+		SessionId:                 r.SessionId,
+		Symptoms:                  r.Symptoms,
+		Diagnosis:                 r.Diagnosis,
+		PrescriptionProducts:      prescriptionProducts,
+		InteractionOverrideReason: strings.TrimSpace(r.InteractionOverrideReason),
 	}
 }
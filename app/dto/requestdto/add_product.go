@@ -1,6 +1,7 @@
 package requestdto
 
 import (
+	"database/sql"
 	"halodeksik-be/app/entity"
 	"mime/multipart"
 )
@@ -20,6 +21,7 @@ type AddProduct struct {
 	Length               float64               `json:"length" form:"length" validate:"required"`
 	Width                float64               `json:"width" form:"width" validate:"required"`
 	Height               float64               `json:"height" form:"height" validate:"required"`
+	Code                 string                `json:"code" form:"code" validate:"omitempty,max=64"`
 	Image                *multipart.FileHeader `json:"image" form:"image" validate:"required,filetype=png jpg jpeg,filesize=500"`
 }
 
@@ -39,5 +41,6 @@ func (r AddProduct) ToProduct() entity.Product {
 		Length:               r.Length,
 		Width:                r.Width,
 		Height:               r.Height,
+		Code:                 sql.NullString{String: r.Code, Valid: r.Code != ""},
 	}
 }
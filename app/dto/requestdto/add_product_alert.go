@@ -0,0 +1,15 @@
+package requestdto
+
+import "halodeksik-be/app/entity"
+
+type AddProductAlert struct {
+	ProductId     int64 `json:"product_id" validate:"required,min=1"`
+	NotifyByEmail bool  `json:"notify_by_email"`
+}
+
+func (r AddProductAlert) ToProductAlert() entity.ProductAlert {
+	return entity.ProductAlert{
+		ProductId:     r.ProductId,
+		NotifyByEmail: r.NotifyByEmail,
+	}
+}
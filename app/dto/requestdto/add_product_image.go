@@ -0,0 +1,7 @@
+package requestdto
+
+import "mime/multipart"
+
+type AddProductImage struct {
+	Image *multipart.FileHeader `json:"image" form:"image" validate:"required,filetype=png jpg jpeg,filesize=2048"`
+}
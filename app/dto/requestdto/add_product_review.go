@@ -0,0 +1,22 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/entity"
+	"mime/multipart"
+	"strings"
+)
+
+type AddProductReview struct {
+	OrderDetailId int64                   `form:"order_detail_id" validate:"required,min=1"`
+	Rating        int32                   `form:"rating" validate:"required,min=1,max=5"`
+	Comment       string                  `form:"comment" validate:"required,max=2000"`
+	Photos        []*multipart.FileHeader `form:"photos" validate:"omitempty,max=3,dive,filetype=png jpg jpeg,filesize=2048"`
+}
+
+func (r AddProductReview) ToProductReview() entity.ProductReview {
+	return entity.ProductReview{
+		OrderDetailId: r.OrderDetailId,
+		Rating:        r.Rating,
+		Comment:       strings.TrimSpace(r.Comment),
+	}
+}
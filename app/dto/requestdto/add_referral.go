@@ -0,0 +1,34 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type AddReferral struct {
+	SessionId              int64  `json:"session_id" validate:"required,min=1"`
+	DoctorSpecializationId int64  `json:"doctor_specialization_id" validate:"required_without=TargetDoctorId,omitempty,min=1"`
+	TargetDoctorId         int64  `json:"target_doctor_id" validate:"omitempty,min=1"`
+	ClinicalSummary        string `json:"clinical_summary" validate:"required"`
+}
+
+func (r AddReferral) ToReferral() entity.Referral {
+	referral := entity.Referral{
+		SessionId:       r.SessionId,
+		ClinicalSummary: strings.TrimSpace(r.ClinicalSummary),
+	}
+	if r.DoctorSpecializationId != 0 {
+		referral.DoctorSpecializationId = appdb.NewSqlNullInt64(r.DoctorSpecializationId)
+	}
+	if r.TargetDoctorId != 0 {
+		referral.TargetDoctorId = appdb.NewSqlNullInt64(r.TargetDoctorId)
+	}
+	return referral
+}
+
+// AddReferralSession only needs a doctor when the referral names a specialization without a specific doctor,
+// it is ignored otherwise
+type AddReferralSession struct {
+	DoctorId int64 `json:"doctor_id" validate:"omitempty,min=1"`
+}
@@ -8,10 +8,12 @@ type AddTransaction struct {
 type AddOrder struct {
 	ShippingMethodId int64             `json:"shipping_method_id" validate:"required"`
 	ShippingCost     string            `json:"shipping_cost" validate:"required,numeric,numericgt=0"`
+	DependentId      int64             `json:"dependent_id" validate:"omitempty,min=1"`
 	OrderDetails     []AddOrderDetails `json:"order_details" validate:"min=1,required,dive"`
 }
 
 type AddOrderDetails struct {
-	Quantity          int32 `json:"quantity" validate:"required,min=1"`
-	PharmacyProductId int64 `json:"pharmacy_product_id" validate:"required"`
+	Quantity              int32 `json:"quantity" validate:"required,min=1"`
+	PharmacyProductId     int64 `json:"pharmacy_product_id" validate:"required"`
+	PrescriptionProductId int64 `json:"prescription_product_id" validate:"omitempty,min=1"`
 }
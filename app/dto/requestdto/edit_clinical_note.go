@@ -0,0 +1,26 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+// EditClinicalNote carries the version the doctor started editing from, 0 when the note does not exist yet,
+// so a save based on an outdated version is rejected instead of silently overwriting
+type EditClinicalNote struct {
+	Subjective string `json:"subjective" validate:"required_without_all=Objective Assessment Plan"`
+	Objective  string `json:"objective"`
+	Assessment string `json:"assessment"`
+	Plan       string `json:"plan"`
+	Version    int32  `json:"version" validate:"min=0"`
+}
+
+func (r EditClinicalNote) ToClinicalNote() entity.ClinicalNote {
+	return entity.ClinicalNote{
+		Subjective: strings.TrimSpace(r.Subjective),
+		Objective:  strings.TrimSpace(r.Objective),
+		Assessment: strings.TrimSpace(r.Assessment),
+		Plan:       strings.TrimSpace(r.Plan),
+		Version:    r.Version,
+	}
+}
@@ -0,0 +1,75 @@
+package requestdto
+
+import (
+	"database/sql"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+	"strings"
+)
+
+type EditMedicalProfile struct {
+	BloodType   string                         `json:"blood_type" validate:"omitempty,oneof=A+ A- B+ B- AB+ AB- O+ O-"`
+	Allergies   []EditMedicalProfileAllergy    `json:"allergies" validate:"dive"`
+	Conditions  []EditMedicalProfileCondition  `json:"conditions" validate:"dive"`
+	Medications []EditMedicalProfileMedication `json:"medications" validate:"dive"`
+}
+
+type EditMedicalProfileAllergy struct {
+	Allergen    string `json:"allergen" validate:"required"`
+	GenericName string `json:"generic_name"`
+	Reaction    string `json:"reaction"`
+}
+
+type EditMedicalProfileCondition struct {
+	Name        string `json:"name" validate:"required"`
+	DiagnosedAt string `json:"diagnosed_at" validate:"omitempty,datetime=2006-01-02"`
+	Note        string `json:"note"`
+}
+
+type EditMedicalProfileMedication struct {
+	Name        string `json:"name" validate:"required"`
+	GenericName string `json:"generic_name"`
+	Dosage      string `json:"dosage"`
+}
+
+func (r EditMedicalProfile) ToMedicalProfile() entity.MedicalProfile {
+	allergies := make([]*entity.MedicalProfileAllergy, 0)
+	for _, allergy := range r.Allergies {
+		allergies = append(allergies, &entity.MedicalProfileAllergy{
+			Allergen:    strings.TrimSpace(allergy.Allergen),
+			GenericName: entity.NormalizeGenericName(allergy.GenericName),
+			Reaction:    strings.TrimSpace(allergy.Reaction),
+		})
+	}
+
+	conditions := make([]*entity.MedicalProfileCondition, 0)
+	for _, condition := range r.Conditions {
+		diagnosedAt := sql.NullTime{}
+		if !util.IsEmptyString(condition.DiagnosedAt) {
+			parsed, _ := util.ParseDateTime(condition.DiagnosedAt, appconstant.TimeFormatQueryParam)
+			diagnosedAt = sql.NullTime{Time: parsed, Valid: true}
+		}
+		conditions = append(conditions, &entity.MedicalProfileCondition{
+			Name:        strings.TrimSpace(condition.Name),
+			DiagnosedAt: diagnosedAt,
+			Note:        strings.TrimSpace(condition.Note),
+		})
+	}
+
+	medications := make([]*entity.MedicalProfileMedication, 0)
+	for _, medication := range r.Medications {
+		medications = append(medications, &entity.MedicalProfileMedication{
+			Name:        strings.TrimSpace(medication.Name),
+			GenericName: entity.NormalizeGenericName(medication.GenericName),
+			Dosage:      strings.TrimSpace(medication.Dosage),
+		})
+	}
+
+	return entity.MedicalProfile{
+		BloodType:   r.BloodType,
+		Allergies:   allergies,
+		Conditions:  conditions,
+		Medications: medications,
+	}
+}
@@ -0,0 +1,11 @@
+package requestdto
+
+import "halodeksik-be/app/entity"
+
+type EditMedicalRecordConsent struct {
+	IsGranted *bool `json:"is_granted" validate:"required"`
+}
+
+func (r EditMedicalRecordConsent) ToMedicalRecordConsent() entity.MedicalRecordConsent {
+	return entity.MedicalRecordConsent{IsGranted: *r.IsGranted}
+}
@@ -1,11 +1,15 @@
 package requestdto
 
-import "halodeksik-be/app/entity"
+import (
+	"halodeksik-be/app/entity"
+	"strings"
+)
 
 type EditPrescription struct {
-	Symptoms             string                       `json:"symptoms" validate:"required"`
-	Diagnosis            string                       `json:"diagnosis" validate:"required"`
-	PrescriptionProducts []AddEditPrescriptionProduct `json:"prescription_products" validate:"required,dive"`
+	Symptoms                  string                       `json:"symptoms" validate:"required"`
+	Diagnosis                 string                       `json:"diagnosis" validate:"required"`
+	PrescriptionProducts      []AddEditPrescriptionProduct `json:"prescription_products" validate:"required,dive"`
+	InteractionOverrideReason string                       `json:"interaction_override_reason"`
 }
 
 func (r EditPrescription) ToPrescription() entity.Prescription {
@@ -15,8 +19,9 @@ func (r EditPrescription) ToPrescription() entity.Prescription {
 	}
 
 	return entity.Prescription{
-		Symptoms:             r.Symptoms,
-		Diagnosis:            r.Diagnosis,
-		PrescriptionProducts: prescriptionProducts,
+		Symptoms:                  r.Symptoms,
+		Diagnosis:                 r.Diagnosis,
+		PrescriptionProducts:      prescriptionProducts,
+		InteractionOverrideReason: strings.TrimSpace(r.InteractionOverrideReason),
 	}
 }
@@ -1,6 +1,7 @@
 package requestdto
 
 import (
+	"database/sql"
 	"halodeksik-be/app/entity"
 )
 
@@ -19,6 +20,7 @@ type EditProduct struct {
 	Length               float64               `json:"length" form:"length" validate:"required"`
 	Width                float64               `json:"width" form:"width" validate:"required"`
 	Height               float64               `json:"height" form:"height" validate:"required"`
+	Code                 string                `json:"code" form:"code" validate:"omitempty,max=64"`
 }
 
 func (r EditProduct) ToProduct() entity.Product {
@@ -37,5 +39,6 @@ func (r EditProduct) ToProduct() entity.Product {
 		Length:               r.Length,
 		Width:                r.Width,
 		Height:               r.Height,
+		Code:                 sql.NullString{String: r.Code, Valid: r.Code != ""},
 	}
 }
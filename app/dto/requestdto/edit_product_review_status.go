@@ -0,0 +1,18 @@
+package requestdto
+
+import (
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type EditProductReviewStatus struct {
+	Status         string `json:"status" validate:"required,oneof=published hidden"`
+	ModerationNote string `json:"moderation_note" validate:"max=500"`
+}
+
+func (r EditProductReviewStatus) ToProductReview() entity.ProductReview {
+	return entity.ProductReview{
+		Status:         r.Status,
+		ModerationNote: strings.TrimSpace(r.ModerationNote),
+	}
+}
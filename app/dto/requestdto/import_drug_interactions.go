@@ -0,0 +1,9 @@
+package requestdto
+
+import (
+	"mime/multipart"
+)
+
+type ImportDrugInteractions struct {
+	File *multipart.FileHeader `form:"file" validate:"required,filesize=2048"`
+}
@@ -0,0 +1,9 @@
+package requestdto
+
+import "mime/multipart"
+
+type ImportPharmacyProducts struct {
+	File       *multipart.FileHeader `form:"file" validate:"required,filesize=2048"`
+	PharmacyId int64                 `form:"pharmacy_id" validate:"required,min=1"`
+	DryRun     bool                  `form:"dry_run"`
+}
@@ -0,0 +1,116 @@
+package requestdto
+
+import (
+	"encoding/csv"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type ImportProducts struct {
+	File   *multipart.FileHeader `form:"file" validate:"required,filesize=2048"`
+	DryRun bool                  `form:"dry_run"`
+}
+
+// ImportProductRow is one csv row of a product import, columns are matched to the fields by their csv tag
+type ImportProductRow struct {
+	Name               string `csv:"name" validate:"required"`
+	GenericName        string `csv:"generic_name" validate:"required"`
+	Content            string `csv:"content" validate:"required"`
+	Manufacturer       string `csv:"manufacturer" validate:"required"`
+	Description        string `csv:"description" validate:"required"`
+	DrugClassification string `csv:"drug_classification" validate:"required"`
+	ProductCategory    string `csv:"product_category" validate:"required"`
+	DrugForm           string `csv:"drug_form" validate:"required"`
+	UnitInPack         string `csv:"unit_in_pack" validate:"required"`
+	SellingUnit        string `csv:"selling_unit" validate:"required"`
+	Weight             string `csv:"weight" validate:"required,numericgt=0"`
+	Length             string `csv:"length" validate:"required,numericgt=0"`
+	Width              string `csv:"width" validate:"required,numericgt=0"`
+	Height             string `csv:"height" validate:"required,numericgt=0"`
+	Image              string `csv:"image" validate:"required,url"`
+}
+
+// ReadImportProductRows reads every csv row of a product import, the header can list the columns in any order
+func ReadImportProductRows(file io.Reader) ([]*ImportProductRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	// short rows are kept so their missing columns are reported as row errors instead of failing the whole file
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.ErrProductImportInvalidFile
+	}
+	columnIndexes := make(map[string]int)
+	for index, column := range header {
+		// spreadsheet exports often start the file with a byte order mark
+		columnIndexes[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = index
+	}
+
+	rowType := reflect.TypeOf(ImportProductRow{})
+	fieldColumns := make([]int, rowType.NumField())
+	for index := 0; index < rowType.NumField(); index++ {
+		columnIndex, ok := columnIndexes[rowType.Field(index).Tag.Get("csv")]
+		if !ok {
+			return nil, apperror.ErrProductImportInvalidFile
+		}
+		fieldColumns[index] = columnIndex
+	}
+
+	rows := make([]*ImportProductRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apperror.ErrProductImportInvalidFile
+		}
+		if len(rows) == appconstant.ProductImportMaxRows {
+			return nil, apperror.ErrProductImportTooManyRows
+		}
+
+		var row ImportProductRow
+		value := reflect.ValueOf(&row).Elem()
+		for index, columnIndex := range fieldColumns {
+			if columnIndex < len(record) {
+				value.Field(index).SetString(strings.TrimSpace(record[columnIndex]))
+			}
+		}
+		rows = append(rows, &row)
+	}
+	if len(rows) == 0 {
+		return nil, apperror.ErrProductImportInvalidFile
+	}
+
+	return rows, nil
+}
+
+func (r ImportProductRow) ToProduct() entity.Product {
+	weight, _ := strconv.ParseFloat(r.Weight, 64)
+	length, _ := strconv.ParseFloat(r.Length, 64)
+	width, _ := strconv.ParseFloat(r.Width, 64)
+	height, _ := strconv.ParseFloat(r.Height, 64)
+
+	return entity.Product{
+		Name:        r.Name,
+		GenericName: r.GenericName,
+		Content:     r.Content,
+		Description: r.Description,
+		DrugForm:    r.DrugForm,
+		UnitInPack:  r.UnitInPack,
+		SellingUnit: r.SellingUnit,
+		Weight:      weight,
+		Length:      length,
+		Width:       width,
+		Height:      height,
+		Image:       r.Image,
+	}
+}
@@ -0,0 +1,12 @@
+package requestdto
+
+import "database/sql"
+
+// MoveProductCategory moves a category together with its descendants, an empty parent_id makes it a root category
+type MoveProductCategory struct {
+	ParentId int64 `json:"parent_id" validate:"omitempty,min=1"`
+}
+
+func (r MoveProductCategory) ToParentId() sql.NullInt64 {
+	return sql.NullInt64{Int64: r.ParentId, Valid: r.ParentId != 0}
+}
@@ -0,0 +1,5 @@
+package requestdto
+
+type ReorderProductImages struct {
+	ImageIds []int64 `json:"image_ids" validate:"required,min=1,dive,min=1"`
+}
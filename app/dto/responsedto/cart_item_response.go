@@ -5,6 +5,7 @@ type CartItemResponse struct {
 	UserId                          int64                            `json:"user_id"`
 	ProductId                       int64                            `json:"product_id"`
 	Quantity                        int32                            `json:"quantity"`
+	PrescriptionProductId           int64                            `json:"prescription_product_id,omitempty"`
 	ProductResponse                 *ProductResponse                 `json:"product,omitempty"`
 	PharmacyProductCheckoutResponse *PharmacyProductCheckoutResponse `json:"pharmacy_product,omitempty"`
 }
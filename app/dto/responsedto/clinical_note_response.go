@@ -0,0 +1,26 @@
+package responsedto
+
+type ClinicalNoteResponse struct {
+	Id         int64  `json:"id"`
+	SessionId  int64  `json:"session_id"`
+	DoctorId   int64  `json:"doctor_id"`
+	Subjective string `json:"subjective"`
+	Objective  string `json:"objective"`
+	Assessment string `json:"assessment"`
+	Plan       string `json:"plan"`
+	Version    int32  `json:"version"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type ClinicalNoteVersionResponse struct {
+	Id             int64  `json:"id"`
+	ClinicalNoteId int64  `json:"clinical_note_id"`
+	Version        int32  `json:"version"`
+	Subjective     string `json:"subjective"`
+	Objective      string `json:"objective"`
+	Assessment     string `json:"assessment"`
+	Plan           string `json:"plan"`
+	EditedBy       int64  `json:"edited_by"`
+	CreatedAt      string `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package responsedto
+
+import "time"
+
+type ConsultationSessionExtensionResponse struct {
+	Id               int64      `json:"id"`
+	SessionId        int64      `json:"session_id"`
+	DurationMinutes  int32      `json:"duration_minutes"`
+	Fee              string     `json:"fee"`
+	PaymentProof     string     `json:"payment_proof"`
+	Status           string     `json:"status"`
+	SessionExpiresAt *time.Time `json:"session_expires_at,omitempty"`
+	CreatedAt        string     `json:"created_at"`
+	UpdatedAt        string     `json:"updated_at"`
+}
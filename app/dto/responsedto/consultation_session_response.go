@@ -7,14 +7,18 @@ import (
 type ConsultationSessionResponse struct {
 	Id                          int64                              `json:"id"`
 	UserId                      int64                              `json:"user_id"`
+	DependentId                 int64                              `json:"dependent_id,omitempty"`
 	DoctorId                    int64                              `json:"doctor_id"`
 	ConsultationSessionStatusId int64                              `json:"consultation_session_status_id"`
+	ExpiresAt                   *time.Time                         `json:"expires_at,omitempty"`
 	CreatedAt                   time.Time                          `json:"created_at"`
 	UpdatedAt                   time.Time                          `json:"updated_at"`
 	ConsultationSessionStatus   *ConsultationSessionStatusResponse `json:"consultation_session_status,omitempty"`
 	UserProfile                 *ProfileResponse                   `json:"user,omitempty"`
+	Dependent                   *DependentResponse                 `json:"dependent,omitempty"`
 	DoctorProfile               *ProfileResponse                   `json:"doctor,omitempty"`
 	Prescription                *PrescriptionResponse              `json:"prescription,omitempty"`
 	SickLeaveForm               *SickLeaveFormResponse             `json:"sick_leave_form,omitempty"`
+	ClinicalNote                *ClinicalNoteResponse              `json:"clinical_note,omitempty"`
 	Message                     []*WsConsultationMessage           `json:"messages"`
 }
@@ -0,0 +1,9 @@
+package responsedto
+
+type DependentResponse struct {
+	Id           int64  `json:"id"`
+	UserId       int64  `json:"user_id"`
+	Name         string `json:"name"`
+	DateOfBirth  string `json:"date_of_birth"`
+	Relationship string `json:"relationship"`
+}
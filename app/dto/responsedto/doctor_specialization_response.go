@@ -1,7 +1,8 @@
 package responsedto
 
 type SpecializationResponse struct {
-	Id    int64  `json:"id,omitempty"`
-	Name  string `json:"name,omitempty"`
-	Image string `json:"image"`
+	Id                     int64  `json:"id,omitempty"`
+	Name                   string `json:"name,omitempty"`
+	Image                  string `json:"image"`
+	SessionDurationMinutes int32  `json:"session_duration_minutes,omitempty"`
 }
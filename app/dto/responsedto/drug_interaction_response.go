@@ -0,0 +1,22 @@
+package responsedto
+
+type DrugInteractionResponse struct {
+	Id                        int64  `json:"id"`
+	GenericNameA              string `json:"generic_name_a"`
+	GenericNameB              string `json:"generic_name_b"`
+	DrugInteractionSeverityId int64  `json:"drug_interaction_severity_id"`
+	Severity                  string `json:"severity"`
+	Description               string `json:"description"`
+}
+
+type DrugInteractionImportResponse struct {
+	TotalRows int                                   `json:"total_rows"`
+	Imported  int                                   `json:"imported"`
+	Failed    int                                   `json:"failed"`
+	Errors    []*DrugInteractionImportErrorResponse `json:"errors"`
+}
+
+type DrugInteractionImportErrorResponse struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,40 @@
+package responsedto
+
+type MedicalProfileResponse struct {
+	UserId            int64                                `json:"user_id"`
+	DependentId       int64                                `json:"dependent_id,omitempty"`
+	BloodType         string                               `json:"blood_type"`
+	LatestMeasurement *MedicalProfileMeasurementResponse   `json:"latest_measurement"`
+	Allergies         []*MedicalProfileAllergyResponse     `json:"allergies"`
+	Conditions        []*MedicalProfileConditionResponse   `json:"conditions"`
+	Medications       []*MedicalProfileMedicationResponse  `json:"medications"`
+	Measurements      []*MedicalProfileMeasurementResponse `json:"measurements"`
+}
+
+type MedicalProfileAllergyResponse struct {
+	Id          int64  `json:"id"`
+	Allergen    string `json:"allergen"`
+	GenericName string `json:"generic_name,omitempty"`
+	Reaction    string `json:"reaction,omitempty"`
+}
+
+type MedicalProfileConditionResponse struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	DiagnosedAt string `json:"diagnosed_at,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+type MedicalProfileMedicationResponse struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	GenericName string `json:"generic_name,omitempty"`
+	Dosage      string `json:"dosage,omitempty"`
+}
+
+type MedicalProfileMeasurementResponse struct {
+	Id         int64  `json:"id"`
+	HeightCm   string `json:"height_cm"`
+	WeightKg   string `json:"weight_kg"`
+	MeasuredAt string `json:"measured_at"`
+}
@@ -0,0 +1,18 @@
+package responsedto
+
+type MedicalRecordResponse struct {
+	Id          int64  `json:"id"`
+	Type        string `json:"type"`
+	SessionId   int64  `json:"session_id"`
+	DoctorId    int64  `json:"doctor_id"`
+	DoctorName  string `json:"doctor_name"`
+	OccurredAt  string `json:"occurred_at"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type MedicalRecordConsentResponse struct {
+	SessionId int64  `json:"session_id"`
+	IsGranted bool   `json:"is_granted"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
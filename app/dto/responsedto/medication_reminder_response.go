@@ -0,0 +1,32 @@
+package responsedto
+
+type MedicationReminderResponse struct {
+	Id                    int64                                `json:"id"`
+	UserId                int64                                `json:"user_id"`
+	DependentId           int64                                `json:"dependent_id,omitempty"`
+	PrescriptionProductId int64                                `json:"prescription_product_id,omitempty"`
+	MedicationName        string                               `json:"medication_name"`
+	Instruction           string                               `json:"instruction"`
+	TimesOfDay            []string                             `json:"times_of_day"`
+	StartDate             string                               `json:"start_date"`
+	DurationDays          int32                                `json:"duration_days"`
+	Adherence             *MedicationReminderAdherenceResponse `json:"adherence,omitempty"`
+	Doses                 []*MedicationReminderDoseResponse    `json:"doses,omitempty"`
+}
+
+type MedicationReminderDoseResponse struct {
+	Id                   int64  `json:"id"`
+	MedicationReminderId int64  `json:"medication_reminder_id"`
+	ScheduledAt          string `json:"scheduled_at"`
+	Status               string `json:"status"`
+	LoggedAt             string `json:"logged_at,omitempty"`
+}
+
+type MedicationReminderAdherenceResponse struct {
+	TotalDoses    int64   `json:"total_doses"`
+	DueDoses      int64   `json:"due_doses"`
+	TakenDoses    int64   `json:"taken_doses"`
+	SkippedDoses  int64   `json:"skipped_doses"`
+	MissedDoses   int64   `json:"missed_doses"`
+	AdherenceRate float64 `json:"adherence_rate"`
+}
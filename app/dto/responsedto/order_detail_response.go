@@ -3,13 +3,27 @@ package responsedto
 import "time"
 
 type OrderDetailResponse struct {
-	Name        string `json:"name"`
-	GenericName string `json:"generic_name"`
-	Content     string `json:"content"`
-	Description string `json:"description"`
-	Image       string `json:"image"`
-	Price       string `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	Name                  string                           `json:"name"`
+	GenericName           string                           `json:"generic_name"`
+	Content               string                           `json:"content"`
+	Description           string                           `json:"description"`
+	Image                 string                           `json:"image"`
+	Price                 string                           `json:"price"`
+	Quantity              int32                            `json:"quantity"`
+	PrescriptionProductId int64                            `json:"prescription_product_id,omitempty"`
+	Prescription          *OrderDetailPrescriptionResponse `json:"prescription,omitempty"`
+}
+
+type OrderDetailPrescriptionResponse struct {
+	PrescriptionId     int64  `json:"prescription_id"`
+	SessionId          int64  `json:"session_id"`
+	DoctorName         string `json:"doctor_name"`
+	PatientName        string `json:"patient_name"`
+	PrescribedQuantity int32  `json:"prescribed_quantity"`
+	Note               string `json:"note"`
+	Instruction        string `json:"instruction"`
+	IssuedAt           string `json:"issued_at"`
+	ExpiredAt          string `json:"expired_at"`
 }
 
 type OrderDetailFullResponse struct {
@@ -20,5 +34,6 @@ type OrderDetailFullResponse struct {
 	ShippingCost        string                        `json:"shipping_cost"`
 	Pharmacy            *PharmacyIdNameResponse       `json:"pharmacy"`
 	UserAddress         string                        `json:"user_address"`
+	DependentId         int64                         `json:"dependent_id,omitempty"`
 	OrderDetails        []*OrderDetailResponse        `json:"order_details"`
 }
@@ -0,0 +1,21 @@
+package responsedto
+
+type PharmacyProductImportResponse struct {
+	PharmacyId int64                               `json:"pharmacy_id"`
+	IsDryRun   bool                                `json:"is_dry_run"`
+	IsApplied  bool                                `json:"is_applied"`
+	TotalRows  int                                 `json:"total_rows"`
+	Created    int                                 `json:"created"`
+	Updated    int                                 `json:"updated"`
+	Unchanged  int                                 `json:"unchanged"`
+	Failed     int                                 `json:"failed"`
+	Rows       []*PharmacyProductImportRowResponse `json:"rows"`
+}
+
+type PharmacyProductImportRowResponse struct {
+	RowNumber       int32    `json:"row_number"`
+	ProductId       int64    `json:"product_id,omitempty"`
+	Status          string   `json:"status"`
+	StockDifference int32    `json:"stock_difference"`
+	Errors          []string `json:"errors,omitempty"`
+}
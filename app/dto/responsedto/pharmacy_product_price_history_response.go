@@ -0,0 +1,14 @@
+package responsedto
+
+import "time"
+
+type PharmacyProductPriceHistoryResponse struct {
+	Id                int64     `json:"id"`
+	PharmacyProductId int64     `json:"pharmacy_product_id"`
+	OldPrice          string    `json:"old_price,omitempty"`
+	NewPrice          string    `json:"new_price"`
+	ChangedBy         int64     `json:"changed_by,omitempty"`
+	ChangedByEmail    string    `json:"changed_by_email,omitempty"`
+	Source            string    `json:"source"`
+	ChangedAt         time.Time `json:"changed_at"`
+}
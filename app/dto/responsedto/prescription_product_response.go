@@ -5,11 +5,21 @@ import (
 )
 
 type PrescriptionProductResponse struct {
-	Id             int64            `json:"id"`
-	PrescriptionId int64            `json:"prescription_id,omitempty"`
-	ProductId      int64            `json:"product_id"`
-	Note           string           `json:"note"`
-	CreatedAt      time.Time        `json:"created_at"`
-	UpdatedAt      time.Time        `json:"updated_at"`
-	Product        *ProductResponse `json:"product,omitempty"`
+	Id                int64            `json:"id"`
+	PrescriptionId    int64            `json:"prescription_id,omitempty"`
+	ProductId         int64            `json:"product_id"`
+	Note              string           `json:"note"`
+	Quantity          int32            `json:"quantity"`
+	DoseAmount        string           `json:"dose_amount,omitempty"`
+	DoseUnit          string           `json:"dose_unit,omitempty"`
+	Frequency         int32            `json:"frequency,omitempty"`
+	Route             string           `json:"route,omitempty"`
+	DurationDays      int32            `json:"duration_days,omitempty"`
+	MealTiming        string           `json:"meal_timing,omitempty"`
+	RedeemedQuantity  int32            `json:"redeemed_quantity"`
+	RemainingQuantity int32            `json:"remaining_quantity"`
+	Instruction       string           `json:"instruction"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	Product           *ProductResponse `json:"product,omitempty"`
 }
@@ -0,0 +1,25 @@
+package responsedto
+
+type PrescriptionTemplateResponse struct {
+	Id        int64                                  `json:"id"`
+	DoctorId  int64                                  `json:"doctor_id"`
+	Name      string                                 `json:"name"`
+	CreatedAt string                                 `json:"created_at"`
+	UpdatedAt string                                 `json:"updated_at"`
+	Products  []*PrescriptionTemplateProductResponse `json:"products"`
+}
+
+type PrescriptionTemplateProductResponse struct {
+	Id           int64            `json:"id"`
+	ProductId    int64            `json:"product_id"`
+	Note         string           `json:"note"`
+	Quantity     int32            `json:"quantity"`
+	DoseAmount   string           `json:"dose_amount,omitempty"`
+	DoseUnit     string           `json:"dose_unit,omitempty"`
+	Frequency    int32            `json:"frequency,omitempty"`
+	Route        string           `json:"route,omitempty"`
+	DurationDays int32            `json:"duration_days,omitempty"`
+	MealTiming   string           `json:"meal_timing,omitempty"`
+	Instruction  string           `json:"instruction"`
+	Product      *ProductResponse `json:"product,omitempty"`
+}
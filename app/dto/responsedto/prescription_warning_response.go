@@ -0,0 +1,9 @@
+package responsedto
+
+type PrescriptionWarningResponse struct {
+	Type         string   `json:"type"`
+	Severity     string   `json:"severity,omitempty"`
+	GenericNames []string `json:"generic_names"`
+	ProductIds   []int64  `json:"product_ids"`
+	Message      string   `json:"message"`
+}
@@ -0,0 +1,11 @@
+package responsedto
+
+type ProductAlertResponse struct {
+	Id              int64            `json:"id"`
+	ProductId       int64            `json:"product_id"`
+	NotifyByEmail   bool             `json:"notify_by_email"`
+	IsAvailable     bool             `json:"is_available"`
+	LowestPrice     string           `json:"lowest_price,omitempty"`
+	CreatedAt       string           `json:"created_at"`
+	ProductResponse *ProductResponse `json:"product,omitempty"`
+}
@@ -1,6 +1,8 @@
 package responsedto
 
 type ProductCategoryResponse struct {
-	Id   int64  `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	Id       int64                      `json:"id,omitempty"`
+	Name     string                     `json:"name,omitempty"`
+	ParentId int64                      `json:"parent_id,omitempty"`
+	Children []*ProductCategoryResponse `json:"children,omitempty"`
 }
@@ -0,0 +1,9 @@
+package responsedto
+
+type ProductImageResponse struct {
+	Id           int64  `json:"id"`
+	Position     int32  `json:"position"`
+	OriginalUrl  string `json:"original_url"`
+	MediumUrl    string `json:"medium_url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+}
@@ -0,0 +1,24 @@
+package responsedto
+
+type ProductImportResponse struct {
+	Id            int64                       `json:"id"`
+	AdminId       int64                       `json:"admin_id"`
+	FileName      string                      `json:"file_name"`
+	IsDryRun      bool                        `json:"is_dry_run"`
+	Status        string                      `json:"status"`
+	TotalRows     int32                       `json:"total_rows"`
+	ProcessedRows int32                       `json:"processed_rows"`
+	SucceededRows int32                       `json:"succeeded_rows"`
+	FailedRows    int32                       `json:"failed_rows"`
+	ErrorMessage  string                      `json:"error_message,omitempty"`
+	Rows          []*ProductImportRowResponse `json:"rows,omitempty"`
+	CreatedAt     string                      `json:"created_at"`
+	UpdatedAt     string                      `json:"updated_at"`
+}
+
+type ProductImportRowResponse struct {
+	RowNumber int32    `json:"row_number"`
+	Status    string   `json:"status"`
+	ProductId int64    `json:"product_id,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
@@ -17,9 +17,15 @@ type ProductResponse struct {
 	Width                      float64                     `json:"width,omitempty"`
 	Height                     float64                     `json:"height,omitempty"`
 	Image                      string                      `json:"image,omitempty"`
+	Images                     []*ProductImageResponse     `json:"images,omitempty"`
+	Code                       string                      `json:"code,omitempty"`
 	ManufacturerResponse       *ManufacturerResponse       `json:"manufacturer,omitempty"`
 	DrugClassificationResponse *DrugClassificationResponse `json:"drug_classification,omitempty"`
 	ProductCategoryResponse    *ProductCategoryResponse    `json:"product_category,omitempty"`
+	ProductCategoryPath        []*ProductCategoryResponse  `json:"product_category_path,omitempty"`
 	MinimumPrice               string                      `json:"minimum_price,omitempty"`
 	MaximumPrice               string                      `json:"maximum_price,omitempty"`
+	RatingAverage              float64                     `json:"rating_average,omitempty"`
+	RatingCount                int32                       `json:"rating_count,omitempty"`
+	RelatedProducts            []*ProductResponse          `json:"related_products,omitempty"`
 }
@@ -0,0 +1,15 @@
+package responsedto
+
+type ProductReviewResponse struct {
+	Id             int64    `json:"id"`
+	ProductId      int64    `json:"product_id"`
+	OrderDetailId  int64    `json:"order_detail_id"`
+	ReviewerName   string   `json:"reviewer_name"`
+	Rating         int32    `json:"rating"`
+	Comment        string   `json:"comment"`
+	Photos         []string `json:"photos"`
+	Status         string   `json:"status"`
+	ModerationNote string   `json:"moderation_note,omitempty"`
+	ModeratedAt    string   `json:"moderated_at,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package responsedto
+
+type ReferralResponse struct {
+	Id                       int64                 `json:"id"`
+	SessionId                int64                 `json:"session_id"`
+	ReferringDoctorId        int64                 `json:"referring_doctor_id"`
+	UserId                   int64                 `json:"user_id"`
+	DependentId              int64                 `json:"dependent_id,omitempty"`
+	DoctorSpecializationId   int64                 `json:"doctor_specialization_id,omitempty"`
+	DoctorSpecializationName string                `json:"doctor_specialization_name,omitempty"`
+	TargetDoctorId           int64                 `json:"target_doctor_id,omitempty"`
+	ClinicalSummary          string                `json:"clinical_summary"`
+	ReferredSessionId        int64                 `json:"referred_session_id,omitempty"`
+	IsUsed                   bool                  `json:"is_used"`
+	CreatedAt                string                `json:"created_at"`
+	ReferringPrescription    *PrescriptionResponse `json:"referring_prescription,omitempty"`
+	ReferringClinicalNote    *ClinicalNoteResponse `json:"referring_clinical_note,omitempty"`
+}
@@ -0,0 +1,10 @@
+package responsedto
+
+type SickLeaveFormVerificationResponse struct {
+	IsValid              bool   `json:"is_valid"`
+	CertificateNumber    string `json:"certificate_number"`
+	StartingDate         string `json:"starting_date"`
+	EndingDate           string `json:"ending_date"`
+	DoctorName           string `json:"doctor_name"`
+	DoctorSpecialization string `json:"doctor_specialization"`
+}
@@ -0,0 +1,10 @@
+package responsedto
+
+type UserNotificationResponse struct {
+	Id        int64  `json:"id"`
+	ProductId int64  `json:"product_id,omitempty"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt string `json:"created_at"`
+}
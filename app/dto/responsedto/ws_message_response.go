@@ -3,11 +3,13 @@ package responsedto
 import "time"
 
 type WsConsultationMessage struct {
-	IsTyping    bool      `json:"is_typing"`
-	MessageType int64     `json:"message_type"`
-	Message     string    `json:"message"`
-	Attachment  string    `json:"attachment"`
-	CreatedAt   time.Time `json:"created_at"`
-	SenderId    int64     `json:"sender_id"`
-	SessionId   int64     `json:"session_id"`
+	IsTyping         bool       `json:"is_typing"`
+	MessageType      int64      `json:"message_type"`
+	Message          string     `json:"message"`
+	Attachment       string     `json:"attachment"`
+	CreatedAt        time.Time  `json:"created_at"`
+	SenderId         int64      `json:"sender_id"`
+	SessionId        int64      `json:"session_id"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	RemainingSeconds int64      `json:"remaining_seconds,omitempty"`
 }
@@ -0,0 +1,5 @@
+package uriparamdto
+
+type ClinicalNoteBySessionId struct {
+	SessionId int64 `uri:"sessionId" validate:"required,number"`
+}
@@ -0,0 +1,6 @@
+package uriparamdto
+
+type ConsultationSessionExtensionById struct {
+	SessionId int64 `uri:"id" validate:"required,number"`
+	Id        int64 `uri:"extensionId" validate:"required,number"`
+}
@@ -0,0 +1,5 @@
+package uriparamdto
+
+type MedicalProfileByUserId struct {
+	UserId int64 `uri:"userId" validate:"required,number"`
+}
@@ -0,0 +1,6 @@
+package uriparamdto
+
+type ProductImageById struct {
+	ProductId int64 `uri:"id" validate:"required,number"`
+	Id        int64 `uri:"imageId" validate:"required,number"`
+}
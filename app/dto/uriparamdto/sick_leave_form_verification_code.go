@@ -0,0 +1,5 @@
+package uriparamdto
+
+type SickLeaveFormVerificationCode struct {
+	Code string `uri:"code" validate:"required"`
+}
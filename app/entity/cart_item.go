@@ -10,15 +10,16 @@ import (
 )
 
 type CartItem struct {
-	Id              int64        `json:"id"`
-	UserId          int64        `json:"user_id"`
-	ProductId       int64        `json:"product_id"`
-	Quantity        int32        `json:"quantity"`
-	CreatedAt       time.Time    `json:"created_at"`
-	UpdatedAt       time.Time    `json:"updated_at"`
-	DeletedAt       sql.NullTime `json:"deleted_at"`
-	Product         *Product
-	PharmacyProduct *PharmacyProduct
+	Id                    int64         `json:"id"`
+	UserId                int64         `json:"user_id"`
+	ProductId             int64         `json:"product_id"`
+	Quantity              int32         `json:"quantity"`
+	PrescriptionProductId sql.NullInt64 `json:"prescription_product_id"`
+	CreatedAt             time.Time     `json:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at"`
+	DeletedAt             sql.NullTime  `json:"deleted_at"`
+	Product               *Product
+	PharmacyProduct       *PharmacyProduct
 }
 
 func (ci *CartItem) GetEntityName() string {
@@ -43,6 +44,7 @@ func (ci *CartItem) ToResponse() *responsedto.CartItemResponse {
 		UserId:                          ci.UserId,
 		ProductId:                       ci.ProductId,
 		Quantity:                        ci.Quantity,
+		PrescriptionProductId:           ci.PrescriptionProductId.Int64,
 		ProductResponse:                 ci.Product.ToProductResponse(),
 		PharmacyProductCheckoutResponse: ci.PharmacyProduct.ToPharmacyProductCheckoutResponse(),
 	}
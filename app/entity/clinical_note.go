@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// ClinicalNote is the treating doctor's private SOAP note of a session, it must only be returned to doctors
+type ClinicalNote struct {
+	Id         int64     `json:"id"`
+	SessionId  int64     `json:"session_id"`
+	DoctorId   int64     `json:"doctor_id"`
+	Subjective string    `json:"subjective"`
+	Objective  string    `json:"objective"`
+	Assessment string    `json:"assessment"`
+	Plan       string    `json:"plan"`
+	Version    int32     `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (e *ClinicalNote) GetEntityName() string {
+	return "clinical_notes"
+}
+
+func (e *ClinicalNote) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ClinicalNote) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ClinicalNote) ToResponse() *responsedto.ClinicalNoteResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.ClinicalNoteResponse{
+		Id:         e.Id,
+		SessionId:  e.SessionId,
+		DoctorId:   e.DoctorId,
+		Subjective: e.Subjective,
+		Objective:  e.Objective,
+		Assessment: e.Assessment,
+		Plan:       e.Plan,
+		Version:    e.Version,
+		CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type ClinicalNoteVersion struct {
+	Id             int64     `json:"id"`
+	ClinicalNoteId int64     `json:"clinical_note_id"`
+	Version        int32     `json:"version"`
+	Subjective     string    `json:"subjective"`
+	Objective      string    `json:"objective"`
+	Assessment     string    `json:"assessment"`
+	Plan           string    `json:"plan"`
+	EditedBy       int64     `json:"edited_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (e *ClinicalNoteVersion) GetEntityName() string {
+	return "clinical_note_versions"
+}
+
+func (e *ClinicalNoteVersion) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ClinicalNoteVersion) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ClinicalNoteVersion) ToResponse() *responsedto.ClinicalNoteVersionResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.ClinicalNoteVersionResponse{
+		Id:             e.Id,
+		ClinicalNoteId: e.ClinicalNoteId,
+		Version:        e.Version,
+		Subjective:     e.Subjective,
+		Objective:      e.Objective,
+		Assessment:     e.Assessment,
+		Plan:           e.Plan,
+		EditedBy:       e.EditedBy,
+		CreatedAt:      e.CreatedAt.Format(time.RFC3339),
+	}
+}
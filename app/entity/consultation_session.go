@@ -10,18 +10,22 @@ import (
 )
 
 type ConsultationSession struct {
-	Id                          int64        `json:"id"`
-	UserId                      int64        `json:"user_id"`
-	DoctorId                    int64        `json:"doctor_id"`
-	ConsultationSessionStatusId int64        `json:"consultation_session_status_id"`
-	CreatedAt                   time.Time    `json:"created_at"`
-	UpdatedAt                   time.Time    `json:"updated_at"`
-	DeletedAt                   sql.NullTime `json:"deleted_at"`
+	Id                          int64         `json:"id"`
+	UserId                      int64         `json:"user_id"`
+	DependentId                 sql.NullInt64 `json:"dependent_id"`
+	DoctorId                    int64         `json:"doctor_id"`
+	ConsultationSessionStatusId int64         `json:"consultation_session_status_id"`
+	ExpiresAt                   sql.NullTime  `json:"expires_at"`
+	CreatedAt                   time.Time     `json:"created_at"`
+	UpdatedAt                   time.Time     `json:"updated_at"`
+	DeletedAt                   sql.NullTime  `json:"deleted_at"`
 	ConsultationSessionStatus   *ConsultationSessionStatus
 	UserProfile                 *UserProfile
+	Dependent                   *Dependent
 	DoctorProfile               *DoctorProfile
 	Prescription                *Prescription
 	SickLeaveForm               *SickLeaveForm
+	ClinicalNote                *ClinicalNote
 	Message                     []*ConsultationMessage
 }
 
@@ -51,18 +55,27 @@ func (e *ConsultationSession) ToResponse() *responsedto.ConsultationSessionRespo
 		messageResp = append(messageResp, message.ToWsMessage())
 	}
 
+	var expiresAt *time.Time
+	if e.ExpiresAt.Valid {
+		expiresAt = &e.ExpiresAt.Time
+	}
+
 	return &responsedto.ConsultationSessionResponse{
 		Id:                          e.Id,
 		UserId:                      e.UserId,
+		DependentId:                 e.DependentId.Int64,
 		DoctorId:                    e.DoctorId,
 		ConsultationSessionStatusId: e.ConsultationSessionStatusId,
+		ExpiresAt:                   expiresAt,
 		CreatedAt:                   e.CreatedAt,
 		UpdatedAt:                   e.UpdatedAt,
 		ConsultationSessionStatus:   e.ConsultationSessionStatus.ToResponse(),
 		UserProfile:                 e.UserProfile.GetProfile().ToResponse(),
+		Dependent:                   e.Dependent.ToResponse(),
 		DoctorProfile:               e.DoctorProfile.GetProfile().ToResponse(),
 		Prescription:                e.Prescription.ToResponse(),
 		SickLeaveForm:               e.SickLeaveForm.ToResponse(),
+		ClinicalNote:                e.ClinicalNote.ToResponse(),
 		Message:                     messageResp,
 	}
 }
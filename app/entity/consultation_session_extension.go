@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// ConsultationSessionExtension is a paid request of the patient for one more session length, the fee and the length
+// are copied from the doctor when the request is made
+type ConsultationSessionExtension struct {
+	Id              int64           `json:"id"`
+	SessionId       int64           `json:"session_id"`
+	DurationMinutes int32           `json:"duration_minutes"`
+	Fee             decimal.Decimal `json:"fee"`
+	PaymentProof    string          `json:"payment_proof"`
+	Status          string          `json:"status"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Session         *ConsultationSession
+}
+
+func (e *ConsultationSessionExtension) GetEntityName() string {
+	return "consultation_session_extensions"
+}
+
+func (e *ConsultationSessionExtension) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ConsultationSessionExtension) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ConsultationSessionExtension) ToResponse() *responsedto.ConsultationSessionExtensionResponse {
+	if e == nil {
+		return nil
+	}
+
+	var sessionExpiresAt *time.Time
+	if e.Session != nil && e.Session.ExpiresAt.Valid {
+		sessionExpiresAt = &e.Session.ExpiresAt.Time
+	}
+
+	return &responsedto.ConsultationSessionExtensionResponse{
+		Id:               e.Id,
+		SessionId:        e.SessionId,
+		DurationMinutes:  e.DurationMinutes,
+		Fee:              e.Fee.String(),
+		PaymentProof:     e.PaymentProof,
+		Status:           e.Status,
+		SessionExpiresAt: sessionExpiresAt,
+		CreatedAt:        e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        e.UpdatedAt.Format(time.RFC3339),
+	}
+}
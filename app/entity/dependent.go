@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+type Dependent struct {
+	Id           int64        `json:"id"`
+	UserId       int64        `json:"user_id"`
+	Name         string       `json:"name"`
+	DateOfBirth  time.Time    `json:"date_of_birth"`
+	Relationship string       `json:"relationship"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    sql.NullTime `json:"deleted_at"`
+}
+
+func (e *Dependent) GetEntityName() string {
+	return "dependents"
+}
+
+func (e *Dependent) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *Dependent) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *Dependent) ToResponse() *responsedto.DependentResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.DependentResponse{
+		Id:           e.Id,
+		UserId:       e.UserId,
+		Name:         e.Name,
+		DateOfBirth:  e.DateOfBirth.Format(appconstant.TimeFormatQueryParam),
+		Relationship: e.Relationship,
+	}
+}
@@ -10,12 +10,13 @@ import (
 )
 
 type DoctorSpecialization struct {
-	Id        int64        `json:"id"`
-	Name      string       `json:"name"`
-	Image     string       `json:"image"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"deleted_at"`
+	Id                     int64        `json:"id"`
+	Name                   string       `json:"name"`
+	Image                  string       `json:"image"`
+	SessionDurationMinutes int32        `json:"session_duration_minutes"`
+	CreatedAt              time.Time    `json:"created_at"`
+	UpdatedAt              time.Time    `json:"updated_at"`
+	DeletedAt              sql.NullTime `json:"deleted_at"`
 }
 
 func (e *DoctorSpecialization) ToDoctorSpecializationResponse() responsedto.DoctorSpecializationResponse {
@@ -46,8 +47,9 @@ func (e *DoctorSpecialization) ToResponse() *responsedto.SpecializationResponse
 		return nil
 	}
 	return &responsedto.SpecializationResponse{
-		Id:    e.Id,
-		Name:  e.Name,
-		Image: e.Image,
+		Id:                     e.Id,
+		Name:                   e.Name,
+		Image:                  e.Image,
+		SessionDurationMinutes: e.SessionDurationMinutes,
 	}
 }
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type DrugInteraction struct {
+	Id                        int64        `json:"id"`
+	GenericNameA              string       `json:"generic_name_a"`
+	GenericNameB              string       `json:"generic_name_b"`
+	DrugInteractionSeverityId int64        `json:"drug_interaction_severity_id"`
+	Description               string       `json:"description"`
+	CreatedAt                 time.Time    `json:"created_at"`
+	UpdatedAt                 time.Time    `json:"updated_at"`
+	DeletedAt                 sql.NullTime `json:"deleted_at"`
+}
+
+func (e *DrugInteraction) GetEntityName() string {
+	return "drug_interactions"
+}
+
+func (e *DrugInteraction) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *DrugInteraction) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+// Normalize lowercases both generic names and orders them so a pair is stored only once
+func (e *DrugInteraction) Normalize() {
+	e.GenericNameA = NormalizeGenericName(e.GenericNameA)
+	e.GenericNameB = NormalizeGenericName(e.GenericNameB)
+	if e.GenericNameA > e.GenericNameB {
+		e.GenericNameA, e.GenericNameB = e.GenericNameB, e.GenericNameA
+	}
+}
+
+func (e *DrugInteraction) IsSevere() bool {
+	return e.DrugInteractionSeverityId == appconstant.DrugInteractionSeverityIdSevere
+}
+
+func (e *DrugInteraction) ToResponse() *responsedto.DrugInteractionResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.DrugInteractionResponse{
+		Id:                        e.Id,
+		GenericNameA:              e.GenericNameA,
+		GenericNameB:              e.GenericNameB,
+		DrugInteractionSeverityId: e.DrugInteractionSeverityId,
+		Severity:                  appconstant.DrugInteractionSeverityNames[e.DrugInteractionSeverityId],
+		Description:               e.Description,
+	}
+}
+
+func NormalizeGenericName(genericName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(genericName), " "))
+}
+
+type DrugInteractionImportResult struct {
+	TotalRows int
+	Imported  int
+	Errors    []*DrugInteractionImportError
+}
+
+type DrugInteractionImportError struct {
+	Row     int
+	Message string
+}
+
+func (e *DrugInteractionImportResult) ToResponse() *responsedto.DrugInteractionImportResponse {
+	errs := make([]*responsedto.DrugInteractionImportErrorResponse, 0)
+	for _, importErr := range e.Errors {
+		errs = append(errs, &responsedto.DrugInteractionImportErrorResponse{Row: importErr.Row, Message: importErr.Message})
+	}
+	return &responsedto.DrugInteractionImportResponse{
+		TotalRows: e.TotalRows,
+		Imported:  e.Imported,
+		Failed:    len(e.Errors),
+		Errors:    errs,
+	}
+}
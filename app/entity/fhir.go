@@ -0,0 +1,219 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/fhirdto"
+	"time"
+)
+
+func fhirReference(resourceType string, id int64, display ...string) fhirdto.Reference {
+	reference := fhirdto.Reference{Reference: fmt.Sprintf("%s/%d", resourceType, id)}
+	if len(display) > 0 {
+		reference.Display = display[0]
+	}
+	return reference
+}
+
+// FhirPatientId identifies the patient of a session, dependents are prefixed so they never collide with the
+// account holder's user id
+func FhirPatientId(userId int64, dependentId sql.NullInt64) string {
+	if dependentId.Valid {
+		return fmt.Sprintf("dependent-%d", dependentId.Int64)
+	}
+	return fmt.Sprintf("%d", userId)
+}
+
+func fhirPatientReference(userId int64, dependentId sql.NullInt64) fhirdto.Reference {
+	return fhirdto.Reference{Reference: fmt.Sprintf("%s/%s", fhirdto.ResourceTypePatient, FhirPatientId(userId, dependentId))}
+}
+
+func (u *User) ToFhirPatient(identifierSystem string) *fhirdto.Patient {
+	if u == nil {
+		return nil
+	}
+	patient := &fhirdto.Patient{
+		ResourceType: fhirdto.ResourceTypePatient,
+		Id:           fmt.Sprintf("%d", u.Id),
+		Identifier:   []fhirdto.Identifier{{System: identifierSystem, Value: fmt.Sprintf("%d", u.Id)}},
+		Telecom:      []fhirdto.ContactPoint{{System: "email", Value: u.Email}},
+	}
+	if u.UserProfile != nil {
+		patient.Name = []fhirdto.HumanName{{Use: "official", Text: u.UserProfile.Name}}
+		if !u.UserProfile.DateOfBirth.IsZero() {
+			patient.BirthDate = u.UserProfile.DateOfBirth.Format(appconstant.TimeFormatQueryParam)
+		}
+	}
+	return patient
+}
+
+func (e *Dependent) ToFhirPatient(identifierSystem string) *fhirdto.Patient {
+	if e == nil {
+		return nil
+	}
+	id := FhirPatientId(e.UserId, sql.NullInt64{Int64: e.Id, Valid: true})
+	patient := &fhirdto.Patient{
+		ResourceType: fhirdto.ResourceTypePatient,
+		Id:           id,
+		Identifier:   []fhirdto.Identifier{{System: identifierSystem, Value: id}},
+		Name:         []fhirdto.HumanName{{Use: "official", Text: e.Name}},
+	}
+	if !e.DateOfBirth.IsZero() {
+		patient.BirthDate = e.DateOfBirth.Format(appconstant.TimeFormatQueryParam)
+	}
+	return patient
+}
+
+func (u *User) ToFhirPractitioner(identifierSystem string) *fhirdto.Practitioner {
+	if u == nil {
+		return nil
+	}
+	practitioner := &fhirdto.Practitioner{
+		ResourceType: fhirdto.ResourceTypePractitioner,
+		Id:           fmt.Sprintf("%d", u.Id),
+		Identifier:   []fhirdto.Identifier{{System: identifierSystem, Value: fmt.Sprintf("%d", u.Id)}},
+		Telecom:      []fhirdto.ContactPoint{{System: "email", Value: u.Email}},
+	}
+	if u.DoctorProfile != nil {
+		practitioner.Name = []fhirdto.HumanName{{Use: "official", Text: u.DoctorProfile.Name}}
+		if u.DoctorProfile.DoctorSpecialization != nil {
+			practitioner.Qualification = []fhirdto.PractitionerQualification{
+				{Code: fhirdto.CodeableConcept{Text: u.DoctorProfile.DoctorSpecialization.Name}},
+			}
+		}
+	}
+	return practitioner
+}
+
+func (e *ConsultationSession) ToFhirEncounter() *fhirdto.Encounter {
+	if e == nil {
+		return nil
+	}
+	encounter := &fhirdto.Encounter{
+		ResourceType: fhirdto.ResourceTypeEncounter,
+		Id:           fmt.Sprintf("%d", e.Id),
+		Status:       appconstant.FhirEncounterStatusInProgress,
+		Class: fhirdto.Coding{
+			System:  appconstant.FhirCodeSystemActCode,
+			Code:    appconstant.FhirEncounterClassVirtual,
+			Display: "virtual",
+		},
+		Subject:     fhirPatientReference(e.UserId, e.DependentId),
+		Participant: []fhirdto.EncounterParticipant{{Individual: fhirReference(fhirdto.ResourceTypePractitioner, e.DoctorId)}},
+		Period:      &fhirdto.Period{Start: e.CreatedAt.Format(time.RFC3339)},
+	}
+	if e.ConsultationSessionStatusId == appconstant.ConsultationSessionStatusEnded {
+		encounter.Status = appconstant.FhirEncounterStatusFinished
+		encounter.Period.End = e.UpdatedAt.Format(time.RFC3339)
+	}
+	return encounter
+}
+
+// ToFhirCondition maps the prescription diagnosis, the patient and doctor are referenced from the prescription's session
+func (e *Prescription) ToFhirCondition(session *ConsultationSession) *fhirdto.Condition {
+	if e == nil || session == nil {
+		return nil
+	}
+	encounter := fhirReference(fhirdto.ResourceTypeEncounter, e.SessionId)
+	recorder := fhirReference(fhirdto.ResourceTypePractitioner, session.DoctorId)
+
+	condition := &fhirdto.Condition{
+		ResourceType: fhirdto.ResourceTypeCondition,
+		Id:           fmt.Sprintf("%d", e.Id),
+		ClinicalStatus: &fhirdto.CodeableConcept{
+			Coding: []fhirdto.Coding{{System: appconstant.FhirCodeSystemConditionClinical, Code: "active"}},
+		},
+		VerificationStatus: &fhirdto.CodeableConcept{
+			Coding: []fhirdto.Coding{{System: appconstant.FhirCodeSystemConditionVerStat, Code: "provisional"}},
+		},
+		Category: []fhirdto.CodeableConcept{
+			{Coding: []fhirdto.Coding{{System: appconstant.FhirCodeSystemConditionCategory, Code: "encounter-diagnosis"}}},
+		},
+		Code:         fhirdto.CodeableConcept{Text: e.Diagnosis},
+		Subject:      fhirPatientReference(session.UserId, session.DependentId),
+		Encounter:    &encounter,
+		RecordedDate: e.CreatedAt.Format(time.RFC3339),
+		Recorder:     &recorder,
+	}
+	if e.Symptoms != "" {
+		condition.Note = []fhirdto.Annotation{{Text: fmt.Sprintf("Symptoms: %s", e.Symptoms)}}
+	}
+	return condition
+}
+
+func (e *PrescriptionProduct) ToFhirMedicationRequest(prescription *Prescription, session *ConsultationSession) *fhirdto.MedicationRequest {
+	if e == nil || prescription == nil || session == nil {
+		return nil
+	}
+
+	status := appconstant.FhirMedicationRequestStatusActive
+	if e.GetRemainingQuantity() <= 0 {
+		status = appconstant.FhirMedicationRequestStatusCompleted
+	} else if prescription.IsExpired() {
+		status = appconstant.FhirMedicationRequestStatusStopped
+	}
+
+	medication := fhirdto.CodeableConcept{}
+	if e.Product != nil {
+		medication.Text = e.Product.Name
+		if e.Product.GenericName != "" {
+			medication.Text = fmt.Sprintf("%s (%s)", e.Product.Name, e.Product.GenericName)
+		}
+	}
+
+	// only the structured dosage the doctor gave is exported, without any the note is the whole dosage text
+	dosage := fhirdto.Dosage{Text: e.GetInstruction()}
+	repeat := fhirdto.TimingRepeat{}
+	if e.Frequency.Valid {
+		repeat.Frequency = e.Frequency.Int32
+		repeat.Period = 1
+		repeat.PeriodUnit = "d"
+	}
+	if e.DurationDays.Valid {
+		repeat.BoundsDuration = &fhirdto.Quantity{
+			Value: float64(e.DurationDays.Int32), Unit: "days", System: appconstant.FhirCodeSystemUcum, Code: "d",
+		}
+	}
+	if when, ok := appconstant.FhirMealTimingEvents[e.MealTiming.String]; ok {
+		repeat.When = []string{when}
+	}
+	if e.Frequency.Valid || e.DurationDays.Valid || len(repeat.When) > 0 {
+		dosage.Timing = &fhirdto.Timing{Repeat: repeat}
+	}
+	if e.Route.Valid {
+		dosage.Route = &fhirdto.CodeableConcept{Text: e.Route.String}
+	}
+	if e.DoseAmount.Valid {
+		doseAmount, _ := e.DoseAmount.Decimal.Float64()
+		dosage.DoseAndRate = []fhirdto.DoseAndRate{{DoseQuantity: fhirdto.Quantity{Value: doseAmount, Unit: e.DoseUnit}}}
+	}
+
+	encounter := fhirReference(fhirdto.ResourceTypeEncounter, prescription.SessionId)
+	requester := fhirReference(fhirdto.ResourceTypePractitioner, session.DoctorId)
+
+	medicationRequest := &fhirdto.MedicationRequest{
+		ResourceType:              fhirdto.ResourceTypeMedicationRequest,
+		Id:                        fmt.Sprintf("%d", e.Id),
+		Status:                    status,
+		Intent:                    appconstant.FhirMedicationRequestIntentOrder,
+		MedicationCodeableConcept: medication,
+		Subject:                   fhirPatientReference(session.UserId, session.DependentId),
+		Encounter:                 &encounter,
+		AuthoredOn:                prescription.CreatedAt.Format(time.RFC3339),
+		Requester:                 &requester,
+		ReasonReference:           []fhirdto.Reference{fhirReference(fhirdto.ResourceTypeCondition, prescription.Id)},
+		DosageInstruction:         []fhirdto.Dosage{dosage},
+		DispenseRequest: &fhirdto.MedicationRequestDispenseRequest{
+			ValidityPeriod: &fhirdto.Period{
+				Start: prescription.CreatedAt.Format(time.RFC3339),
+				End:   prescription.ExpiredAt.Format(time.RFC3339),
+			},
+			Quantity: &fhirdto.Quantity{Value: float64(e.Quantity)},
+		},
+	}
+	if e.Note != "" {
+		medicationRequest.Note = []fhirdto.Annotation{{Text: e.Note}}
+	}
+	return medicationRequest
+}
@@ -0,0 +1,270 @@
+package entity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/fhirdto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// fhirJsonValue marshals the resource and returns the value found at the dot separated path,
+// numeric segments index into arrays
+func fhirJsonValue(t *testing.T, resource interface{}, path string) interface{} {
+	t.Helper()
+
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		t.Fatalf("failed to marshal resource: %v", err)
+	}
+
+	var current interface{}
+	if err := json.Unmarshal(raw, &current); err != nil {
+		t.Fatalf("failed to unmarshal resource: %v", err)
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+func TestFhirResources(t *testing.T) {
+	createdAt := time.Date(2023, 11, 1, 9, 0, 0, 0, time.UTC)
+	patient := &User{
+		Id:          7,
+		Email:       "patient@mail.com",
+		UserProfile: &UserProfile{Name: "Patient", DateOfBirth: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	doctor := &User{
+		Id:    3,
+		Email: "doctor@mail.com",
+		DoctorProfile: &DoctorProfile{
+			Name:                 "Doctor",
+			DoctorSpecialization: &DoctorSpecialization{Name: "General"},
+		},
+	}
+	session := &ConsultationSession{
+		Id:                          11,
+		UserId:                      patient.Id,
+		DoctorId:                    doctor.Id,
+		ConsultationSessionStatusId: appconstant.ConsultationSessionStatusEnded,
+		CreatedAt:                   createdAt,
+		UpdatedAt:                   createdAt.Add(time.Hour),
+	}
+	dependent := &Dependent{Id: 5, UserId: patient.Id, Name: "Dependent", DateOfBirth: time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)}
+	dependentSession := &ConsultationSession{
+		Id:                          12,
+		UserId:                      patient.Id,
+		DependentId:                 sql.NullInt64{Int64: dependent.Id, Valid: true},
+		DoctorId:                    doctor.Id,
+		ConsultationSessionStatusId: appconstant.ConsultationSessionStatusOngoing,
+		CreatedAt:                   createdAt,
+	}
+	dependentPrescription := &Prescription{Id: 14, SessionId: dependentSession.Id, Diagnosis: "Cough", CreatedAt: createdAt}
+	prescription := &Prescription{
+		Id:        13,
+		SessionId: session.Id,
+		Symptoms:  "Fever",
+		Diagnosis: "Influenza",
+		ExpiredAt: createdAt.AddDate(0, 0, appconstant.PrescriptionValidityDays),
+		CreatedAt: createdAt,
+	}
+	prescriptionProduct := &PrescriptionProduct{
+		Id:               17,
+		PrescriptionId:   prescription.Id,
+		Quantity:         10,
+		RedeemedQuantity: 10,
+		DoseAmount:       decimal.NewNullDecimal(decimal.NewFromInt(1)),
+		DoseUnit:         "tablet",
+		Frequency:        sql.NullInt32{Int32: 3, Valid: true},
+		Route:            sql.NullString{String: appconstant.PrescriptionRouteOral, Valid: true},
+		DurationDays:     sql.NullInt32{Int32: 5, Valid: true},
+		MealTiming:       sql.NullString{String: appconstant.PrescriptionMealTimingAfter, Valid: true},
+		Product:          &Product{Name: "Paracetamol"},
+	}
+	noteOnlyProduct := &PrescriptionProduct{
+		Id:             18,
+		PrescriptionId: prescription.Id,
+		Note:           "Use when needed",
+		Quantity:       1,
+		Product:        &Product{Name: "Salbutamol"},
+	}
+
+	tests := []struct {
+		name     string
+		resource interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "patient",
+			resource: patient.ToFhirPatient("http://localhost/fhir"),
+			expected: map[string]interface{}{
+				"resourceType":       fhirdto.ResourceTypePatient,
+				"id":                 "7",
+				"identifier.0.value": "7",
+				"name.0.text":        "Patient",
+				"birthDate":          "1990-01-02",
+			},
+		},
+		{
+			name:     "dependent patient",
+			resource: dependent.ToFhirPatient("http://localhost/fhir"),
+			expected: map[string]interface{}{
+				"resourceType": fhirdto.ResourceTypePatient,
+				"id":           "dependent-5",
+				"name.0.text":  "Dependent",
+				"birthDate":    "2015-03-04",
+			},
+		},
+		{
+			name:     "practitioner",
+			resource: doctor.ToFhirPractitioner("http://localhost/fhir"),
+			expected: map[string]interface{}{
+				"resourceType":              fhirdto.ResourceTypePractitioner,
+				"id":                        "3",
+				"name.0.text":               "Doctor",
+				"qualification.0.code.text": "General",
+			},
+		},
+		{
+			name:     "encounter",
+			resource: session.ToFhirEncounter(),
+			expected: map[string]interface{}{
+				"resourceType":                       fhirdto.ResourceTypeEncounter,
+				"id":                                 "11",
+				"status":                             appconstant.FhirEncounterStatusFinished,
+				"subject.reference":                  "Patient/7",
+				"participant.0.individual.reference": "Practitioner/3",
+				"period.end":                         createdAt.Add(time.Hour).Format(time.RFC3339),
+			},
+		},
+		{
+			name:     "dependent encounter",
+			resource: dependentSession.ToFhirEncounter(),
+			expected: map[string]interface{}{
+				"resourceType":      fhirdto.ResourceTypeEncounter,
+				"status":            appconstant.FhirEncounterStatusInProgress,
+				"subject.reference": "Patient/dependent-5",
+			},
+		},
+		{
+			name:     "dependent condition",
+			resource: dependentPrescription.ToFhirCondition(dependentSession),
+			expected: map[string]interface{}{
+				"subject.reference":   "Patient/dependent-5",
+				"encounter.reference": "Encounter/12",
+			},
+		},
+		{
+			name:     "condition",
+			resource: prescription.ToFhirCondition(session),
+			expected: map[string]interface{}{
+				"resourceType":        fhirdto.ResourceTypeCondition,
+				"id":                  "13",
+				"code.text":           "Influenza",
+				"subject.reference":   "Patient/7",
+				"encounter.reference": "Encounter/11",
+				"recorder.reference":  "Practitioner/3",
+			},
+		},
+		{
+			name:     "medication request",
+			resource: prescriptionProduct.ToFhirMedicationRequest(prescription, session),
+			expected: map[string]interface{}{
+				"resourceType":                fhirdto.ResourceTypeMedicationRequest,
+				"id":                          "17",
+				"status":                      appconstant.FhirMedicationRequestStatusCompleted,
+				"subject.reference":           "Patient/7",
+				"encounter.reference":         "Encounter/11",
+				"requester.reference":         "Practitioner/3",
+				"reasonReference.0.reference": "Condition/13",
+				"dosageInstruction.0.timing.repeat.when.0":            "PC",
+				"dosageInstruction.0.timing.repeat.frequency":         float64(3),
+				"dispenseRequest.quantity.value":                      float64(10),
+				"dispenseRequest.validityPeriod.end":                  prescription.ExpiredAt.Format(time.RFC3339),
+				"dosageInstruction.0.doseAndRate.0.doseQuantity.unit": "tablet",
+			},
+		},
+		{
+			name:     "medication request without structured dosage",
+			resource: noteOnlyProduct.ToFhirMedicationRequest(prescription, session),
+			expected: map[string]interface{}{
+				"resourceType":                    fhirdto.ResourceTypeMedicationRequest,
+				"subject.reference":               "Patient/7",
+				"dosageInstruction.0.text":        "Use when needed",
+				"dosageInstruction.0.timing":      nil,
+				"dosageInstruction.0.route":       nil,
+				"dosageInstruction.0.doseAndRate": nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for path, want := range tt.expected {
+				if got := fhirJsonValue(t, tt.resource, path); got != want {
+					t.Errorf("%s = %v, want %v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrescriptionProduct_ToFhirMedicationRequestStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		redeemed     int32
+		expiredAt    time.Time
+		expectedCode string
+	}{
+		{
+			name:         "active while quantity remains and prescription is valid",
+			redeemed:     2,
+			expiredAt:    now.Add(time.Hour),
+			expectedCode: appconstant.FhirMedicationRequestStatusActive,
+		},
+		{
+			name:         "completed once fully redeemed",
+			redeemed:     5,
+			expiredAt:    now.Add(time.Hour),
+			expectedCode: appconstant.FhirMedicationRequestStatusCompleted,
+		},
+		{
+			name:         "stopped when the prescription expired",
+			redeemed:     2,
+			expiredAt:    now.Add(-time.Hour),
+			expectedCode: appconstant.FhirMedicationRequestStatusStopped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prescription := &Prescription{Id: 1, SessionId: 2, ExpiredAt: tt.expiredAt, CreatedAt: now}
+			prescriptionProduct := &PrescriptionProduct{Id: 3, Quantity: 5, RedeemedQuantity: tt.redeemed}
+
+			got := fhirJsonValue(t, prescriptionProduct.ToFhirMedicationRequest(prescription, &ConsultationSession{Id: 2, UserId: 4, DoctorId: 5}), "status")
+			if got != tt.expectedCode {
+				t.Errorf("status = %v, want %v", got, tt.expectedCode)
+			}
+		})
+	}
+}
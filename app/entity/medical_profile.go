@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+type MedicalProfile struct {
+	UserId       int64         `json:"user_id"`
+	DependentId  sql.NullInt64 `json:"dependent_id"`
+	BloodType    string        `json:"blood_type"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	DeletedAt    sql.NullTime  `json:"deleted_at"`
+	Allergies    []*MedicalProfileAllergy
+	Conditions   []*MedicalProfileCondition
+	Medications  []*MedicalProfileMedication
+	Measurements []*MedicalProfileMeasurement
+}
+
+func (e *MedicalProfile) GetEntityName() string {
+	return "medical_profiles"
+}
+
+func (e *MedicalProfile) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *MedicalProfile) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+// GetLatestMeasurement returns the most recent height and weight record, measurements are ordered newest first
+func (e *MedicalProfile) GetLatestMeasurement() *MedicalProfileMeasurement {
+	if e == nil || len(e.Measurements) == 0 {
+		return nil
+	}
+	return e.Measurements[0]
+}
+
+func (e *MedicalProfile) ToResponse() *responsedto.MedicalProfileResponse {
+	if e == nil {
+		return nil
+	}
+
+	allergies := make([]*responsedto.MedicalProfileAllergyResponse, 0)
+	for _, allergy := range e.Allergies {
+		allergies = append(allergies, allergy.ToResponse())
+	}
+
+	conditions := make([]*responsedto.MedicalProfileConditionResponse, 0)
+	for _, condition := range e.Conditions {
+		conditions = append(conditions, condition.ToResponse())
+	}
+
+	medications := make([]*responsedto.MedicalProfileMedicationResponse, 0)
+	for _, medication := range e.Medications {
+		medications = append(medications, medication.ToResponse())
+	}
+
+	measurements := make([]*responsedto.MedicalProfileMeasurementResponse, 0)
+	for _, measurement := range e.Measurements {
+		measurements = append(measurements, measurement.ToResponse())
+	}
+
+	return &responsedto.MedicalProfileResponse{
+		UserId:            e.UserId,
+		DependentId:       e.DependentId.Int64,
+		BloodType:         e.BloodType,
+		LatestMeasurement: e.GetLatestMeasurement().ToResponse(),
+		Allergies:         allergies,
+		Conditions:        conditions,
+		Medications:       medications,
+		Measurements:      measurements,
+	}
+}
+
+type MedicalProfileAllergy struct {
+	Id          int64        `json:"id"`
+	UserId      int64        `json:"user_id"`
+	Allergen    string       `json:"allergen"`
+	GenericName string       `json:"generic_name"`
+	Reaction    string       `json:"reaction"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (e *MedicalProfileAllergy) ToResponse() *responsedto.MedicalProfileAllergyResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicalProfileAllergyResponse{
+		Id:          e.Id,
+		Allergen:    e.Allergen,
+		GenericName: e.GenericName,
+		Reaction:    e.Reaction,
+	}
+}
+
+type MedicalProfileCondition struct {
+	Id          int64        `json:"id"`
+	UserId      int64        `json:"user_id"`
+	Name        string       `json:"name"`
+	DiagnosedAt sql.NullTime `json:"diagnosed_at"`
+	Note        string       `json:"note"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (e *MedicalProfileCondition) ToResponse() *responsedto.MedicalProfileConditionResponse {
+	if e == nil {
+		return nil
+	}
+	diagnosedAt := ""
+	if e.DiagnosedAt.Valid {
+		diagnosedAt = e.DiagnosedAt.Time.Format(appconstant.TimeFormatQueryParam)
+	}
+	return &responsedto.MedicalProfileConditionResponse{
+		Id:          e.Id,
+		Name:        e.Name,
+		DiagnosedAt: diagnosedAt,
+		Note:        e.Note,
+	}
+}
+
+type MedicalProfileMedication struct {
+	Id          int64        `json:"id"`
+	UserId      int64        `json:"user_id"`
+	Name        string       `json:"name"`
+	GenericName string       `json:"generic_name"`
+	Dosage      string       `json:"dosage"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (e *MedicalProfileMedication) ToResponse() *responsedto.MedicalProfileMedicationResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicalProfileMedicationResponse{
+		Id:          e.Id,
+		Name:        e.Name,
+		GenericName: e.GenericName,
+		Dosage:      e.Dosage,
+	}
+}
+
+type MedicalProfileMeasurement struct {
+	Id          int64           `json:"id"`
+	UserId      int64           `json:"user_id"`
+	DependentId sql.NullInt64   `json:"dependent_id"`
+	HeightCm    decimal.Decimal `json:"height_cm"`
+	WeightKg    decimal.Decimal `json:"weight_kg"`
+	MeasuredAt  time.Time       `json:"measured_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   sql.NullTime    `json:"deleted_at"`
+}
+
+func (e *MedicalProfileMeasurement) ToResponse() *responsedto.MedicalProfileMeasurementResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicalProfileMeasurementResponse{
+		Id:         e.Id,
+		HeightCm:   e.HeightCm.String(),
+		WeightKg:   e.WeightKg.String(),
+		MeasuredAt: e.MeasuredAt.Format(time.RFC3339),
+	}
+}
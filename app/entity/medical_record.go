@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// MedicalRecord is a single entry of the patient's timeline, aggregated from consultation sessions, prescriptions,
+// sick leave forms and orders of prescribed products
+type MedicalRecord struct {
+	Id          int64         `json:"id"`
+	Type        string        `json:"type"`
+	SessionId   int64         `json:"session_id"`
+	UserId      int64         `json:"user_id"`
+	DependentId sql.NullInt64 `json:"dependent_id"`
+	DoctorId    int64         `json:"doctor_id"`
+	DoctorName  string        `json:"doctor_name"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+}
+
+func (e *MedicalRecord) GetEntityName() string {
+	return "medical_records"
+}
+
+func (e *MedicalRecord) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *MedicalRecord) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *MedicalRecord) ToResponse() *responsedto.MedicalRecordResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicalRecordResponse{
+		Id:          e.Id,
+		Type:        e.Type,
+		SessionId:   e.SessionId,
+		DoctorId:    e.DoctorId,
+		DoctorName:  e.DoctorName,
+		OccurredAt:  e.OccurredAt.Format(time.RFC3339),
+		Title:       e.Title,
+		Description: e.Description,
+	}
+}
+
+type MedicalRecordConsent struct {
+	SessionId int64     `json:"session_id"`
+	IsGranted bool      `json:"is_granted"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (e *MedicalRecordConsent) ToResponse() *responsedto.MedicalRecordConsentResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicalRecordConsentResponse{
+		SessionId: e.SessionId,
+		IsGranted: e.IsGranted,
+		UpdatedAt: e.UpdatedAt.Format(time.RFC3339),
+	}
+}
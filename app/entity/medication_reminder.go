@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type MedicationReminder struct {
+	Id                    int64         `json:"id"`
+	UserId                int64         `json:"user_id"`
+	DependentId           sql.NullInt64 `json:"dependent_id"`
+	PrescriptionProductId sql.NullInt64 `json:"prescription_product_id"`
+	MedicationName        string        `json:"medication_name"`
+	Instruction           string        `json:"instruction"`
+	TimesOfDay            []string      `json:"times_of_day"`
+	StartDate             time.Time     `json:"start_date"`
+	DurationDays          int32         `json:"duration_days"`
+	CreatedAt             time.Time     `json:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at"`
+	DeletedAt             sql.NullTime  `json:"deleted_at"`
+	Doses                 []*MedicationReminderDose
+	Adherence             *MedicationReminderAdherence
+	User                  *User
+}
+
+func (e *MedicationReminder) GetEntityName() string {
+	return "medication_reminders"
+}
+
+func (e *MedicationReminder) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *MedicationReminder) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *MedicationReminder) JoinTimesOfDay() string {
+	return strings.Join(e.TimesOfDay, appconstant.MedicationReminderTimesSeparator)
+}
+
+func (e *MedicationReminder) SplitTimesOfDay(timesOfDay string) {
+	e.TimesOfDay = strings.Split(timesOfDay, appconstant.MedicationReminderTimesSeparator)
+}
+
+// GenerateDoses lays out one dose for every time of day on every day of the schedule, in the server's time zone
+func (e *MedicationReminder) GenerateDoses() []*MedicationReminderDose {
+	doses := make([]*MedicationReminderDose, 0, int(e.DurationDays)*len(e.TimesOfDay))
+	for day := 0; day < int(e.DurationDays); day++ {
+		date := e.StartDate.AddDate(0, 0, day)
+		for _, timeOfDay := range e.TimesOfDay {
+			clock, err := time.Parse(appconstant.MedicationReminderTimeFormat, timeOfDay)
+			if err != nil {
+				continue
+			}
+			doses = append(doses, &MedicationReminderDose{
+				ScheduledAt: time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local),
+				Status:      appconstant.MedicationReminderDoseStatusPending,
+			})
+		}
+	}
+	return doses
+}
+
+func (e *MedicationReminder) ToResponse() *responsedto.MedicationReminderResponse {
+	if e == nil {
+		return nil
+	}
+
+	var doses []*responsedto.MedicationReminderDoseResponse
+	if e.Doses != nil {
+		doses = make([]*responsedto.MedicationReminderDoseResponse, 0)
+		for _, dose := range e.Doses {
+			doses = append(doses, dose.ToResponse())
+		}
+	}
+
+	return &responsedto.MedicationReminderResponse{
+		Id:                    e.Id,
+		UserId:                e.UserId,
+		DependentId:           e.DependentId.Int64,
+		PrescriptionProductId: e.PrescriptionProductId.Int64,
+		MedicationName:        e.MedicationName,
+		Instruction:           e.Instruction,
+		TimesOfDay:            e.TimesOfDay,
+		StartDate:             e.StartDate.Format(appconstant.TimeFormatQueryParam),
+		DurationDays:          e.DurationDays,
+		Adherence:             e.Adherence.ToResponse(),
+		Doses:                 doses,
+	}
+}
+
+type MedicationReminderDose struct {
+	Id                   int64        `json:"id"`
+	MedicationReminderId int64        `json:"medication_reminder_id"`
+	ScheduledAt          time.Time    `json:"scheduled_at"`
+	Status               string       `json:"status"`
+	LoggedAt             sql.NullTime `json:"logged_at"`
+	NotifiedAt           sql.NullTime `json:"notified_at"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
+	MedicationReminder   *MedicationReminder
+}
+
+func (e *MedicationReminderDose) GetEntityName() string {
+	return "medication_reminder_doses"
+}
+
+func (e *MedicationReminderDose) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *MedicationReminderDose) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *MedicationReminderDose) IsLoggable() bool {
+	return !time.Now().Add(appconstant.MedicationReminderEarlyLogWindow).Before(e.ScheduledAt)
+}
+
+func (e *MedicationReminderDose) ToResponse() *responsedto.MedicationReminderDoseResponse {
+	if e == nil {
+		return nil
+	}
+	var loggedAt string
+	if e.LoggedAt.Valid {
+		loggedAt = e.LoggedAt.Time.Format(time.RFC3339)
+	}
+	return &responsedto.MedicationReminderDoseResponse{
+		Id:                   e.Id,
+		MedicationReminderId: e.MedicationReminderId,
+		ScheduledAt:          e.ScheduledAt.Format(time.RFC3339),
+		Status:               e.Status,
+		LoggedAt:             loggedAt,
+	}
+}
+
+// MedicationReminderAdherence only counts doses that are already due, a pending dose past its schedule is missed
+type MedicationReminderAdherence struct {
+	TotalDoses   int64
+	DueDoses     int64
+	TakenDoses   int64
+	SkippedDoses int64
+	MissedDoses  int64
+}
+
+func (e *MedicationReminderAdherence) GetRate() float64 {
+	if e.DueDoses == 0 {
+		return 0
+	}
+	return float64(e.TakenDoses) / float64(e.DueDoses)
+}
+
+func (e *MedicationReminderAdherence) ToResponse() *responsedto.MedicationReminderAdherenceResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.MedicationReminderAdherenceResponse{
+		TotalDoses:    e.TotalDoses,
+		DueDoses:      e.DueDoses,
+		TakenDoses:    e.TakenDoses,
+		SkippedDoses:  e.SkippedDoses,
+		MissedDoses:   e.MissedDoses,
+		AdherenceRate: e.GetRate(),
+	}
+}
@@ -20,6 +20,7 @@ type Order struct {
 	ShippingCost     decimal.Decimal `json:"shipping_cost"`
 	TotalPayment     decimal.Decimal `json:"total_payment"`
 	TransactionId    int64           `json:"transaction_id"`
+	DependentId      sql.NullInt64   `json:"dependent_id"`
 	CreatedAt        time.Time       `json:"created_at"`
 	UpdatedAt        time.Time       `json:"updated_at"`
 	DeletedAt        sql.NullTime    `json:"deleted_at"`
@@ -99,6 +100,7 @@ func (u *Order) ToOrderDetailResponse() responsedto.OrderDetailFullResponse {
 		ShippingCost:        u.ShippingCost.String(),
 		Pharmacy:            &pharmacy,
 		UserAddress:         u.UserAddress,
+		DependentId:         u.DependentId.Int64,
 		OrderDetails:        res,
 	}
 }
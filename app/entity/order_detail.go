@@ -11,19 +11,21 @@ import (
 )
 
 type OrderDetail struct {
-	Id          int64           `json:"id"`
-	OrderId     int64           `json:"order_id"`
-	ProductId   int64           `json:"product_id"`
-	Quantity    int32           `json:"quantity"`
-	Name        string          `json:"name"`
-	GenericName string          `json:"generic_name"`
-	Content     string          `json:"content"`
-	Description string          `json:"description"`
-	Image       string          `json:"image"`
-	Price       decimal.Decimal `json:"price"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	DeletedAt   sql.NullTime    `json:"deleted_at"`
+	Id                    int64           `json:"id"`
+	OrderId               int64           `json:"order_id"`
+	ProductId             int64           `json:"product_id"`
+	Quantity              int32           `json:"quantity"`
+	Name                  string          `json:"name"`
+	GenericName           string          `json:"generic_name"`
+	Content               string          `json:"content"`
+	Description           string          `json:"description"`
+	Image                 string          `json:"image"`
+	Price                 decimal.Decimal `json:"price"`
+	PrescriptionProductId sql.NullInt64   `json:"prescription_product_id"`
+	PrescriptionProduct   *PrescriptionProduct
+	CreatedAt             time.Time    `json:"created_at"`
+	UpdatedAt             time.Time    `json:"updated_at"`
+	DeletedAt             sql.NullTime `json:"deleted_at"`
 }
 
 func (o *OrderDetail) GetEntityName() string {
@@ -44,12 +46,14 @@ func (o *OrderDetail) GetSqlColumnFromField(fieldName string) string {
 
 func (o *OrderDetail) ToOrderDetailResponse() responsedto.OrderDetailResponse {
 	return responsedto.OrderDetailResponse{
-		Name:        o.Name,
-		GenericName: o.GenericName,
-		Content:     o.Content,
-		Description: o.Description,
-		Image:       o.Image,
-		Price:       o.Price.String(),
-		Quantity:    o.Quantity,
+		Name:                  o.Name,
+		GenericName:           o.GenericName,
+		Content:               o.Content,
+		Description:           o.Description,
+		Image:                 o.Image,
+		Price:                 o.Price.String(),
+		Quantity:              o.Quantity,
+		PrescriptionProductId: o.PrescriptionProductId.Int64,
+		Prescription:          o.PrescriptionProduct.ToOrderDetailPrescriptionResponse(),
 	}
 }
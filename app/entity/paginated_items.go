@@ -6,6 +6,8 @@ type PaginatedItems struct {
 	CurrentPageTotalItems int64 `json:"current_page_total_items"`
 	CurrentPage           int64 `json:"current_page"`
 	Items                 any    `json:"items"`
+	Facets                *ProductFacets `json:"facets,omitempty"`
+	Suggestions           any            `json:"suggestions,omitempty"`
 }
 
 func NewPaginationInfo(totalItems, totalPages, currentPageTotalItems, currentPage int64, items any) *PaginatedItems {
@@ -15,5 +17,7 @@ func NewPaginationInfo(totalItems, totalPages, currentPageTotalItems, currentPag
 		currentPageTotalItems,
 		currentPage,
 		items,
+		nil,
+		nil,
 	}
 }
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+)
+
+// PharmacyProductImportResult reports an inventory import, nothing is written unless every row is valid
+type PharmacyProductImportResult struct {
+	PharmacyId int64
+	IsDryRun   bool
+	IsApplied  bool
+	Rows       []*PharmacyProductImportRow
+}
+
+// PharmacyProductImportRow is one csv row of an inventory import, the product is given by its id or its code
+type PharmacyProductImportRow struct {
+	RowNumber       int32
+	ProductId       int64
+	ProductCode     string
+	Price           decimal.Decimal
+	Stock           int32
+	IsActive        bool
+	Status          string
+	StockDifference int32
+	Errors          []string
+}
+
+func (e *PharmacyProductImportRow) AddError(message string) {
+	e.Errors = append(e.Errors, message)
+	e.Status = appconstant.PharmacyProductImportRowStatusFailed
+}
+
+func (e *PharmacyProductImportResult) ToResponse() *responsedto.PharmacyProductImportResponse {
+	resp := &responsedto.PharmacyProductImportResponse{
+		PharmacyId: e.PharmacyId,
+		IsDryRun:   e.IsDryRun,
+		IsApplied:  e.IsApplied,
+		TotalRows:  len(e.Rows),
+		Rows:       make([]*responsedto.PharmacyProductImportRowResponse, 0, len(e.Rows)),
+	}
+	for _, row := range e.Rows {
+		switch row.Status {
+		case appconstant.PharmacyProductImportRowStatusCreated:
+			resp.Created++
+		case appconstant.PharmacyProductImportRowStatusUpdated:
+			resp.Updated++
+		case appconstant.PharmacyProductImportRowStatusUnchanged:
+			resp.Unchanged++
+		case appconstant.PharmacyProductImportRowStatusFailed:
+			resp.Failed++
+		}
+		resp.Rows = append(resp.Rows, &responsedto.PharmacyProductImportRowResponse{
+			RowNumber:       row.RowNumber,
+			ProductId:       row.ProductId,
+			Status:          row.Status,
+			StockDifference: row.StockDifference,
+			Errors:          row.Errors,
+		})
+	}
+	return resp
+}
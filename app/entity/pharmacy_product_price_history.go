@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// PharmacyProductPriceHistory is one price a pharmacy product was set to, OldPrice is not valid for the price the
+// product was added with and ChangedBy is not valid for the prices set before the history was kept
+type PharmacyProductPriceHistory struct {
+	Id                int64               `json:"id"`
+	PharmacyProductId int64               `json:"pharmacy_product_id"`
+	OldPrice          decimal.NullDecimal `json:"old_price"`
+	NewPrice          decimal.Decimal     `json:"new_price"`
+	ChangedBy         sql.NullInt64       `json:"changed_by"`
+	Source            string              `json:"source"`
+	CreatedAt         time.Time           `json:"created_at"`
+	ChangedByEmail    sql.NullString
+}
+
+func (e *PharmacyProductPriceHistory) GetEntityName() string {
+	return "pharmacy_product_price_histories"
+}
+
+func (e *PharmacyProductPriceHistory) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *PharmacyProductPriceHistory) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *PharmacyProductPriceHistory) ToResponse() *responsedto.PharmacyProductPriceHistoryResponse {
+	if e == nil {
+		return nil
+	}
+	var oldPrice string
+	if e.OldPrice.Valid {
+		oldPrice = e.OldPrice.Decimal.String()
+	}
+	return &responsedto.PharmacyProductPriceHistoryResponse{
+		Id:                e.Id,
+		PharmacyProductId: e.PharmacyProductId,
+		OldPrice:          oldPrice,
+		NewPrice:          e.NewPrice.String(),
+		ChangedBy:         e.ChangedBy.Int64,
+		ChangedByEmail:    e.ChangedByEmail.String,
+		Source:            e.Source,
+		ChangedAt:         e.CreatedAt.UTC(),
+	}
+}
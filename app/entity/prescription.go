@@ -10,17 +10,20 @@ import (
 )
 
 type Prescription struct {
-	Id                   int64        `json:"id"`
-	SessionId            int64        `json:"session_id"`
-	Symptoms             string       `json:"symptoms"`
-	Diagnosis            string       `json:"diagnosis"`
-	CreatedAt            time.Time    `json:"created_at"`
-	UpdatedAt            time.Time    `json:"updated_at"`
-	DeletedAt            sql.NullTime `json:"deleted_at"`
-	PrescriptionProducts []*PrescriptionProduct
-
-	User   *User
-	Doctor *User
+	Id                        int64        `json:"id"`
+	SessionId                 int64        `json:"session_id"`
+	Symptoms                  string       `json:"symptoms"`
+	Diagnosis                 string       `json:"diagnosis"`
+	ExpiredAt                 time.Time    `json:"expired_at"`
+	InteractionOverrideReason string       `json:"interaction_override_reason"`
+	CreatedAt                 time.Time    `json:"created_at"`
+	UpdatedAt                 time.Time    `json:"updated_at"`
+	DeletedAt                 sql.NullTime `json:"deleted_at"`
+	PrescriptionProducts      []*PrescriptionProduct
+	Warnings                  []*PrescriptionWarning
+	DependentId               sql.NullInt64
+	User                      *User
+	Doctor                    *User
 }
 
 func (e *Prescription) GetEntityName() string {
@@ -39,6 +42,19 @@ func (e *Prescription) GetSqlColumnFromField(fieldName string) string {
 	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
 }
 
+func (e *Prescription) IsExpired() bool {
+	return !e.ExpiredAt.IsZero() && !time.Now().Before(e.ExpiredAt)
+}
+
+func (e *Prescription) IsRedeemed() bool {
+	for _, prescriptionProduct := range e.PrescriptionProducts {
+		if prescriptionProduct.GetRemainingQuantity() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *Prescription) ToResponse() *responsedto.PrescriptionResponse {
 	if e == nil {
 		return nil
@@ -49,11 +65,17 @@ func (e *Prescription) ToResponse() *responsedto.PrescriptionResponse {
 		prescriptionProducts = append(prescriptionProducts, prescriptionProduct.ToResponse())
 	}
 
+	warnings := make([]*responsedto.PrescriptionWarningResponse, 0)
+	for _, warning := range e.Warnings {
+		warnings = append(warnings, warning.ToResponse())
+	}
+
 	var (
 		userResponse      *responsedto.PrescriptionSickLeaveUserProfileResponse
 		doctorResponse    *responsedto.PrescriptionSickLeaveUserProfileResponse
 		createdAtResponse string
 		updatedAtResponse string
+		expiredAtResponse string
 	)
 
 	if e.User != nil && e.User.UserProfile != nil {
@@ -72,15 +94,24 @@ func (e *Prescription) ToResponse() *responsedto.PrescriptionResponse {
 		updatedAtResponse = e.UpdatedAt.Format(time.RFC3339)
 	}
 
+	if !e.ExpiredAt.IsZero() {
+		expiredAtResponse = e.ExpiredAt.Format(time.RFC3339)
+	}
+
 	return &responsedto.PrescriptionResponse{
-		Id:                   e.Id,
-		SessionId:            e.SessionId,
-		Symptoms:             e.Symptoms,
-		Diagnosis:            e.Diagnosis,
-		CreatedAt:            createdAtResponse,
-		UpdatedAt:            updatedAtResponse,
-		PrescriptionProducts: prescriptionProducts,
-		User:                 userResponse,
-		Doctor:               doctorResponse,
+		Id:                        e.Id,
+		SessionId:                 e.SessionId,
+		Symptoms:                  e.Symptoms,
+		Diagnosis:                 e.Diagnosis,
+		CreatedAt:                 createdAtResponse,
+		UpdatedAt:                 updatedAtResponse,
+		ExpiredAt:                 expiredAtResponse,
+		IsExpired:                 e.IsExpired(),
+		IsRedeemed:                e.IsRedeemed(),
+		InteractionOverrideReason: e.InteractionOverrideReason,
+		Warnings:                  warnings,
+		PrescriptionProducts:      prescriptionProducts,
+		User:                      userResponse,
+		Doctor:                    doctorResponse,
 	}
 }
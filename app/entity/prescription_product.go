@@ -3,21 +3,32 @@ package entity
 import (
 	"database/sql"
 	"fmt"
+	"github.com/shopspring/decimal"
 	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/dto/responsedto"
 	"reflect"
+	"strings"
 	"time"
 )
 
 type PrescriptionProduct struct {
-	Id             int64        `json:"id"`
-	PrescriptionId int64        `json:"prescription_id"`
-	ProductId      int64        `json:"product_id"`
-	Note           string       `json:"note"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
-	DeletedAt      sql.NullTime `json:"deleted_at"`
-	Product        *Product
+	Id               int64               `json:"id"`
+	PrescriptionId   int64               `json:"prescription_id"`
+	ProductId        int64               `json:"product_id"`
+	Note             string              `json:"note"`
+	Quantity         int32               `json:"quantity"`
+	DoseAmount       decimal.NullDecimal `json:"dose_amount"`
+	DoseUnit         string              `json:"dose_unit"`
+	Frequency        sql.NullInt32       `json:"frequency"`
+	Route            sql.NullString      `json:"route"`
+	DurationDays     sql.NullInt32       `json:"duration_days"`
+	MealTiming       sql.NullString      `json:"meal_timing"`
+	RedeemedQuantity int32               `json:"redeemed_quantity"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+	DeletedAt        sql.NullTime        `json:"deleted_at"`
+	Product          *Product
+	Prescription     *Prescription
 }
 
 func (e *PrescriptionProduct) GetEntityName() string {
@@ -36,17 +47,159 @@ func (e *PrescriptionProduct) GetSqlColumnFromField(fieldName string) string {
 	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
 }
 
+func (e *PrescriptionProduct) GetRemainingQuantity() int32 {
+	if e.RedeemedQuantity >= e.Quantity {
+		return 0
+	}
+	return e.Quantity - e.RedeemedQuantity
+}
+
+// HasDosage tells whether the doctor gave any structured dosage, products prescribed with only a note have none
+func (e *PrescriptionProduct) HasDosage() bool {
+	return e.DoseAmount.Valid || e.Frequency.Valid || e.Route.Valid || e.DurationDays.Valid || e.MealTiming.Valid
+}
+
+// GetInstruction describes the structured dosage that was given, without one the doctor's note is the instruction
+func (e *PrescriptionProduct) GetInstruction() string {
+	if !e.HasDosage() {
+		return e.Note
+	}
+
+	var instruction strings.Builder
+	instruction.WriteString(e.getDoseDescription())
+
+	if e.Frequency.Valid {
+		switch e.Frequency.Int32 {
+		case 1:
+			instruction.WriteString(" once a day")
+		case 2:
+			instruction.WriteString(" twice a day")
+		default:
+			instruction.WriteString(fmt.Sprintf(" %d times a day", e.Frequency.Int32))
+		}
+	}
+
+	if mealTiming := appconstant.PrescriptionMealTimingInstructions[e.MealTiming.String]; mealTiming != "" {
+		instruction.WriteString(fmt.Sprintf(" %s", mealTiming))
+	}
+
+	if e.DurationDays.Valid {
+		if e.DurationDays.Int32 == 1 {
+			instruction.WriteString(" for 1 day")
+		} else {
+			instruction.WriteString(fmt.Sprintf(" for %d days", e.DurationDays.Int32))
+		}
+	}
+	instruction.WriteString(fmt.Sprintf(". Dispense %d.", e.Quantity))
+
+	return instruction.String()
+}
+
+// GetDoseInstruction describes a single dose, it is what a medication reminder shows at each scheduled time
+func (e *PrescriptionProduct) GetDoseInstruction() string {
+	if !e.HasDosage() {
+		return e.Note
+	}
+
+	var instruction strings.Builder
+	instruction.WriteString(e.getDoseDescription())
+	if mealTiming := appconstant.PrescriptionMealTimingInstructions[e.MealTiming.String]; mealTiming != "" {
+		instruction.WriteString(fmt.Sprintf(" %s", mealTiming))
+	}
+	instruction.WriteString(".")
+	if e.Note != "" {
+		instruction.WriteString(fmt.Sprintf(" %s", e.Note))
+	}
+
+	return instruction.String()
+}
+
+// getDoseDescription starts an instruction with the parts of the dose that were given, e.g. "Take 1 tablet by mouth"
+func (e *PrescriptionProduct) getDoseDescription() string {
+	var description strings.Builder
+
+	description.WriteString("Take")
+	if e.DoseAmount.Valid {
+		description.WriteString(fmt.Sprintf(" %s", e.DoseAmount.Decimal.String()))
+	}
+	if e.DoseUnit != "" {
+		description.WriteString(fmt.Sprintf(" %s", e.DoseUnit))
+	}
+	if route := appconstant.PrescriptionRouteInstructions[e.Route.String]; route != "" {
+		description.WriteString(fmt.Sprintf(" %s", route))
+	}
+
+	return description.String()
+}
+
+// GetDefaultDoseTimes spreads the daily frequency evenly between the first and last dose of the day, there are none
+// without a prescribed frequency
+func (e *PrescriptionProduct) GetDefaultDoseTimes() []string {
+	if !e.Frequency.Valid {
+		return nil
+	}
+
+	first := appconstant.MedicationReminderFirstDoseMinute
+	if e.Frequency.Int32 <= 1 {
+		return []string{fmt.Sprintf("%02d:%02d", first/60, first%60)}
+	}
+
+	interval := (appconstant.MedicationReminderLastDoseMinute - first) / int(e.Frequency.Int32-1)
+	times := make([]string, 0, e.Frequency.Int32)
+	for i := 0; i < int(e.Frequency.Int32); i++ {
+		minute := first + i*interval
+		times = append(times, fmt.Sprintf("%02d:%02d", minute/60, minute%60))
+	}
+	return times
+}
+
 func (e *PrescriptionProduct) ToResponse() *responsedto.PrescriptionProductResponse {
 	if e == nil {
 		return nil
 	}
-	return &responsedto.PrescriptionProductResponse{
-		Id:             e.Id,
-		PrescriptionId: e.PrescriptionId,
-		ProductId:      e.ProductId,
-		Note:           e.Note,
-		CreatedAt:      e.CreatedAt,
-		UpdatedAt:      e.UpdatedAt,
-		Product:        e.Product.ToProductResponse(),
+	resp := &responsedto.PrescriptionProductResponse{
+		Id:                e.Id,
+		PrescriptionId:    e.PrescriptionId,
+		ProductId:         e.ProductId,
+		Note:              e.Note,
+		Quantity:          e.Quantity,
+		DoseUnit:          e.DoseUnit,
+		Frequency:         e.Frequency.Int32,
+		Route:             e.Route.String,
+		DurationDays:      e.DurationDays.Int32,
+		MealTiming:        e.MealTiming.String,
+		RedeemedQuantity:  e.RedeemedQuantity,
+		RemainingQuantity: e.GetRemainingQuantity(),
+		Instruction:       e.GetInstruction(),
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+		Product:           e.Product.ToProductResponse(),
+	}
+	if e.DoseAmount.Valid {
+		resp.DoseAmount = e.DoseAmount.Decimal.String()
+	}
+	return resp
+}
+
+func (e *PrescriptionProduct) ToOrderDetailPrescriptionResponse() *responsedto.OrderDetailPrescriptionResponse {
+	if e == nil || e.Prescription == nil {
+		return nil
+	}
+
+	resp := &responsedto.OrderDetailPrescriptionResponse{
+		PrescriptionId:     e.PrescriptionId,
+		SessionId:          e.Prescription.SessionId,
+		PrescribedQuantity: e.Quantity,
+		Note:               e.Note,
+		Instruction:        e.GetInstruction(),
+		IssuedAt:           e.Prescription.CreatedAt.Format(time.RFC3339),
+		ExpiredAt:          e.Prescription.ExpiredAt.Format(time.RFC3339),
+	}
+	if e.Prescription.Doctor != nil && e.Prescription.Doctor.DoctorProfile != nil {
+		resp.DoctorName = e.Prescription.Doctor.DoctorProfile.Name
+	}
+	if e.Prescription.User != nil && e.Prescription.User.UserProfile != nil {
+		resp.PatientName = e.Prescription.User.UserProfile.Name
 	}
+	return resp
 }
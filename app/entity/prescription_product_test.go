@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"database/sql"
+	"halodeksik-be/app/appconstant"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestPrescriptionProduct_GetInstruction(t *testing.T) {
+	tests := []struct {
+		name                string
+		prescriptionProduct *PrescriptionProduct
+		expected            string
+		expectedDose        string
+	}{
+		{
+			name:                "note only",
+			prescriptionProduct: &PrescriptionProduct{Note: "Use when needed", Quantity: 1},
+			expected:            "Use when needed",
+			expectedDose:        "Use when needed",
+		},
+		{
+			name: "full dosage",
+			prescriptionProduct: &PrescriptionProduct{
+				Note:         "Stop when the fever is gone",
+				Quantity:     10,
+				DoseAmount:   decimal.NewNullDecimal(decimal.NewFromInt(1)),
+				DoseUnit:     "tablet",
+				Frequency:    sql.NullInt32{Int32: 3, Valid: true},
+				Route:        sql.NullString{String: appconstant.PrescriptionRouteOral, Valid: true},
+				DurationDays: sql.NullInt32{Int32: 5, Valid: true},
+				MealTiming:   sql.NullString{String: appconstant.PrescriptionMealTimingAfter, Valid: true},
+			},
+			expected:     "Take 1 tablet by mouth 3 times a day after meals for 5 days. Dispense 10.",
+			expectedDose: "Take 1 tablet by mouth after meals. Stop when the fever is gone",
+		},
+		{
+			name: "partial dosage",
+			prescriptionProduct: &PrescriptionProduct{
+				Quantity:  2,
+				Frequency: sql.NullInt32{Int32: 2, Valid: true},
+			},
+			expected:     "Take twice a day. Dispense 2.",
+			expectedDose: "Take.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.prescriptionProduct.GetInstruction(); got != tt.expected {
+				t.Errorf("GetInstruction() = %q, want %q", got, tt.expected)
+			}
+			if got := tt.prescriptionProduct.GetDoseInstruction(); got != tt.expectedDose {
+				t.Errorf("GetDoseInstruction() = %q, want %q", got, tt.expectedDose)
+			}
+		})
+	}
+}
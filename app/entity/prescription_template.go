@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+type PrescriptionTemplate struct {
+	Id        int64        `json:"id"`
+	DoctorId  int64        `json:"doctor_id"`
+	Name      string       `json:"name"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	Products  []*PrescriptionTemplateProduct
+}
+
+func (e *PrescriptionTemplate) GetEntityName() string {
+	return "prescription_templates"
+}
+
+func (e *PrescriptionTemplate) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *PrescriptionTemplate) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *PrescriptionTemplate) ToPrescriptionProducts() []*PrescriptionProduct {
+	prescriptionProducts := make([]*PrescriptionProduct, 0)
+	for _, templateProduct := range e.Products {
+		prescriptionProducts = append(prescriptionProducts, templateProduct.ToPrescriptionProduct())
+	}
+	return prescriptionProducts
+}
+
+func (e *PrescriptionTemplate) ToResponse() *responsedto.PrescriptionTemplateResponse {
+	if e == nil {
+		return nil
+	}
+
+	products := make([]*responsedto.PrescriptionTemplateProductResponse, 0)
+	for _, templateProduct := range e.Products {
+		products = append(products, templateProduct.ToResponse())
+	}
+
+	return &responsedto.PrescriptionTemplateResponse{
+		Id:        e.Id,
+		DoctorId:  e.DoctorId,
+		Name:      e.Name,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: e.UpdatedAt.Format(time.RFC3339),
+		Products:  products,
+	}
+}
+
+type PrescriptionTemplateProduct struct {
+	Id                     int64               `json:"id"`
+	PrescriptionTemplateId int64               `json:"prescription_template_id"`
+	ProductId              int64               `json:"product_id"`
+	Note                   string              `json:"note"`
+	Quantity               int32               `json:"quantity"`
+	DoseAmount             decimal.NullDecimal `json:"dose_amount"`
+	DoseUnit               string              `json:"dose_unit"`
+	Frequency              sql.NullInt32       `json:"frequency"`
+	Route                  sql.NullString      `json:"route"`
+	DurationDays           sql.NullInt32       `json:"duration_days"`
+	MealTiming             sql.NullString      `json:"meal_timing"`
+	CreatedAt              time.Time           `json:"created_at"`
+	UpdatedAt              time.Time           `json:"updated_at"`
+	Product                *Product
+}
+
+func (e *PrescriptionTemplateProduct) GetEntityName() string {
+	return "prescription_template_products"
+}
+
+func (e *PrescriptionTemplateProduct) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *PrescriptionTemplateProduct) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *PrescriptionTemplateProduct) ToPrescriptionProduct() *PrescriptionProduct {
+	return &PrescriptionProduct{
+		ProductId:    e.ProductId,
+		Note:         e.Note,
+		Quantity:     e.Quantity,
+		DoseAmount:   e.DoseAmount,
+		DoseUnit:     e.DoseUnit,
+		Frequency:    e.Frequency,
+		Route:        e.Route,
+		DurationDays: e.DurationDays,
+		MealTiming:   e.MealTiming,
+	}
+}
+
+func (e *PrescriptionTemplateProduct) ToResponse() *responsedto.PrescriptionTemplateProductResponse {
+	if e == nil {
+		return nil
+	}
+	resp := &responsedto.PrescriptionTemplateProductResponse{
+		Id:           e.Id,
+		ProductId:    e.ProductId,
+		Note:         e.Note,
+		Quantity:     e.Quantity,
+		DoseUnit:     e.DoseUnit,
+		Frequency:    e.Frequency.Int32,
+		Route:        e.Route.String,
+		DurationDays: e.DurationDays.Int32,
+		MealTiming:   e.MealTiming.String,
+		Instruction:  e.ToPrescriptionProduct().GetInstruction(),
+		Product:      e.Product.ToProductResponse(),
+	}
+	if e.DoseAmount.Valid {
+		resp.DoseAmount = e.DoseAmount.Decimal.String()
+	}
+	return resp
+}
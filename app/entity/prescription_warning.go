@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+)
+
+type PrescriptionWarning struct {
+	Type         string
+	SeverityId   int64
+	GenericNames []string
+	ProductIds   []int64
+	Message      string
+}
+
+func (e *PrescriptionWarning) ToResponse() *responsedto.PrescriptionWarningResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.PrescriptionWarningResponse{
+		Type:         e.Type,
+		Severity:     appconstant.DrugInteractionSeverityNames[e.SeverityId],
+		GenericNames: e.GenericNames,
+		ProductIds:   e.ProductIds,
+		Message:      e.Message,
+	}
+}
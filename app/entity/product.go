@@ -11,28 +11,34 @@ import (
 )
 
 type Product struct {
-	Id                   int64        `json:"id"`
-	Name                 string       `json:"name"`
-	GenericName          string       `json:"generic_name"`
-	Content              string       `json:"content"`
-	ManufacturerId       int64        `json:"manufacturer_id"`
-	Description          string       `json:"description"`
-	DrugClassificationId int64        `json:"drug_classification_id"`
-	ProductCategoryId    int64        `json:"product_category_id"`
-	DrugForm             string       `json:"drug_form"`
-	UnitInPack           string       `json:"unit_in_pack"`
-	SellingUnit          string       `json:"selling_unit"`
-	Weight               float64      `json:"weight"`
-	Length               float64      `json:"length"`
-	Width                float64      `json:"width"`
-	Height               float64      `json:"height"`
-	Image                string       `json:"image"`
-	CreatedAt            time.Time    `json:"created_at"`
-	UpdatedAt            time.Time    `json:"updated_at"`
-	DeletedAt            sql.NullTime `json:"-"`
+	Id                   int64          `json:"id"`
+	Name                 string         `json:"name"`
+	GenericName          string         `json:"generic_name"`
+	Content              string         `json:"content"`
+	ManufacturerId       int64          `json:"manufacturer_id"`
+	Description          string         `json:"description"`
+	DrugClassificationId int64          `json:"drug_classification_id"`
+	ProductCategoryId    int64          `json:"product_category_id"`
+	DrugForm             string         `json:"drug_form"`
+	UnitInPack           string         `json:"unit_in_pack"`
+	SellingUnit          string         `json:"selling_unit"`
+	Weight               float64        `json:"weight"`
+	Length               float64        `json:"length"`
+	Width                float64        `json:"width"`
+	Height               float64        `json:"height"`
+	Image                string         `json:"image"`
+	Code                 sql.NullString `json:"code"`
+	RatingAverage        float64        `json:"rating_average"`
+	RatingCount          int32          `json:"rating_count"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            sql.NullTime   `json:"-"`
 	Manufacturer         *Manufacturer
 	DrugClassification   *DrugClassification
 	ProductCategory      *ProductCategory
+	ProductCategoryPath  []*ProductCategory
+	Images               []*ProductImage
+	RelatedProducts      []*Product
 	MinimumPrice         decimal.Decimal
 	MaximumPrice         decimal.Decimal
 }
@@ -67,6 +73,21 @@ func (p *Product) ToProductResponse() *responsedto.ProductResponse {
 		maximumPrice = ""
 	}
 
+	var productCategoryPath []*responsedto.ProductCategoryResponse
+	for _, category := range p.ProductCategoryPath {
+		productCategoryPath = append(productCategoryPath, category.ToResponse())
+	}
+
+	var images []*responsedto.ProductImageResponse
+	for _, image := range p.Images {
+		images = append(images, image.ToResponse())
+	}
+
+	var relatedProducts []*responsedto.ProductResponse
+	for _, relatedProduct := range p.RelatedProducts {
+		relatedProducts = append(relatedProducts, relatedProduct.ToProductResponse())
+	}
+
 	return &responsedto.ProductResponse{
 		Id:                         p.Id,
 		Name:                       p.Name,
@@ -84,10 +105,16 @@ func (p *Product) ToProductResponse() *responsedto.ProductResponse {
 		Width:                      p.Width,
 		Height:                     p.Height,
 		Image:                      p.Image,
+		Images:                     images,
+		Code:                       p.Code.String,
 		ManufacturerResponse:       p.Manufacturer.ToResponse(),
 		DrugClassificationResponse: p.DrugClassification.ToResponse(),
 		ProductCategoryResponse:    p.ProductCategory.ToResponse(),
+		ProductCategoryPath:        productCategoryPath,
 		MinimumPrice:               minimumPrice,
 		MaximumPrice:               maximumPrice,
+		RatingAverage:              p.RatingAverage,
+		RatingCount:                p.RatingCount,
+		RelatedProducts:            relatedProducts,
 	}
 }
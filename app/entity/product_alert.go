@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// ProductAlert keeps whether the product was available around the main address of the user and its lowest price
+// there when the user was last told about it
+type ProductAlert struct {
+	Id            int64               `json:"id"`
+	UserId        int64               `json:"user_id"`
+	ProductId     int64               `json:"product_id"`
+	NotifyByEmail bool                `json:"notify_by_email"`
+	IsAvailable   bool                `json:"is_available"`
+	LowestPrice   decimal.NullDecimal `json:"lowest_price"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	DeletedAt     sql.NullTime        `json:"deleted_at"`
+	Product       *Product
+}
+
+func (e *ProductAlert) GetEntityName() string {
+	return "product_alerts"
+}
+
+func (e *ProductAlert) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ProductAlert) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ProductAlert) ToResponse() *responsedto.ProductAlertResponse {
+	if e == nil {
+		return nil
+	}
+	var lowestPrice string
+	if e.LowestPrice.Valid {
+		lowestPrice = e.LowestPrice.Decimal.String()
+	}
+	return &responsedto.ProductAlertResponse{
+		Id:              e.Id,
+		ProductId:       e.ProductId,
+		NotifyByEmail:   e.NotifyByEmail,
+		IsAvailable:     e.IsAvailable,
+		LowestPrice:     lowestPrice,
+		CreatedAt:       e.CreatedAt.Format(time.RFC3339),
+		ProductResponse: e.Product.ToProductResponse(),
+	}
+}
+
+// ProductAlertMatch is an alert with the availability of its product around the main address of its user right now
+type ProductAlertMatch struct {
+	Alert       *ProductAlert
+	Email       string
+	IsAvailable bool
+	LowestPrice decimal.NullDecimal
+}
+
+func (e *ProductAlertMatch) IsBackInStock() bool {
+	return !e.Alert.IsAvailable && e.IsAvailable
+}
+
+func (e *ProductAlertMatch) IsPriceDrop() bool {
+	return e.Alert.IsAvailable && e.IsAvailable && e.Alert.LowestPrice.Valid && e.LowestPrice.Valid &&
+		e.LowestPrice.Decimal.LessThan(e.Alert.LowestPrice.Decimal)
+}
+
+// ToUserNotification describes the change the user has not been told about yet, nil when there is nothing to tell
+func (e *ProductAlertMatch) ToUserNotification() *UserNotification {
+	productName := e.Alert.Product.Name
+	notification := &UserNotification{
+		UserId:    e.Alert.UserId,
+		ProductId: sql.NullInt64{Int64: e.Alert.ProductId, Valid: true},
+	}
+
+	switch {
+	case e.IsBackInStock():
+		notification.Title = fmt.Sprintf(appconstant.ProductAlertBackInStockTitle, productName)
+		notification.Message = fmt.Sprintf(appconstant.ProductAlertBackInStockBody, productName, e.LowestPrice.Decimal.String())
+	case e.IsPriceDrop():
+		notification.Title = fmt.Sprintf(appconstant.ProductAlertPriceDropTitle, productName)
+		notification.Message = fmt.Sprintf(
+			appconstant.ProductAlertPriceDropBody, productName, e.Alert.LowestPrice.Decimal.String(), e.LowestPrice.Decimal.String(),
+		)
+	default:
+		return nil
+	}
+	return notification
+}
@@ -10,20 +10,31 @@ import (
 )
 
 type ProductCategory struct {
-	Id        int64        `json:"id"`
-	Name      string       `json:"name"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"deleted_at"`
+	Id        int64         `json:"id"`
+	Name      string        `json:"name"`
+	ParentId  sql.NullInt64 `json:"parent_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	Children  []*ProductCategory
 }
 
 func (e *ProductCategory) ToResponse() *responsedto.ProductCategoryResponse {
 	if e == nil {
 		return nil
 	}
+	var children []*responsedto.ProductCategoryResponse
+	if e.Children != nil {
+		children = make([]*responsedto.ProductCategoryResponse, 0, len(e.Children))
+		for _, child := range e.Children {
+			children = append(children, child.ToResponse())
+		}
+	}
 	return &responsedto.ProductCategoryResponse{
-		Id:   e.Id,
-		Name: e.Name,
+		Id:       e.Id,
+		Name:     e.Name,
+		ParentId: e.ParentId.Int64,
+		Children: children,
 	}
 }
 
@@ -0,0 +1,21 @@
+package entity
+
+type ProductFacetValue struct {
+	Id    int64  `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type ProductPriceFacetValue struct {
+	MinPrice string `json:"min_price"`
+	MaxPrice string `json:"max_price,omitempty"`
+	Count    int64  `json:"count"`
+}
+
+type ProductFacets struct {
+	DrugClassifications []*ProductFacetValue      `json:"drug_classifications"`
+	ProductCategories   []*ProductFacetValue      `json:"product_categories"`
+	Manufacturers       []*ProductFacetValue      `json:"manufacturers"`
+	DrugForms           []*ProductFacetValue      `json:"drug_forms"`
+	PriceRanges         []*ProductPriceFacetValue `json:"price_ranges"`
+}
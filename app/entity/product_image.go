@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// ProductImage is one image of the gallery of a product, the image at the lowest position is the cover of the product
+type ProductImage struct {
+	Id           int64        `json:"id"`
+	ProductId    int64        `json:"product_id"`
+	Position     int32        `json:"position"`
+	OriginalUrl  string       `json:"original_url"`
+	MediumUrl    string       `json:"medium_url"`
+	ThumbnailUrl string       `json:"thumbnail_url"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    sql.NullTime `json:"deleted_at"`
+}
+
+func (e *ProductImage) GetEntityName() string {
+	return "product_images"
+}
+
+func (e *ProductImage) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ProductImage) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ProductImage) ToResponse() *responsedto.ProductImageResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.ProductImageResponse{
+		Id:           e.Id,
+		Position:     e.Position,
+		OriginalUrl:  e.OriginalUrl,
+		MediumUrl:    e.MediumUrl,
+		ThumbnailUrl: e.ThumbnailUrl,
+	}
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ProductImport is a background import of a product catalog csv, a dry run only validates the rows
+type ProductImport struct {
+	Id            int64     `json:"id"`
+	AdminId       int64     `json:"admin_id"`
+	FileName      string    `json:"file_name"`
+	IsDryRun      bool      `json:"is_dry_run"`
+	Status        string    `json:"status"`
+	TotalRows     int32     `json:"total_rows"`
+	ProcessedRows int32     `json:"processed_rows"`
+	SucceededRows int32     `json:"succeeded_rows"`
+	FailedRows    int32     `json:"failed_rows"`
+	ErrorMessage  string    `json:"error_message"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Rows          []*ProductImportRow
+}
+
+// ProductImportRow is the outcome of one csv row, names are resolved to the ids of the product while processing
+type ProductImportRow struct {
+	Id                     int64         `json:"id"`
+	ProductImportId        int64         `json:"product_import_id"`
+	RowNumber              int32         `json:"row_number"`
+	Status                 string        `json:"status"`
+	ProductId              sql.NullInt64 `json:"product_id"`
+	Errors                 []string      `json:"errors"`
+	Product                Product
+	ManufacturerName       string
+	DrugClassificationName string
+	ProductCategoryName    string
+}
+
+func (e *ProductImport) GetEntityName() string {
+	return "product_imports"
+}
+
+func (e *ProductImport) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ProductImport) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ProductImport) ToResponse() *responsedto.ProductImportResponse {
+	if e == nil {
+		return nil
+	}
+
+	var rows []*responsedto.ProductImportRowResponse
+	if e.Rows != nil {
+		rows = make([]*responsedto.ProductImportRowResponse, 0, len(e.Rows))
+		for _, row := range e.Rows {
+			rows = append(rows, row.ToResponse())
+		}
+	}
+
+	return &responsedto.ProductImportResponse{
+		Id:            e.Id,
+		AdminId:       e.AdminId,
+		FileName:      e.FileName,
+		IsDryRun:      e.IsDryRun,
+		Status:        e.Status,
+		TotalRows:     e.TotalRows,
+		ProcessedRows: e.ProcessedRows,
+		SucceededRows: e.SucceededRows,
+		ErrorMessage:  e.ErrorMessage,
+		FailedRows:    e.FailedRows,
+		Rows:          rows,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (e *ProductImportRow) AddError(message string) {
+	e.Errors = append(e.Errors, message)
+}
+
+func (e *ProductImportRow) JoinErrors() string {
+	return strings.Join(e.Errors, appconstant.ProductImportRowErrorsSeparator)
+}
+
+func (e *ProductImportRow) ToResponse() *responsedto.ProductImportRowResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.ProductImportRowResponse{
+		RowNumber: e.RowNumber,
+		Status:    e.Status,
+		ProductId: e.ProductId.Int64,
+		Errors:    e.Errors,
+	}
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+// ProductReview is written by the buyer of one order detail, only published reviews count toward the rating
+// of the product
+type ProductReview struct {
+	Id             int64        `json:"id"`
+	UserId         int64        `json:"user_id"`
+	ProductId      int64        `json:"product_id"`
+	OrderDetailId  int64        `json:"order_detail_id"`
+	Rating         int32        `json:"rating"`
+	Comment        string       `json:"comment"`
+	Status         string       `json:"status"`
+	ModerationNote string       `json:"moderation_note"`
+	ModeratedAt    sql.NullTime `json:"moderated_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Photos         []*ProductReviewPhoto
+	UserProfile    *UserProfile
+}
+
+func (e *ProductReview) GetEntityName() string {
+	return "product_reviews"
+}
+
+func (e *ProductReview) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *ProductReview) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *ProductReview) ToResponse() *responsedto.ProductReviewResponse {
+	if e == nil {
+		return nil
+	}
+
+	photos := make([]string, 0, len(e.Photos))
+	for _, photo := range e.Photos {
+		photos = append(photos, photo.Url)
+	}
+
+	var reviewerName string
+	if e.UserProfile != nil {
+		reviewerName = e.UserProfile.Name
+	}
+
+	var moderatedAt string
+	if e.ModeratedAt.Valid {
+		moderatedAt = e.ModeratedAt.Time.Format(time.RFC3339)
+	}
+
+	return &responsedto.ProductReviewResponse{
+		Id:             e.Id,
+		ProductId:      e.ProductId,
+		OrderDetailId:  e.OrderDetailId,
+		ReviewerName:   reviewerName,
+		Rating:         e.Rating,
+		Comment:        e.Comment,
+		Photos:         photos,
+		Status:         e.Status,
+		ModerationNote: e.ModerationNote,
+		ModeratedAt:    moderatedAt,
+		CreatedAt:      e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type ProductReviewPhoto struct {
+	Id              int64     `json:"id"`
+	ProductReviewId int64     `json:"product_review_id"`
+	Position        int32     `json:"position"`
+	Url             string    `json:"url"`
+	CreatedAt       time.Time `json:"created_at"`
+}
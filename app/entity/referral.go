@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+type Referral struct {
+	Id                     int64         `json:"id"`
+	SessionId              int64         `json:"session_id"`
+	ReferringDoctorId      int64         `json:"referring_doctor_id"`
+	UserId                 int64         `json:"user_id"`
+	DependentId            sql.NullInt64 `json:"dependent_id"`
+	DoctorSpecializationId sql.NullInt64 `json:"doctor_specialization_id"`
+	TargetDoctorId         sql.NullInt64 `json:"target_doctor_id"`
+	ClinicalSummary        string        `json:"clinical_summary"`
+	ReferredSessionId      sql.NullInt64 `json:"referred_session_id"`
+	CreatedAt              time.Time     `json:"created_at"`
+	UpdatedAt              time.Time     `json:"updated_at"`
+	DeletedAt              sql.NullTime  `json:"deleted_at"`
+	DoctorSpecialization   *DoctorSpecialization
+	ReferringPrescription  *Prescription
+	ReferringClinicalNote  *ClinicalNote
+}
+
+func (e *Referral) GetEntityName() string {
+	return "referrals"
+}
+
+func (e *Referral) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *Referral) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *Referral) IsUsed() bool {
+	return e.ReferredSessionId.Valid
+}
+
+func (e *Referral) ToResponse() *responsedto.ReferralResponse {
+	if e == nil {
+		return nil
+	}
+
+	var specializationName string
+	if e.DoctorSpecialization != nil {
+		specializationName = e.DoctorSpecialization.Name
+	}
+
+	return &responsedto.ReferralResponse{
+		Id:                       e.Id,
+		SessionId:                e.SessionId,
+		ReferringDoctorId:        e.ReferringDoctorId,
+		UserId:                   e.UserId,
+		DependentId:              e.DependentId.Int64,
+		DoctorSpecializationId:   e.DoctorSpecializationId.Int64,
+		DoctorSpecializationName: specializationName,
+		TargetDoctorId:           e.TargetDoctorId.Int64,
+		ClinicalSummary:          e.ClinicalSummary,
+		ReferredSessionId:        e.ReferredSessionId.Int64,
+		IsUsed:                   e.IsUsed(),
+		CreatedAt:                e.CreatedAt.Format(time.RFC3339),
+		ReferringPrescription:    e.ReferringPrescription.ToResponse(),
+		ReferringClinicalNote:    e.ReferringClinicalNote.ToResponse(),
+	}
+}
@@ -1,5 +1,10 @@
 package entity
 
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
 type SellReportMonthly struct {
 	Month     int32 `json:"month"`
 	TotalSell int64 `json:"total_sell"`
@@ -12,3 +17,20 @@ type SellReport struct {
 	TotalSells         int64  `json:"total_sells"`
 	Year               int64  `json:"year"`
 }
+
+// SellPriceReport compares the price a product was sold at with the current price of the product in that pharmacy,
+// PriceDifference is the current price minus the sold price
+type SellPriceReport struct {
+	OrderId           int64           `json:"order_id"`
+	OrderDetailId     int64           `json:"order_detail_id"`
+	OrderDate         time.Time       `json:"order_date"`
+	PharmacyId        int64           `json:"pharmacy_id"`
+	PharmacyName      string          `json:"pharmacy_name"`
+	PharmacyProductId int64           `json:"pharmacy_product_id"`
+	ProductId         int64           `json:"product_id"`
+	ProductName       string          `json:"product_name"`
+	Quantity          int32           `json:"quantity"`
+	SoldPrice         decimal.Decimal `json:"sold_price"`
+	CurrentPrice      decimal.Decimal `json:"current_price"`
+	PriceDifference   decimal.Decimal `json:"price_difference"`
+}
@@ -81,3 +81,41 @@ func (e *SickLeaveForm) ToResponse() *responsedto.SickLeaveFormResponse {
 		Doctor:       doctorResponse,
 	}
 }
+
+func (e *SickLeaveForm) GetCertificateNumber() string {
+	return fmt.Sprintf(
+		appconstant.SickLeaveCertificateNumberFormat, e.CreatedAt.Format(appconstant.SickLeaveCertificateNumberDateFormat), e.Id,
+	)
+}
+
+func (e *SickLeaveForm) GetSignaturePayload() string {
+	return fmt.Sprintf(
+		"%d|%d|%s|%s|%s",
+		e.Id, e.SessionId,
+		e.StartingDate.Format(appconstant.TimeFormatQueryParam), e.EndingDate.Format(appconstant.TimeFormatQueryParam),
+		e.Description,
+	)
+}
+
+func (e *SickLeaveForm) ToVerificationResponse() *responsedto.SickLeaveFormVerificationResponse {
+	if e == nil {
+		return nil
+	}
+
+	var doctorName, doctorSpecialization string
+	if e.Doctor != nil && e.Doctor.DoctorProfile != nil {
+		doctorName = e.Doctor.DoctorProfile.Name
+		if e.Doctor.DoctorProfile.DoctorSpecialization != nil {
+			doctorSpecialization = e.Doctor.DoctorProfile.DoctorSpecialization.Name
+		}
+	}
+
+	return &responsedto.SickLeaveFormVerificationResponse{
+		IsValid:              true,
+		CertificateNumber:    e.GetCertificateNumber(),
+		StartingDate:         e.StartingDate.Format(appconstant.TimeFormatQueryParam),
+		EndingDate:           e.EndingDate.Format(appconstant.TimeFormatQueryParam),
+		DoctorName:           doctorName,
+		DoctorSpecialization: doctorSpecialization,
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"reflect"
+	"time"
+)
+
+type SickLeaveFormVerification struct {
+	Id              int64        `json:"id"`
+	SickLeaveFormId int64        `json:"sick_leave_form_id"`
+	IsValid         bool         `json:"is_valid"`
+	IpAddress       string       `json:"ip_address"`
+	UserAgent       string       `json:"user_agent"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"deleted_at"`
+}
+
+func (e *SickLeaveFormVerification) GetEntityName() string {
+	return "sick_leave_form_verifications"
+}
+
+func (e *SickLeaveFormVerification) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *SickLeaveFormVerification) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/responsedto"
+	"reflect"
+	"time"
+)
+
+type UserNotification struct {
+	Id        int64         `json:"id"`
+	UserId    int64         `json:"user_id"`
+	ProductId sql.NullInt64 `json:"product_id"`
+	Title     string        `json:"title"`
+	Message   string        `json:"message"`
+	IsRead    bool          `json:"is_read"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+func (e *UserNotification) GetEntityName() string {
+	return "user_notifications"
+}
+
+func (e *UserNotification) GetFieldStructTag(fieldName string, structTag string) string {
+	field, ok := reflect.TypeOf(e).Elem().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get(structTag)
+}
+
+func (e *UserNotification) GetSqlColumnFromField(fieldName string) string {
+	return fmt.Sprintf("%s.%s", e.GetEntityName(), e.GetFieldStructTag(fieldName, appconstant.JsonStructTag))
+}
+
+func (e *UserNotification) ToResponse() *responsedto.UserNotificationResponse {
+	if e == nil {
+		return nil
+	}
+	return &responsedto.UserNotificationResponse{
+		Id:        e.Id,
+		ProductId: e.ProductId.Int64,
+		Title:     e.Title,
+		Message:   e.Message,
+		IsRead:    e.IsRead,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
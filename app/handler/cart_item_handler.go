@@ -151,7 +151,8 @@ func (h *CartItemHandler) Checkout(ctx *gin.Context) {
 	}
 
 	param := getCartItemCheckoutQuery.ToGetAllParams()
-	paginatedItems, err := h.uc.Checkout(ctx.Request.Context(), param, ids...)
+	suggestionParam := getCartItemCheckoutQuery.ToSuggestionParams()
+	paginatedItems, err := h.uc.Checkout(ctx.Request.Context(), param, suggestionParam, ids...)
 	if err != nil {
 		return
 	}
@@ -162,5 +163,11 @@ func (h *CartItemHandler) Checkout(ctx *gin.Context) {
 	}
 	paginatedItems.Items = resps
 
+	suggestionResps := make([]*responsedto.ProductResponse, 0)
+	for _, suggestion := range paginatedItems.Suggestions.([]*entity.Product) {
+		suggestionResps = append(suggestionResps, suggestion.ToProductResponse())
+	}
+	paginatedItems.Suggestions = suggestionResps
+
 	ctx.JSON(http.StatusOK, paginatedItems)
 }
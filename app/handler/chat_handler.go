@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -32,6 +33,7 @@ type ChatHandler struct {
 	hub                   *ws.Hub
 	consultationSessionUC usecase.ConsultationSessionUseCase
 	consultationMessageUC usecase.ConsultationMessageUseCase
+	extensionUC           usecase.ConsultationSessionExtensionUseCase
 	profileUC             usecase.ProfileUseCase
 	validator             appvalidator.AppValidator
 }
@@ -40,6 +42,7 @@ func NewChatHandler(
 	hub *ws.Hub,
 	consultationSessionUC usecase.ConsultationSessionUseCase,
 	consultationMessageUC usecase.ConsultationMessageUseCase,
+	extensionUC usecase.ConsultationSessionExtensionUseCase,
 	profileUC usecase.ProfileUseCase,
 	validator appvalidator.AppValidator,
 ) *ChatHandler {
@@ -47,6 +50,7 @@ func NewChatHandler(
 		hub:                   hub,
 		consultationSessionUC: consultationSessionUC,
 		consultationMessageUC: consultationMessageUC,
+		extensionUC:           extensionUC,
 		profileUC:             profileUC,
 		validator:             validator,
 	}
@@ -74,16 +78,7 @@ func (h *ChatHandler) CreateRoom(ctx *gin.Context) {
 
 	addedOrFound, err := h.consultationSessionUC.Add(ctx, req.ToConsultationSessionUseCase())
 	if err != nil && errors.Is(err, apperror.ErrChatStillOngoing) {
-		roomId := addedOrFound.Id
-		_, isRoomExisted := h.hub.ConsultationSessions[roomId]
-		if !isRoomExisted {
-			h.hub.ConsultationSessions[roomId] = &ws.ConsultationSession{
-				Id:        roomId,
-				DoctorId:  addedOrFound.DoctorId,
-				PatientId: addedOrFound.UserId,
-				Clients:   make(map[int64]*ws.Client),
-			}
-		}
+		h.hub.CreateRoom <- ws.NewConsultationSession(addedOrFound)
 		return
 	}
 
@@ -91,13 +86,7 @@ func (h *ChatHandler) CreateRoom(ctx *gin.Context) {
 		return
 	}
 
-	roomId := addedOrFound.Id
-	h.hub.ConsultationSessions[roomId] = &ws.ConsultationSession{
-		Id:        roomId,
-		DoctorId:  addedOrFound.DoctorId,
-		PatientId: addedOrFound.UserId,
-		Clients:   make(map[int64]*ws.Client),
-	}
+	h.hub.CreateRoom <- ws.NewConsultationSession(addedOrFound)
 
 	resp := dto.ResponseDto{Data: addedOrFound.ToResponse()}
 	ctx.JSON(http.StatusOK, resp)
@@ -154,15 +143,7 @@ func (h *ChatHandler) JoinRoom(ctx *gin.Context) {
 		return
 	}
 
-	_, isRoomExisted := h.hub.ConsultationSessions[sessionId]
-	if !isRoomExisted {
-		h.hub.ConsultationSessions[sessionId] = &ws.ConsultationSession{
-			Id:        sessionId,
-			DoctorId:  sessionDb.DoctorId,
-			PatientId: sessionDb.UserId,
-			Clients:   make(map[int64]*ws.Client),
-		}
-	}
+	h.hub.CreateRoom <- ws.NewConsultationSession(sessionDb)
 
 	clientIdCtx := ctx.Request.Context().Value(appconstant.ContextKeyUserId)
 	clientId := clientIdCtx.(int64)
@@ -265,3 +246,142 @@ func (h *ChatHandler) EditStatusAsEnded(ctx *gin.Context) {
 	resp := dto.ResponseDto{Data: edited.ToResponse()}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+func (h *ChatHandler) AddExtension(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	file, err := ctx.FormFile(appconstant.FormPaymentProof)
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		return
+	}
+
+	if file != nil {
+		req := requestdto.RequestPaymentProof{}
+		err = ctx.ShouldBind(&req)
+		if err != nil {
+			return
+		}
+
+		err = h.validator.Validate(req)
+		if err != nil {
+			return
+		}
+
+		reqCtx := context.WithValue(ctx.Request.Context(), appconstant.FormPaymentProof, file)
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+	}
+
+	added, err := h.extensionUC.Add(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	h.broadcastAlert(ctx, added.SessionId, appconstant.MessageConsultationSessionExtensionRequest)
+
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ChatHandler) GetAllExtensions(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	extensions, err := h.extensionUC.GetAllBySessionId(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ConsultationSessionExtensionResponse, 0)
+	for _, extension := range extensions {
+		resps = append(resps, extension.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ChatHandler) AcceptExtension(ctx *gin.Context) {
+	h.editExtensionStatus(ctx, true)
+}
+
+func (h *ChatHandler) RejectExtension(ctx *gin.Context) {
+	h.editExtensionStatus(ctx, false)
+}
+
+func (h *ChatHandler) editExtensionStatus(ctx *gin.Context, isAccepted bool) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ConsultationSessionExtensionById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.extensionUC.EditStatus(ctx.Request.Context(), uri.SessionId, uri.Id, isAccepted)
+	if err != nil {
+		return
+	}
+
+	if !isAccepted {
+		h.broadcastAlert(ctx, edited.SessionId, appconstant.MessageConsultationSessionExtensionRejected)
+	}
+	if isAccepted {
+		h.hub.Deadline <- &ws.ConsultationSessionDeadline{SessionId: edited.SessionId, ExpiresAt: edited.Session.ExpiresAt.Time}
+		h.broadcastAlert(ctx, edited.SessionId, appconstant.MessageConsultationSessionExtensionAccepted)
+	}
+
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ChatHandler) broadcastAlert(ctx *gin.Context, sessionId int64, message string) {
+	h.hub.Broadcast <- &responsedto.WsConsultationMessage{
+		MessageType: appconstant.MessageTypeAlert,
+		Message:     message,
+		CreatedAt:   time.Now(),
+		SenderId:    ctx.Request.Context().Value(appconstant.ContextKeyUserId).(int64),
+		SessionId:   sessionId,
+	}
+}
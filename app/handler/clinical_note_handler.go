@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type ClinicalNoteHandler struct {
+	uc        usecase.ClinicalNoteUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewClinicalNoteHandler(uc usecase.ClinicalNoteUseCase, validator appvalidator.AppValidator) *ClinicalNoteHandler {
+	return &ClinicalNoteHandler{uc: uc, validator: validator}
+}
+
+func (h *ClinicalNoteHandler) GetBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ClinicalNoteBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	note, err := h.uc.GetBySessionId(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: note.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ClinicalNoteHandler) GetAllVersionsBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ClinicalNoteBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	versions, err := h.uc.GetAllVersionsBySessionId(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ClinicalNoteVersionResponse, 0)
+	for _, version := range versions {
+		resps = append(resps, version.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ClinicalNoteHandler) EditBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ClinicalNoteBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.EditClinicalNote{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	saved, err := h.uc.EditBySessionId(ctx.Request.Context(), uri.SessionId, req.ToClinicalNote())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: saved.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
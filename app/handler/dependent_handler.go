@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type DependentHandler struct {
+	uc        usecase.DependentUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewDependentHandler(uc usecase.DependentUseCase, validator appvalidator.AppValidator) *DependentHandler {
+	return &DependentHandler{uc: uc, validator: validator}
+}
+
+func (h *DependentHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddEditDependent{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToDependent())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DependentHandler) GetById(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	dependent, err := h.uc.GetById(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: dependent.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DependentHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	dependents, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.DependentResponse, 0)
+	for _, dependent := range dependents {
+		resps = append(resps, dependent.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DependentHandler) Edit(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.AddEditDependent{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.uc.Edit(ctx.Request.Context(), uri.Id, req.ToDependent())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DependentHandler) Remove(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.Remove(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
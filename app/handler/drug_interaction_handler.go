@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type DrugInteractionHandler struct {
+	uc        usecase.DrugInteractionUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewDrugInteractionHandler(uc usecase.DrugInteractionUseCase, validator appvalidator.AppValidator) *DrugInteractionHandler {
+	return &DrugInteractionHandler{uc: uc, validator: validator}
+}
+
+func (h *DrugInteractionHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddEditDrugInteraction{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToDrugInteraction())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DrugInteractionHandler) GetById(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	interaction, err := h.uc.GetById(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: interaction.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DrugInteractionHandler) GetAll(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	getAllDrugInteractionsQuery := queryparamdto.GetAllDrugInteractionsQuery{}
+	err = ctx.ShouldBindQuery(&getAllDrugInteractionsQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllDrugInteractionsQuery)
+	if err != nil {
+		return
+	}
+
+	param := getAllDrugInteractionsQuery.ToGetAllParams()
+	paginatedItems, err := h.uc.GetAll(ctx.Request.Context(), param)
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.DrugInteractionResponse, 0)
+	for _, interaction := range paginatedItems.Items.([]*entity.DrugInteraction) {
+		resps = append(resps, interaction.ToResponse())
+	}
+	paginatedItems.Items = resps
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DrugInteractionHandler) Edit(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.AddEditDrugInteraction{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.uc.Edit(ctx.Request.Context(), uri.Id, req.ToDrugInteraction())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *DrugInteractionHandler) Remove(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.Remove(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
+
+func (h *DrugInteractionHandler) Import(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.ImportDrugInteractions{}
+	err = ctx.ShouldBind(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	result, err := h.uc.Import(ctx.Request.Context(), file)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: result.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -99,6 +99,9 @@ func WrapError(err error, customCode ...int) error {
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrForbiddenModifyEntity):
 		errWrapper.Code = http.StatusForbidden
 
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrMedicalRecordConsentNotGranted):
+		errWrapper.Code = http.StatusForbidden
+
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrDeleteAlreadyAssignedAdmin):
 		fallthrough
 
@@ -114,9 +117,27 @@ func WrapError(err error, customCode ...int) error {
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductCategoryStillUsedByProducts):
 		fallthrough
 
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductCategoryStillHasChildren):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductCategoryInvalidParent):
+		fallthrough
+
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductUniqueConstraint):
 		fallthrough
 
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductImageLastImage):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductImageOrderMismatch):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrInvalidImage):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrImageTooLarge):
+		fallthrough
+
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrInvalidLatLong):
 		fallthrough
 
@@ -210,6 +231,117 @@ func WrapError(err error, customCode ...int) error {
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrConsultationSessionAlreadyHasSickLeaveForm):
 		fallthrough
 
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrSickLeaveCertificateInvalid):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionMustHaveAtLeastOneProduct):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductInvalidDosage):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductFrequencyTooHigh):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductInvalidRoute):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductInvalidMealTiming):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionExpired):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionAlreadyRedeemed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductQuantityExceeded):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionProductMismatch):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionRequired):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionPatientMismatch):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrMedicationReminderPrescriptionNotRedeemed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrMedicationReminderDoseNotDue):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrMedicationReminderPrescriptionNoSchedule):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrClinicalNoteVersionConflict):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrReferralAlreadyUsed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrReferralToSameDoctor):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrReferralDoctorSpecializationMismatch):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrReferralDoctorRequired):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionTemplateNameAlreadyUsed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrConsultationSessionExtensionWaiting):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrConsultationSessionExtensionNotWaiting):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrConsultationSessionExtensionPaymentProofRequired):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPrescriptionSevereInteraction):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrDrugInteractionUniqueConstraint):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrDrugInteractionSameGenericName):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrDrugInteractionInvalidSeverity):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrDrugInteractionImportInvalidFile):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductImportInvalidFile):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductImportTooManyRows):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductCodeAlreadyUsed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPharmacyProductImportInvalidFile):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrPharmacyProductImportTooManyRows):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductAlertAlreadyExists):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductReviewNotAllowed):
+		fallthrough
+
+	case errors.Is(errWrapper.ErrorStored, apperror.ErrProductReviewAlreadyExists):
+		fallthrough
+
 	case errors.Is(errWrapper.ErrorStored, apperror.ErrChatAlreadyEnded):
 		errWrapper.Code = http.StatusBadRequest
 
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type MedicalProfileHandler struct {
+	uc        usecase.MedicalProfileUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewMedicalProfileHandler(uc usecase.MedicalProfileUseCase, validator appvalidator.AppValidator) *MedicalProfileHandler {
+	return &MedicalProfileHandler{uc: uc, validator: validator}
+}
+
+func (h *MedicalProfileHandler) GetMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	userId := ctx.Request.Context().Value(appconstant.ContextKeyUserId).(int64)
+
+	profile, err := h.uc.GetByUserId(ctx, userId)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: profile.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) GetByUserId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.MedicalProfileByUserId{}
+	err = ctx.ShouldBindUri(&uri)
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	profile, err := h.uc.GetByUserId(ctx, uri.UserId)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: profile.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) Edit(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.EditMedicalProfile{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.uc.Edit(ctx, req.ToMedicalProfile())
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) AddMeasurement(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddMedicalProfileMeasurement{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	profile, err := h.uc.AddMeasurement(ctx, req.ToMedicalProfileMeasurement())
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: profile.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) GetByDependentId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	profile, err := h.uc.GetByDependentId(ctx, uri.Id)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: profile.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) EditDependent(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.EditMedicalProfile{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	profile := req.ToMedicalProfile()
+	profile.DependentId = appdb.NewSqlNullInt64(uri.Id)
+	edited, err := h.uc.Edit(ctx, profile)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalProfileHandler) AddDependentMeasurement(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.AddMedicalProfileMeasurement{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	measurement := req.ToMedicalProfileMeasurement()
+	measurement.DependentId = appdb.NewSqlNullInt64(uri.Id)
+	profile, err := h.uc.AddMeasurement(ctx, measurement)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: profile.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type MedicalRecordHandler struct {
+	uc        usecase.MedicalRecordUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewMedicalRecordHandler(uc usecase.MedicalRecordUseCase, validator appvalidator.AppValidator) *MedicalRecordHandler {
+	return &MedicalRecordHandler{uc: uc, validator: validator}
+}
+
+func (h *MedicalRecordHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	getAllMedicalRecordsQuery := queryparamdto.GetAllMedicalRecordsQuery{}
+	_ = ctx.ShouldBindQuery(&getAllMedicalRecordsQuery)
+
+	err = h.validator.Validate(getAllMedicalRecordsQuery)
+	if err != nil {
+		return
+	}
+
+	param, err := getAllMedicalRecordsQuery.ToGetAllParams()
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.GetAllMine(ctx.Request.Context(), param)
+	if err != nil {
+		return
+	}
+
+	paginatedItems.Items = h.toMedicalRecordResponses(paginatedItems.Items.([]*entity.MedicalRecord))
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalRecordHandler) GetAllBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.MedicalRecordBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	getAllMedicalRecordsQuery := queryparamdto.GetAllMedicalRecordsQuery{}
+	_ = ctx.ShouldBindQuery(&getAllMedicalRecordsQuery)
+
+	err = h.validator.Validate(getAllMedicalRecordsQuery)
+	if err != nil {
+		return
+	}
+
+	param, err := getAllMedicalRecordsQuery.ToGetAllParams()
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.GetAllBySessionId(ctx.Request.Context(), uri.SessionId, param)
+	if err != nil {
+		return
+	}
+
+	paginatedItems.Items = h.toMedicalRecordResponses(paginatedItems.Items.([]*entity.MedicalRecord))
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalRecordHandler) GetConsentBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.MedicalRecordBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	consent, err := h.uc.GetConsentBySessionId(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: consent.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalRecordHandler) EditConsentBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.MedicalRecordBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.EditMedicalRecordConsent{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	consent, err := h.uc.EditConsentBySessionId(ctx.Request.Context(), uri.SessionId, req.ToMedicalRecordConsent())
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: consent.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicalRecordHandler) ExportFhirMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	bundle, err := h.uc.ExportFhirMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return
+	}
+	ctx.Data(http.StatusOK, appconstant.ContentTypeFhirJson, body)
+}
+
+func (h *MedicalRecordHandler) ExportFhirBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.MedicalRecordBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	bundle, err := h.uc.ExportFhirBySessionId(ctx.Request.Context(), uri.SessionId)
+	if err != nil {
+		return
+	}
+
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return
+	}
+	ctx.Data(http.StatusOK, appconstant.ContentTypeFhirJson, body)
+}
+
+func (h *MedicalRecordHandler) toMedicalRecordResponses(records []*entity.MedicalRecord) []*responsedto.MedicalRecordResponse {
+	resps := make([]*responsedto.MedicalRecordResponse, 0)
+	for _, record := range records {
+		resps = append(resps, record.ToResponse())
+	}
+	return resps
+}
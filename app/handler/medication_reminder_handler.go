@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type MedicationReminderHandler struct {
+	uc        usecase.MedicationReminderUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewMedicationReminderHandler(uc usecase.MedicationReminderUseCase, validator appvalidator.AppValidator) *MedicationReminderHandler {
+	return &MedicationReminderHandler{uc: uc, validator: validator}
+}
+
+func (h *MedicationReminderHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddMedicationReminder{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToMedicationReminder())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicationReminderHandler) AddFromPrescriptionProduct(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.AddMedicationReminderFromPrescription{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.AddFromPrescriptionProduct(ctx.Request.Context(), uri.Id, req.ToMedicationReminder())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicationReminderHandler) GetById(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	reminder, err := h.uc.GetById(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: reminder.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicationReminderHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	reminders, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.MedicationReminderResponse, 0)
+	for _, reminder := range reminders {
+		resps = append(resps, reminder.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *MedicationReminderHandler) Remove(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.Remove(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
+
+func (h *MedicationReminderHandler) LogDose(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.EditMedicationReminderDose{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	dose, err := h.uc.LogDose(ctx.Request.Context(), uri.Id, req.Status)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: dose.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
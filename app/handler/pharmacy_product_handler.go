@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/appvalidator"
 	"halodeksik-be/app/dto"
@@ -201,3 +203,113 @@ func (h *PharmacyProductHandler) Edit(ctx *gin.Context) {
 	resp := dto.ResponseDto{Data: updated.ToPharmacyProductResponse()}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+func (h *PharmacyProductHandler) Import(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.ImportPharmacyProducts{}
+	err = ctx.ShouldBind(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	result, err := h.uc.Import(ctx.Request.Context(), req.PharmacyId, file, req.DryRun)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: result.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PharmacyProductHandler) Export(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	query := queryparamdto.ExportPharmacyProductsQuery{}
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(query)
+	if err != nil {
+		return
+	}
+
+	inventory, err := h.uc.Export(ctx.Request.Context(), query.GetPharmacyId())
+	if err != nil {
+		return
+	}
+
+	fileName := fmt.Sprintf(appconstant.PharmacyInventoryFileName, query.GetPharmacyId())
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	ctx.Data(http.StatusOK, appconstant.ContentTypeCsv, inventory)
+}
+
+func (h *PharmacyProductHandler) GetAllPriceHistories(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	getAllPriceHistoriesQuery := queryparamdto.GetAllPharmacyProductPriceHistoriesQuery{}
+	err = ctx.ShouldBindQuery(&getAllPriceHistoriesQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllPriceHistoriesQuery)
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.GetAllPriceHistories(ctx.Request.Context(), uri.Id, getAllPriceHistoriesQuery.ToGetAllParams())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.PharmacyProductPriceHistoryResponse, 0)
+	for _, history := range paginatedItems.Items.([]*entity.PharmacyProductPriceHistory) {
+		resps = append(resps, history.ToResponse())
+	}
+	paginatedItems.Items = resps
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
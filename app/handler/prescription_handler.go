@@ -5,7 +5,9 @@ import (
 	"halodeksik-be/app/appvalidator"
 	"halodeksik-be/app/dto"
 	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
 	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
 	"halodeksik-be/app/usecase"
 	"net/http"
 )
@@ -104,3 +106,38 @@ func (h *PrescriptionHandler) EditBySessionId(ctx *gin.Context) {
 	resp := dto.ResponseDto{Data: edited.ToResponse()}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+func (h *PrescriptionHandler) RedeemBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.PrescriptionBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.RedeemBySessionId(ctx, uri.SessionId)
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.CartItemResponse, 0)
+	for _, cartItem := range paginatedItems.Items.([]*entity.CartItem) {
+		resps = append(resps, cartItem.ToResponse())
+	}
+	paginatedItems.Items = resps
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
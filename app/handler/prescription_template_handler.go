@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type PrescriptionTemplateHandler struct {
+	uc        usecase.PrescriptionTemplateUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewPrescriptionTemplateHandler(uc usecase.PrescriptionTemplateUseCase, validator appvalidator.AppValidator) *PrescriptionTemplateHandler {
+	return &PrescriptionTemplateHandler{uc: uc, validator: validator}
+}
+
+func (h *PrescriptionTemplateHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddEditPrescriptionTemplate{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToPrescriptionTemplate())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PrescriptionTemplateHandler) GetById(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	template, err := h.uc.GetById(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: template.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PrescriptionTemplateHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	templates, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.PrescriptionTemplateResponse, 0)
+	for _, template := range templates {
+		resps = append(resps, template.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PrescriptionTemplateHandler) Edit(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.AddEditPrescriptionTemplate{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.uc.Edit(ctx.Request.Context(), uri.Id, req.ToPrescriptionTemplate())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PrescriptionTemplateHandler) Remove(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.Remove(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
+
+func (h *PrescriptionTemplateHandler) Apply(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.ApplyPrescriptionTemplate{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Apply(ctx.Request.Context(), uri.Id, req.ToPrescription())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *PrescriptionTemplateHandler) AddFavoriteProduct(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.AddFavoriteProduct(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
+
+func (h *PrescriptionTemplateHandler) RemoveFavoriteProduct(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.RemoveFavoriteProduct(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
+
+func (h *PrescriptionTemplateHandler) GetAllFavoriteProducts(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	products, err := h.uc.GetAllFavoriteProducts(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ProductResponse, 0)
+	for _, product := range products {
+		resps = append(resps, product.ToProductResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
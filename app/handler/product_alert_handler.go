@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type ProductAlertHandler struct {
+	uc        usecase.ProductAlertUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewProductAlertHandler(uc usecase.ProductAlertUseCase, validator appvalidator.AppValidator) *ProductAlertHandler {
+	return &ProductAlertHandler{uc: uc, validator: validator}
+}
+
+func (h *ProductAlertHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddProductAlert{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToProductAlert())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductAlertHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	alerts, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ProductAlertResponse, 0)
+	for _, alert := range alerts {
+		resps = append(resps, alert.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductAlertHandler) Remove(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	err = h.uc.Remove(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusNoContent, dto.ResponseDto{})
+}
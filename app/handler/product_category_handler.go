@@ -132,6 +132,28 @@ func (h *ProductCategoryHandler) GetAll(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+func (h *ProductCategoryHandler) GetTree(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	roots, err := h.uc.GetTree(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ProductCategoryResponse, 0)
+	for _, root := range roots {
+		resps = append(resps, root.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func (h *ProductCategoryHandler) Edit(ctx *gin.Context) {
 	var err error
 	defer func() {
@@ -171,6 +193,45 @@ func (h *ProductCategoryHandler) Edit(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+func (h *ProductCategoryHandler) Move(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.MoveProductCategory{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	moved, err := h.uc.Move(ctx.Request.Context(), uri.Id, req.ToParentId())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: moved.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func (h *ProductCategoryHandler) Remove(ctx *gin.Context) {
 	var err error
 	defer func() {
@@ -122,7 +122,7 @@ func (h *ProductHandler) GetByIdForUser(ctx *gin.Context) {
 		return
 	}
 
-	product, err := h.uc.GetByIdForUser(ctx.Request.Context(), uri.Id, getByIdProductQuery.ToGetAllParams())
+	product, err := h.uc.GetByIdForUser(ctx.Request.Context(), uri.Id, getByIdProductQuery.ToGetAllParams(), getByIdProductQuery.ToRelatedProductParams())
 	if err != nil {
 		return
 	}
@@ -160,6 +160,11 @@ func (h *ProductHandler) GetAll(ctx *gin.Context) {
 		return
 	}
 
+	paginatedItems.Facets, err = h.uc.GetAllFacetsForUser(ctx.Request.Context(), lat, long, getAllProductQuery.ToFacetParams())
+	if err != nil {
+		return
+	}
+
 	resps := make([]*responsedto.ProductResponse, 0)
 	for _, product := range paginatedItems.Items.([]*entity.Product) {
 		resps = append(resps, product.ToProductResponse())
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/usecase"
+	"net/http"
+	"strings"
+)
+
+type ProductImportHandler struct {
+	uc        usecase.ProductImportUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewProductImportHandler(uc usecase.ProductImportUseCase, validator appvalidator.AppValidator) *ProductImportHandler {
+	return &ProductImportHandler{uc: uc, validator: validator}
+}
+
+// Add validates every csv row right away and leaves resolving and creating the products to the background import
+func (h *ProductImportHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.ImportProducts{}
+	err = ctx.ShouldBind(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	importRows, err := requestdto.ReadImportProductRows(file)
+	if err != nil {
+		return
+	}
+
+	rows := make([]*entity.ProductImportRow, 0, len(importRows))
+	for index, importRow := range importRows {
+		// the header is the first line of the file
+		row := &entity.ProductImportRow{
+			RowNumber:              int32(index + 2),
+			Product:                importRow.ToProduct(),
+			ManufacturerName:       importRow.Manufacturer,
+			DrugClassificationName: importRow.DrugClassification,
+			ProductCategoryName:    importRow.ProductCategory,
+		}
+
+		var errValidation validator.ValidationErrors
+		if errRow := h.validator.Validate(importRow); errors.As(errRow, &errValidation) {
+			row.Errors = strings.Split(handleErrValidation(errValidation), appconstant.ProductImportRowErrorsSeparator)
+		}
+		rows = append(rows, row)
+	}
+
+	productImport := entity.ProductImport{FileName: req.File.Filename, IsDryRun: req.DryRun}
+	added, err := h.uc.Add(ctx.Request.Context(), productImport, rows)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductImportHandler) GetById(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	productImport, err := h.uc.GetById(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: productImport.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductImportHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	productImports, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.ProductImportResponse, 0)
+	for _, productImport := range productImports {
+		resps = append(resps, productImport.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/dto/requestdto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type ProductReviewHandler struct {
+	uc        usecase.ProductReviewUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewProductReviewHandler(uc usecase.ProductReviewUseCase, validator appvalidator.AppValidator) *ProductReviewHandler {
+	return &ProductReviewHandler{uc: uc, validator: validator}
+}
+
+func (h *ProductReviewHandler) Add(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	req := requestdto.AddProductReview{}
+	err = ctx.ShouldBind(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	added, err := h.uc.Add(ctx.Request.Context(), req.ToProductReview(), req.Photos)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: added.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductReviewHandler) GetAllByProductId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	getAllProductReviewsQuery := queryparamdto.GetAllProductReviewsQuery{}
+	err = ctx.ShouldBindQuery(&getAllProductReviewsQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllProductReviewsQuery)
+	if err != nil {
+		return
+	}
+
+	param := getAllProductReviewsQuery.ToGetAllPublishedParams(uri.Id)
+	paginatedItems, err := h.uc.GetAllByProductId(ctx.Request.Context(), uri.Id, param)
+	if err != nil {
+		return
+	}
+	h.toResponses(paginatedItems)
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductReviewHandler) GetAll(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	getAllProductReviewsQuery := queryparamdto.GetAllProductReviewsQuery{}
+	err = ctx.ShouldBindQuery(&getAllProductReviewsQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllProductReviewsQuery)
+	if err != nil {
+		return
+	}
+
+	param := getAllProductReviewsQuery.ToGetAllParams()
+	paginatedItems, err := h.uc.GetAll(ctx.Request.Context(), param)
+	if err != nil {
+		return
+	}
+	h.toResponses(paginatedItems)
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductReviewHandler) EditStatus(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	req := requestdto.EditProductReviewStatus{}
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return
+	}
+
+	edited, err := h.uc.EditStatus(ctx.Request.Context(), uri.Id, req.ToProductReview())
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: edited.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *ProductReviewHandler) toResponses(paginatedItems *entity.PaginatedItems) {
+	resps := make([]*responsedto.ProductReviewResponse, 0)
+	for _, review := range paginatedItems.Items.([]*entity.ProductReview) {
+		resps = append(resps, review.ToResponse())
+	}
+	paginatedItems.Items = resps
+}
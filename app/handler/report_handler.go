@@ -150,3 +150,61 @@ func (h ReportHandler) GetAllSellPharmacyAdminMonthly(ctx *gin.Context) {
 	resp := dto.ResponseDto{Data: paginatedItems}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+func (h ReportHandler) GetAllSellPrice(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	getAllSellPricesQuery := queryparamdto.GetAllSellPricesQuery{}
+	err = ctx.ShouldBindQuery(&getAllSellPricesQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllSellPricesQuery)
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.GetSellPrices(ctx.Request.Context(), getAllSellPricesQuery.ToGetAllParams())
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h ReportHandler) GetAllSellPricePharmacyAdmin(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	getAllSellPricesQuery := queryparamdto.GetAllSellPricesQuery{}
+	err = ctx.ShouldBindQuery(&getAllSellPricesQuery)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(getAllSellPricesQuery)
+	if err != nil {
+		return
+	}
+
+	paginatedItems, err := h.uc.GetSellPricesAdminPharmacy(ctx.Request.Context(), getAllSellPricesQuery.ToGetAllParams())
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: paginatedItems}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/appvalidator"
 	"halodeksik-be/app/dto"
 	"halodeksik-be/app/dto/requestdto"
 	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/entity"
 	"halodeksik-be/app/usecase"
 	"net/http"
 )
@@ -134,3 +137,67 @@ func (h *SickLeaveFormHandler) EditBySessionId(ctx *gin.Context) {
 	resp := dto.ResponseDto{Data: edited.ToResponse()}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+func (h *SickLeaveFormHandler) GetCertificateBySessionId(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.SickLeaveFormBySessionId{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	certificate, err := h.uc.GetCertificateBySessionId(ctx, uri.SessionId)
+	if err != nil {
+		return
+	}
+
+	fileName := fmt.Sprintf(appconstant.SickLeaveCertificateFileName, uri.SessionId)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	ctx.Data(http.StatusOK, appconstant.ContentTypePdf, certificate)
+}
+
+func (h *SickLeaveFormHandler) Verify(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.SickLeaveFormVerificationCode{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	verification := entity.SickLeaveFormVerification{
+		IpAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+
+	form, err := h.uc.Verify(ctx, uri.Code, verification)
+	if err != nil {
+		return
+	}
+
+	resp := dto.ResponseDto{Data: form.ToVerificationResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
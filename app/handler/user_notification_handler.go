@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"halodeksik-be/app/appvalidator"
+	"halodeksik-be/app/dto"
+	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/dto/uriparamdto"
+	"halodeksik-be/app/usecase"
+	"net/http"
+)
+
+type UserNotificationHandler struct {
+	uc        usecase.UserNotificationUseCase
+	validator appvalidator.AppValidator
+}
+
+func NewUserNotificationHandler(uc usecase.UserNotificationUseCase, validator appvalidator.AppValidator) *UserNotificationHandler {
+	return &UserNotificationHandler{uc: uc, validator: validator}
+}
+
+func (h *UserNotificationHandler) GetAllMine(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	notifications, err := h.uc.GetAllMine(ctx.Request.Context())
+	if err != nil {
+		return
+	}
+
+	resps := make([]*responsedto.UserNotificationResponse, 0)
+	for _, notification := range notifications {
+		resps = append(resps, notification.ToResponse())
+	}
+	resp := dto.ResponseDto{Data: resps}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *UserNotificationHandler) EditAsRead(ctx *gin.Context) {
+	var err error
+	defer func() {
+		if err != nil {
+			err = WrapError(err)
+			_ = ctx.Error(err)
+		}
+	}()
+
+	uri := uriparamdto.ResourceById{}
+	err = ctx.ShouldBindUri(&uri)
+	if err != nil {
+		return
+	}
+
+	err = h.validator.Validate(uri)
+	if err != nil {
+		return
+	}
+
+	notification, err := h.uc.EditAsRead(ctx.Request.Context(), uri.Id)
+	if err != nil {
+		return
+	}
+	resp := dto.ResponseDto{Data: notification.ToResponse()}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -28,10 +28,10 @@ func NewCartItemRepositoryImpl(db *sql.DB) *CartItemRepositoryImpl {
 
 func (repo *CartItemRepositoryImpl) Create(ctx context.Context, cartItem entity.CartItem) (*entity.CartItem, error) {
 	const create = `
-	INSERT INTO cart_items(user_id, product_id, quantity)
-	VALUES ($1, $2, $3) RETURNING id, user_id, product_id, quantity, created_at, updated_at, deleted_at`
+	INSERT INTO cart_items(user_id, product_id, quantity, prescription_product_id)
+	VALUES ($1, $2, $3, $4) RETURNING id, user_id, product_id, quantity, prescription_product_id, created_at, updated_at, deleted_at`
 
-	row := repo.db.QueryRowContext(ctx, create, cartItem.UserId, cartItem.ProductId, cartItem.Quantity)
+	row := repo.db.QueryRowContext(ctx, create, cartItem.UserId, cartItem.ProductId, cartItem.Quantity, cartItem.PrescriptionProductId)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
@@ -41,6 +41,7 @@ func (repo *CartItemRepositoryImpl) Create(ctx context.Context, cartItem entity.
 		&created.UserId,
 		&created.ProductId,
 		&created.Quantity,
+		&created.PrescriptionProductId,
 		&created.CreatedAt,
 		&created.UpdatedAt,
 		&created.DeletedAt,
@@ -49,14 +50,14 @@ func (repo *CartItemRepositoryImpl) Create(ctx context.Context, cartItem entity.
 }
 
 func (repo *CartItemRepositoryImpl) FindByUserIdAndProductId(ctx context.Context, userId int64, productId int64) (*entity.CartItem, error) {
-	const findByUserIdAndProductId = `SELECT id, user_id, product_id, quantity, created_at, updated_at, deleted_at
+	const findByUserIdAndProductId = `SELECT id, user_id, product_id, quantity, prescription_product_id, created_at, updated_at, deleted_at
 		FROM cart_items
 		WHERE user_id = $1 AND product_id = $2 AND deleted_at IS NULL LIMIT 1`
 
 	row := repo.db.QueryRowContext(ctx, findByUserIdAndProductId, userId, productId)
 	var found entity.CartItem
 	err := row.Scan(
-		&found.Id, &found.UserId, &found.ProductId, &found.Quantity, &found.CreatedAt, &found.UpdatedAt, &found.DeletedAt,
+		&found.Id, &found.UserId, &found.ProductId, &found.Quantity, &found.PrescriptionProductId, &found.CreatedAt, &found.UpdatedAt, &found.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -69,7 +70,7 @@ func (repo *CartItemRepositoryImpl) FindByUserIdAndProductId(ctx context.Context
 
 func (repo *CartItemRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64) ([]*entity.CartItem, error) {
 	const findAll = `
-	SELECT ci.id, ci.user_id, ci.product_id, ci.quantity, 
+	SELECT ci.id, ci.user_id, ci.product_id, ci.quantity, ci.prescription_product_id,
 	       products.id, products.name, products.generic_name, products.content, products.manufacturer_id, 
 	       products.description, products.drug_classification_id, products.product_category_id, products.drug_form, 
 	       products.unit_in_pack, products.selling_unit, products.weight, products.length, products.width, products.height, products.image,
@@ -98,7 +99,7 @@ func (repo *CartItemRepositoryImpl) FindAllByUserId(ctx context.Context, userId
 			product  entity.Product
 		)
 		if err := rows.Scan(
-			&cartItem.Id, &cartItem.UserId, &cartItem.ProductId, &cartItem.Quantity,
+			&cartItem.Id, &cartItem.UserId, &cartItem.ProductId, &cartItem.Quantity, &cartItem.PrescriptionProductId,
 			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId,
 			&product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
 			&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width,
@@ -119,7 +120,7 @@ func (repo *CartItemRepositoryImpl) FindAllByUserId(ctx context.Context, userId
 }
 
 func (repo *CartItemRepositoryImpl) FindByMultipleIds(ctx context.Context, id ...int64) ([]*entity.CartItem, error) {
-	const findByMultipleIds = `SELECT id, user_id, product_id, quantity
+	const findByMultipleIds = `SELECT id, user_id, product_id, quantity, prescription_product_id
 	FROM cart_items WHERE id = ANY ($1::int[]) AND deleted_at IS NULL`
 
 	rows, err := repo.db.QueryContext(ctx, findByMultipleIds, pq.Array(id))
@@ -132,7 +133,7 @@ func (repo *CartItemRepositoryImpl) FindByMultipleIds(ctx context.Context, id ..
 	for rows.Next() {
 		var cartItem entity.CartItem
 		if err := rows.Scan(
-			&cartItem.Id, &cartItem.UserId, &cartItem.ProductId, &cartItem.Quantity,
+			&cartItem.Id, &cartItem.UserId, &cartItem.ProductId, &cartItem.Quantity, &cartItem.PrescriptionProductId,
 		); err != nil {
 			return nil, err
 		}
@@ -148,17 +149,18 @@ func (repo *CartItemRepositoryImpl) FindByMultipleIds(ctx context.Context, id ..
 }
 
 func (repo *CartItemRepositoryImpl) Update(ctx context.Context, cartItem entity.CartItem) (*entity.CartItem, error) {
-	const update = `UPDATE cart_items SET quantity = $1, updated_at = now()
-		WHERE user_id = $2 AND product_id = $3 AND deleted_at IS NULL
-		RETURNING id, user_id, product_id, quantity, created_at, updated_at, deleted_at`
+	const update = `UPDATE cart_items SET quantity = $1, prescription_product_id = $2, updated_at = now()
+		WHERE user_id = $3 AND product_id = $4 AND deleted_at IS NULL
+		RETURNING id, user_id, product_id, quantity, prescription_product_id, created_at, updated_at, deleted_at`
 
-	row := repo.db.QueryRowContext(ctx, update, cartItem.Quantity, cartItem.UserId, cartItem.ProductId)
+	row := repo.db.QueryRowContext(ctx, update, cartItem.Quantity, cartItem.PrescriptionProductId, cartItem.UserId, cartItem.ProductId)
 	var updated entity.CartItem
 	err := row.Scan(
 		&updated.Id,
 		&updated.UserId,
 		&updated.ProductId,
 		&updated.Quantity,
+		&updated.PrescriptionProductId,
 		&updated.CreatedAt,
 		&updated.UpdatedAt,
 		&updated.DeletedAt,
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type ClinicalNoteRepository interface {
+	FindBySessionId(ctx context.Context, sessionId int64) (*entity.ClinicalNote, error)
+	FindAllVersionsBySessionId(ctx context.Context, sessionId int64) ([]*entity.ClinicalNoteVersion, error)
+	Save(ctx context.Context, note entity.ClinicalNote) (*entity.ClinicalNote, error)
+}
+
+type ClinicalNoteRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewClinicalNoteRepositoryImpl(db *sql.DB) *ClinicalNoteRepositoryImpl {
+	return &ClinicalNoteRepositoryImpl{db: db}
+}
+
+func (repo *ClinicalNoteRepositoryImpl) FindBySessionId(ctx context.Context, sessionId int64) (*entity.ClinicalNote, error) {
+	const findBySessionId = `SELECT id, session_id, doctor_id, subjective, objective, assessment, plan, version, created_at, updated_at
+	FROM clinical_notes WHERE session_id = $1`
+
+	row := repo.db.QueryRowContext(ctx, findBySessionId, sessionId)
+	var note entity.ClinicalNote
+	err := row.Scan(
+		&note.Id, &note.SessionId, &note.DoctorId, &note.Subjective, &note.Objective, &note.Assessment, &note.Plan,
+		&note.Version, &note.CreatedAt, &note.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	if err = decryptFields(&note.Subjective, &note.Objective, &note.Assessment, &note.Plan); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (repo *ClinicalNoteRepositoryImpl) FindAllVersionsBySessionId(ctx context.Context, sessionId int64) ([]*entity.ClinicalNoteVersion, error) {
+	const findAllVersionsBySessionId = `SELECT clinical_note_versions.id, clinical_note_id, clinical_note_versions.version,
+		   clinical_note_versions.subjective, clinical_note_versions.objective, clinical_note_versions.assessment,
+		   clinical_note_versions.plan, edited_by, clinical_note_versions.created_at
+	FROM clinical_note_versions
+		INNER JOIN clinical_notes ON clinical_note_versions.clinical_note_id = clinical_notes.id
+	WHERE clinical_notes.session_id = $1
+	ORDER BY clinical_note_versions.version DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllVersionsBySessionId, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ClinicalNoteVersion, 0)
+	for rows.Next() {
+		var version entity.ClinicalNoteVersion
+		if err := rows.Scan(
+			&version.Id, &version.ClinicalNoteId, &version.Version, &version.Subjective, &version.Objective,
+			&version.Assessment, &version.Plan, &version.EditedBy, &version.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := decryptFields(&version.Subjective, &version.Objective, &version.Assessment, &version.Plan); err != nil {
+			return nil, err
+		}
+		items = append(items, &version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Save creates the note when note.Version is 0 and otherwise updates it only if note.Version is still the current
+// version, each successful save is copied into clinical_note_versions in the same transaction
+func (repo *ClinicalNoteRepositoryImpl) Save(ctx context.Context, note entity.ClinicalNote) (*entity.ClinicalNote, error) {
+	const createNote = `INSERT INTO clinical_notes(session_id, doctor_id, subjective, objective, assessment, plan)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (session_id) DO NOTHING
+	RETURNING id, session_id, doctor_id, subjective, objective, assessment, plan, version, created_at, updated_at`
+
+	const updateNote = `UPDATE clinical_notes
+	SET subjective = $3, objective = $4, assessment = $5, plan = $6, doctor_id = $2, version = version + 1, updated_at = now()
+	WHERE session_id = $1 AND version = $7
+	RETURNING id, session_id, doctor_id, subjective, objective, assessment, plan, version, created_at, updated_at`
+
+	const createVersion = `INSERT INTO clinical_note_versions(clinical_note_id, version, subjective, objective, assessment, plan, edited_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if err := encryptFields(&note.Subjective, &note.Objective, &note.Assessment, &note.Plan); err != nil {
+		return nil, err
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(tx *sql.Tx) {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}(tx)
+
+	args := []any{note.SessionId, note.DoctorId, note.Subjective, note.Objective, note.Assessment, note.Plan}
+	var row *sql.Row
+	if note.Version == 0 {
+		row = tx.QueryRowContext(ctx, createNote, args...)
+	} else {
+		row = tx.QueryRowContext(ctx, updateNote, append(args, note.Version)...)
+	}
+
+	var saved entity.ClinicalNote
+	err = row.Scan(
+		&saved.Id, &saved.SessionId, &saved.DoctorId, &saved.Subjective, &saved.Objective, &saved.Assessment, &saved.Plan,
+		&saved.Version, &saved.CreatedAt, &saved.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = apperror.ErrClinicalNoteVersionConflict
+		}
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, createVersion,
+		saved.Id, saved.Version, saved.Subjective, saved.Objective, saved.Assessment, saved.Plan, saved.DoctorId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decryptFields(&saved.Subjective, &saved.Objective, &saved.Assessment, &saved.Plan); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
@@ -24,9 +24,19 @@ func (repo *ConsultationMessageRepositoryImpl) Create(ctx context.Context, messa
 	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id, session_id, sender_id, message_type, message, attachment, created_at, updated_at`
 
+	if err := encryptNullString(&message.Message); err != nil {
+		return nil, err
+	}
+
 	row := repo.db.QueryRowContext(ctx, create, message.SessionId, message.SenderId, message.MessageType, message.Message, message.Attachment)
 	var created entity.ConsultationMessage
 	err := row.Scan(&created.Id, &created.SessionId, &created.SenderId, &created.MessageType, &created.Message, &created.Attachment, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
 
-	return &created, err
+	if err = decryptNullString(&created.Message); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
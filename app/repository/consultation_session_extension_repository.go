@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type ConsultationSessionExtensionRepository interface {
+	Create(ctx context.Context, extension entity.ConsultationSessionExtension) (*entity.ConsultationSessionExtension, error)
+	FindById(ctx context.Context, id int64) (*entity.ConsultationSessionExtension, error)
+	FindAllBySessionId(ctx context.Context, sessionId int64) ([]*entity.ConsultationSessionExtension, error)
+	UpdateStatus(ctx context.Context, id int64, status string) (*entity.ConsultationSessionExtension, error)
+}
+
+type ConsultationSessionExtensionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewConsultationSessionExtensionRepositoryImpl(db *sql.DB) *ConsultationSessionExtensionRepositoryImpl {
+	return &ConsultationSessionExtensionRepositoryImpl{db: db}
+}
+
+// Create copies the session length of the doctor's specialization and the doctor's consultation fee into the request
+func (repo *ConsultationSessionExtensionRepositoryImpl) Create(ctx context.Context, extension entity.ConsultationSessionExtension) (*entity.ConsultationSessionExtension, error) {
+	const create = `
+	INSERT INTO consultation_session_extensions(session_id, duration_minutes, fee, payment_proof, status)
+	SELECT consultation_sessions.id, doctor_specializations.session_duration_minutes, doctor_profiles.consultation_fee, $2, $3
+	FROM consultation_sessions
+		INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
+		INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id
+	WHERE consultation_sessions.id = $1
+	RETURNING id, session_id, duration_minutes, fee, payment_proof, status, created_at, updated_at`
+
+	row := repo.db.QueryRowContext(ctx, create, extension.SessionId, extension.PaymentProof, appconstant.ConsultationSessionExtensionStatusWaiting)
+	var created entity.ConsultationSessionExtension
+	err := row.Scan(
+		&created.Id, &created.SessionId, &created.DurationMinutes, &created.Fee, &created.PaymentProof, &created.Status,
+		&created.CreatedAt, &created.UpdatedAt,
+	)
+	if err != nil {
+		var errPgConn *pgconn.PgError
+		if errors.As(err, &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+			return nil, apperror.ErrConsultationSessionExtensionWaiting
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (repo *ConsultationSessionExtensionRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ConsultationSessionExtension, error) {
+	const findById = `SELECT id, session_id, duration_minutes, fee, payment_proof, status, created_at, updated_at
+	FROM consultation_session_extensions WHERE id = $1`
+
+	row := repo.db.QueryRowContext(ctx, findById, id)
+	var extension entity.ConsultationSessionExtension
+	err := row.Scan(
+		&extension.Id, &extension.SessionId, &extension.DurationMinutes, &extension.Fee, &extension.PaymentProof, &extension.Status,
+		&extension.CreatedAt, &extension.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &extension, nil
+}
+
+func (repo *ConsultationSessionExtensionRepositoryImpl) FindAllBySessionId(ctx context.Context, sessionId int64) ([]*entity.ConsultationSessionExtension, error) {
+	const findAllBySessionId = `SELECT id, session_id, duration_minutes, fee, payment_proof, status, created_at, updated_at
+	FROM consultation_session_extensions WHERE session_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllBySessionId, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ConsultationSessionExtension, 0)
+	for rows.Next() {
+		var extension entity.ConsultationSessionExtension
+		if err := rows.Scan(
+			&extension.Id, &extension.SessionId, &extension.DurationMinutes, &extension.Fee, &extension.PaymentProof, &extension.Status,
+			&extension.CreatedAt, &extension.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &extension)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpdateStatus settles a waiting extension, accepting it moves the session expiry forward in the same transaction.
+// An expired session that was kept open by the waiting extension continues from now instead of its old expiry
+func (repo *ConsultationSessionExtensionRepositoryImpl) UpdateStatus(ctx context.Context, id int64, status string) (*entity.ConsultationSessionExtension, error) {
+	const updateStatus = `
+	UPDATE consultation_session_extensions SET status = $1, updated_at = now()
+	WHERE id = $2 AND status = $3
+	RETURNING id, session_id, duration_minutes, fee, payment_proof, status, created_at, updated_at`
+
+	const extendSession = `
+	UPDATE consultation_sessions
+	SET expires_at = GREATEST(COALESCE(expires_at, now()), now()) + $1 * INTERVAL '1 minute', updated_at = now()
+	WHERE id = $2 AND consultation_session_status_id = $3
+	RETURNING expires_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var updated entity.ConsultationSessionExtension
+	err = tx.QueryRowContext(ctx, updateStatus, status, id, appconstant.ConsultationSessionExtensionStatusWaiting).Scan(
+		&updated.Id, &updated.SessionId, &updated.DurationMinutes, &updated.Fee, &updated.PaymentProof, &updated.Status,
+		&updated.CreatedAt, &updated.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrConsultationSessionExtensionNotWaiting
+		}
+		return nil, err
+	}
+
+	if status == appconstant.ConsultationSessionExtensionStatusAccepted {
+		var expiresAt sql.NullTime
+		err = tx.QueryRowContext(ctx, extendSession, updated.DurationMinutes, updated.SessionId, appconstant.ConsultationSessionStatusOngoing).Scan(&expiresAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apperror.ErrChatAlreadyEnded
+			}
+			return nil, err
+		}
+		updated.Session = &entity.ConsultationSession{Id: updated.SessionId, ExpiresAt: expiresAt}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -4,22 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/util"
+	"time"
 )
 
 type ConsultationSessionRepository interface {
 	Create(ctx context.Context, session entity.ConsultationSession) (*entity.ConsultationSession, error)
 	FindById(ctx context.Context, id int64) (*entity.ConsultationSession, error)
 	FindByIdJoinAll(ctx context.Context, id int64) (*entity.ConsultationSession, error)
-	FindByUserIdAndDoctorId(ctx context.Context, userId, doctorId int64) (*entity.ConsultationSession, error)
+	FindByPatientAndDoctorId(ctx context.Context, userId int64, dependentId sql.NullInt64, doctorId int64) (*entity.ConsultationSession, error)
+	FindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.ConsultationSession, error)
 	FindAllByUserIdOrDoctorId(ctx context.Context, userIdOrDoctorId int64, param *queryparamdto.GetAllParams) ([]*entity.ConsultationSession, error)
 	CountFindAllByUserIdOrDoctorId(ctx context.Context, userIdOrDoctorId int64, param *queryparamdto.GetAllParams) (int64, error)
+	FindAllExpired(ctx context.Context, now time.Time) ([]*entity.ConsultationSession, error)
 	Update(ctx context.Context, session entity.ConsultationSession) (*entity.ConsultationSession, error)
 }
 
+// sessionExpiresAtFromDoctor gives a new session one full session length of the doctor's specialization,
+// the doctor id must be bound to $3
+const sessionExpiresAtFromDoctor = `(
+	SELECT now() + doctor_specializations.session_duration_minutes * INTERVAL '1 minute'
+	FROM doctor_profiles
+	INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id
+	WHERE doctor_profiles.user_id = $3)`
+
 type ConsultationSessionRepositoryImpl struct {
 	db *sql.DB
 }
@@ -29,21 +41,24 @@ func NewConsultationSessionRepositoryImpl(db *sql.DB) *ConsultationSessionReposi
 }
 
 func (repo *ConsultationSessionRepositoryImpl) Create(ctx context.Context, session entity.ConsultationSession) (*entity.ConsultationSession, error) {
-	const create = `INSERT INTO consultation_sessions(user_id, doctor_id, consultation_session_status_id)
-	VALUES ($1, $2, $3) RETURNING
-	id, user_id, doctor_id, consultation_session_status_id, created_at, updated_at`
+	const create = `INSERT INTO consultation_sessions(user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at)
+	VALUES ($1, $2, $3, $4, ` + sessionExpiresAtFromDoctor + `) RETURNING
+	id, user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at, created_at, updated_at`
 
-	row := repo.db.QueryRowContext(ctx, create, session.UserId, session.DoctorId, session.ConsultationSessionStatusId)
+	row := repo.db.QueryRowContext(ctx, create, session.UserId, session.DependentId, session.DoctorId, session.ConsultationSessionStatusId)
 	var created entity.ConsultationSession
-	err := row.Scan(&created.Id, &created.UserId, &created.DoctorId, &created.ConsultationSessionStatusId, &created.CreatedAt, &created.UpdatedAt)
+	err := row.Scan(
+		&created.Id, &created.UserId, &created.DependentId, &created.DoctorId, &created.ConsultationSessionStatusId,
+		&created.ExpiresAt, &created.CreatedAt, &created.UpdatedAt,
+	)
 
 	return &created, err
 }
 
 func (repo *ConsultationSessionRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ConsultationSession, error) {
 	const findById = `
-	SELECT consultation_sessions.id, consultation_sessions.user_id, doctor_id, consultation_session_status_id,
-       consultation_sessions.created_at, consultation_sessions.updated_at
+	SELECT consultation_sessions.id, consultation_sessions.user_id, consultation_sessions.dependent_id, doctor_id, consultation_session_status_id,
+       consultation_sessions.expires_at, consultation_sessions.created_at, consultation_sessions.updated_at
 	FROM  consultation_sessions
 	WHERE consultation_sessions.deleted_at IS NULL AND consultation_sessions.id = $1;`
 
@@ -54,8 +69,8 @@ func (repo *ConsultationSessionRepositoryImpl) FindById(ctx context.Context, id
 
 	var session entity.ConsultationSession
 	err := row.Scan(
-		&session.Id, &session.UserId, &session.DoctorId, &session.ConsultationSessionStatusId,
-		&session.CreatedAt, &session.UpdatedAt,
+		&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+		&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 	)
 
 	if err != nil {
@@ -70,10 +85,11 @@ func (repo *ConsultationSessionRepositoryImpl) FindById(ctx context.Context, id
 
 func (repo *ConsultationSessionRepositoryImpl) FindByIdJoinAll(ctx context.Context, id int64) (*entity.ConsultationSession, error) {
 	const findById = `
-	SELECT consultation_sessions.id, consultation_sessions.user_id, doctor_id, consultation_session_status_id,
-       consultation_sessions.created_at, consultation_sessions.updated_at,
+	SELECT consultation_sessions.id, consultation_sessions.user_id, consultation_sessions.dependent_id, doctor_id, consultation_session_status_id,
+       consultation_sessions.expires_at, consultation_sessions.created_at, consultation_sessions.updated_at,
        consultation_session_statuses.name AS session_status,
        user_profiles.user_id, user_profiles.name, user_profiles.profile_photo,
+       dependents.name, dependents.date_of_birth, dependents.relationship,
        doctor_profiles.user_id, doctor_profiles.name, doctor_profiles.profile_photo,
        cm.id, cm.session_id, cm.sender_id, cm.message_type, cm.message, cm.attachment, cm.created_at AS message_created_at,
        cm.updated_at AS message_updated_at
@@ -81,6 +97,7 @@ func (repo *ConsultationSessionRepositoryImpl) FindByIdJoinAll(ctx context.Conte
           INNER JOIN consultation_session_statuses ON consultation_sessions.consultation_session_status_id = consultation_session_statuses.id
           INNER JOIN user_profiles ON consultation_sessions.user_id = user_profiles.user_id
           INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
+          LEFT JOIN dependents ON consultation_sessions.dependent_id = dependents.id
   	LEFT JOIN LATERAL (
 		SELECT id, session_id, sender_id, message_type, message, attachment, created_at, updated_at
 		FROM consultation_messages
@@ -99,6 +116,7 @@ func (repo *ConsultationSessionRepositoryImpl) FindByIdJoinAll(ctx context.Conte
 		session       entity.ConsultationSession
 		sessionStatus entity.ConsultationSessionStatus
 		userProfile   entity.UserProfile
+		dependent     nullableDependent
 		doctorProfile entity.DoctorProfile
 	)
 
@@ -106,17 +124,22 @@ func (repo *ConsultationSessionRepositoryImpl) FindByIdJoinAll(ctx context.Conte
 	for rows.Next() {
 		var message entity.ConsultationMessage
 		if err := rows.Scan(
-			&session.Id, &session.UserId, &session.DoctorId, &session.ConsultationSessionStatusId,
-			&session.CreatedAt, &session.UpdatedAt,
+			&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+			&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 			&sessionStatus.Name,
 			&userProfile.UserId, &userProfile.Name, &userProfile.ProfilePhoto,
+			&dependent.Name, &dependent.DateOfBirth, &dependent.Relationship,
 			&doctorProfile.UserId, &doctorProfile.Name, &doctorProfile.ProfilePhoto,
 			&message.Id, &message.SessionId, &message.SenderId, &message.MessageType, &message.Message, &message.Attachment, &message.CreatedAt, &message.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
+		if err := decryptNullString(&message.Message); err != nil {
+			return nil, err
+		}
 		session.ConsultationSessionStatus = &sessionStatus
 		session.UserProfile = &userProfile
+		session.Dependent = dependent.toDependent(session.DependentId, session.UserId)
 		session.DoctorProfile = &doctorProfile
 		if message.Id.Valid {
 			messages = append(messages, &message)
@@ -138,22 +161,25 @@ func (repo *ConsultationSessionRepositoryImpl) FindByIdJoinAll(ctx context.Conte
 	return &session, err
 }
 
-func (repo *ConsultationSessionRepositoryImpl) FindByUserIdAndDoctorId(ctx context.Context, userId, doctorId int64) (*entity.ConsultationSession, error) {
-	const findByUserIdAndDoctorId = `
-	SELECT consultation_sessions.id, user_id, doctor_id, consultation_session_status_id, 
-	       consultation_sessions.created_at, consultation_sessions.updated_at,
+// FindByPatientAndDoctorId returns the latest session of the patient with the doctor, a NULL dependentId means the
+// account holder is the patient
+func (repo *ConsultationSessionRepositoryImpl) FindByPatientAndDoctorId(ctx context.Context, userId int64, dependentId sql.NullInt64, doctorId int64) (*entity.ConsultationSession, error) {
+	const findByPatientAndDoctorId = `
+	SELECT consultation_sessions.id, user_id, dependent_id, doctor_id, consultation_session_status_id, 
+	       consultation_sessions.expires_at, consultation_sessions.created_at, consultation_sessions.updated_at,
 	       consultation_session_statuses.name
 	FROM consultation_sessions
 	INNER JOIN consultation_session_statuses ON consultation_sessions.consultation_session_status_id = consultation_session_statuses.id 
-	WHERE consultation_sessions.user_id = $1 AND consultation_sessions.doctor_id = $2
+	WHERE consultation_sessions.user_id = $1 AND consultation_sessions.dependent_id IS NOT DISTINCT FROM $2
+	  AND consultation_sessions.doctor_id = $3
 	ORDER BY created_at DESC LIMIT 1`
 
-	row := repo.db.QueryRowContext(ctx, findByUserIdAndDoctorId, userId, doctorId)
+	row := repo.db.QueryRowContext(ctx, findByPatientAndDoctorId, userId, dependentId, doctorId)
 	var session entity.ConsultationSession
 	var sessionStatus entity.ConsultationSessionStatus
 	err := row.Scan(
-		&session.Id, &session.UserId, &session.DoctorId, &session.ConsultationSessionStatusId,
-		&session.CreatedAt, &session.UpdatedAt,
+		&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+		&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 		&sessionStatus.Name,
 	)
 	session.ConsultationSessionStatus = &sessionStatus
@@ -167,12 +193,46 @@ func (repo *ConsultationSessionRepositoryImpl) FindByUserIdAndDoctorId(ctx conte
 	return &session, err
 }
 
+// FindAllByPatient returns every session of a single patient oldest first, a NULL dependentId means the account holder
+// is the patient
+func (repo *ConsultationSessionRepositoryImpl) FindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.ConsultationSession, error) {
+	const findAllByPatient = `
+	SELECT id, user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at, created_at, updated_at
+	FROM consultation_sessions
+	WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL
+	ORDER BY created_at ASC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByPatient, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*entity.ConsultationSession, 0)
+	for rows.Next() {
+		var session entity.ConsultationSession
+		if err := rows.Scan(
+			&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+			&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
 func (repo *ConsultationSessionRepositoryImpl) FindAllByUserIdOrDoctorId(ctx context.Context, userIdOrDoctorId int64, param *queryparamdto.GetAllParams) ([]*entity.ConsultationSession, error) {
 	initQuery := `
-	SELECT consultation_sessions.id, consultation_sessions.user_id, doctor_id, consultation_session_status_id,
-    consultation_sessions.created_at, consultation_sessions.updated_at,
+	SELECT consultation_sessions.id, consultation_sessions.user_id, consultation_sessions.dependent_id, doctor_id, consultation_session_status_id,
+    consultation_sessions.expires_at, consultation_sessions.created_at, consultation_sessions.updated_at,
     consultation_session_statuses.name AS session_status,
     user_profiles.user_id, user_profiles.name, user_profiles.profile_photo,
+    dependents.name, dependents.date_of_birth, dependents.relationship,
     doctor_profiles.user_id, doctor_profiles.name, doctor_profiles.profile_photo,
     cm.id, cm.session_id, cm.sender_id, cm.message_type, cm.message, cm.attachment, cm.created_at AS message_created_at,
     cm.updated_at AS message_updated_at
@@ -180,6 +240,7 @@ func (repo *ConsultationSessionRepositoryImpl) FindAllByUserIdOrDoctorId(ctx con
 	INNER JOIN consultation_session_statuses ON consultation_sessions.consultation_session_status_id = consultation_session_statuses.id
 	INNER JOIN user_profiles ON consultation_sessions.user_id = user_profiles.user_id
 	INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
+	LEFT JOIN dependents ON consultation_sessions.dependent_id = dependents.id
 	LEFT JOIN LATERAL (
 		SELECT id, session_id, sender_id, message_type, message, attachment, created_at, updated_at
 		FROM consultation_messages
@@ -207,21 +268,27 @@ func (repo *ConsultationSessionRepositoryImpl) FindAllByUserIdOrDoctorId(ctx con
 			session       entity.ConsultationSession
 			sessionStatus entity.ConsultationSessionStatus
 			userProfile   entity.UserProfile
+			dependent     nullableDependent
 			doctorProfile entity.DoctorProfile
 			message       entity.ConsultationMessage
 		)
 		if err := rows.Scan(
-			&session.Id, &session.UserId, &session.DoctorId, &session.ConsultationSessionStatusId,
-			&session.CreatedAt, &session.UpdatedAt,
+			&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+			&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 			&sessionStatus.Name,
 			&userProfile.UserId, &userProfile.Name, &userProfile.ProfilePhoto,
+			&dependent.Name, &dependent.DateOfBirth, &dependent.Relationship,
 			&doctorProfile.UserId, &doctorProfile.Name, &doctorProfile.ProfilePhoto,
 			&message.Id, &message.SessionId, &message.SenderId, &message.MessageType, &message.Message, &message.Attachment, &message.CreatedAt, &message.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
+		if err := decryptNullString(&message.Message); err != nil {
+			return nil, err
+		}
 		session.ConsultationSessionStatus = &sessionStatus
 		session.UserProfile = &userProfile
+		session.Dependent = dependent.toDependent(session.DependentId, session.UserId)
 		session.DoctorProfile = &doctorProfile
 		session.Message = make([]*entity.ConsultationMessage, 0)
 		if message.Id.Valid {
@@ -285,17 +352,60 @@ func (repo *ConsultationSessionRepositoryImpl) CountFindAllByUserIdOrDoctorId(ct
 	return totalItems, nil
 }
 
+// FindAllExpired skips sessions with an extension waiting for the doctor, the patient already paid for more time
+func (repo *ConsultationSessionRepositoryImpl) FindAllExpired(ctx context.Context, now time.Time) ([]*entity.ConsultationSession, error) {
+	const findAllExpired = `
+	SELECT consultation_sessions.id, consultation_sessions.user_id, consultation_sessions.dependent_id, doctor_id, consultation_session_status_id,
+	       consultation_sessions.expires_at, consultation_sessions.created_at, consultation_sessions.updated_at
+	FROM consultation_sessions
+	WHERE consultation_sessions.deleted_at IS NULL AND consultation_session_status_id = $1
+	  AND consultation_sessions.expires_at <= $2
+	  AND NOT EXISTS (
+		SELECT 1 FROM consultation_session_extensions
+		WHERE consultation_session_extensions.session_id = consultation_sessions.id AND consultation_session_extensions.status = $3
+		  AND consultation_session_extensions.created_at > $4
+	  )
+	ORDER BY consultation_sessions.expires_at`
+
+	rows, err := repo.db.QueryContext(
+		ctx, findAllExpired, appconstant.ConsultationSessionStatusOngoing, now,
+		appconstant.ConsultationSessionExtensionStatusWaiting, now.Add(-appconstant.ConsultationSessionExtensionWaitingWindow),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*entity.ConsultationSession, 0)
+	for rows.Next() {
+		var session entity.ConsultationSession
+		if err := rows.Scan(
+			&session.Id, &session.UserId, &session.DependentId, &session.DoctorId, &session.ConsultationSessionStatusId,
+			&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
 func (repo *ConsultationSessionRepositoryImpl) Update(ctx context.Context, session entity.ConsultationSession) (*entity.ConsultationSession, error) {
 	const update = `
 	UPDATE consultation_sessions
 	SET consultation_session_status_id = $1, updated_at = now()
 	WHERE id = $2
-	RETURNING id, user_id, doctor_id, consultation_session_status_id, created_at, updated_at`
+	RETURNING id, user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at, created_at, updated_at`
 
 	row := repo.db.QueryRowContext(ctx, update, session.ConsultationSessionStatusId, session.Id)
 	var updated entity.ConsultationSession
 	err := row.Scan(
-		&updated.Id, &updated.UserId, &updated.DoctorId, &updated.ConsultationSessionStatusId, &updated.CreatedAt, &updated.UpdatedAt,
+		&updated.Id, &updated.UserId, &updated.DependentId, &updated.DoctorId, &updated.ConsultationSessionStatusId,
+		&updated.ExpiresAt, &updated.CreatedAt, &updated.UpdatedAt,
 	)
 	return &updated, err
 }
@@ -1,16 +1,20 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"halodeksik-be/app/appconstant"
 	"strings"
+	"time"
 )
 
 type CronRepository interface {
 	ValidateTransactions() error
 	ValidateOrders() error
 	ValidateOrdersConfirmed() error
+	RejectStaleSessionExtensions() error
+	FailStaleProductImports() error
 }
 
 type CronRepoImpl struct {
@@ -57,6 +61,11 @@ func (repo CronRepoImpl) ValidateOrders() error {
 		return err
 	}
 
+	err = releasePrescriptionProducts(context.Background(), tx, orderIds)
+	if err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
@@ -108,14 +117,52 @@ func (repo CronRepoImpl) ValidateOrdersConfirmed() error {
 }
 
 func (repo CronRepoImpl) ValidateTransactions() error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const findExpiredOrderIds = `SELECT orders.id FROM orders
+	INNER JOIN transactions ON orders.transaction_id = transactions.id
+	WHERE (transactions.date::date + INTERVAL '4 day') <= now() AND transactions.transaction_status_id NOT IN (4, 5)`
+
+	rows, err := tx.Query(findExpiredOrderIds)
+	if err != nil {
+		return err
+	}
+	var orderIds []int64
+	for rows.Next() {
+		var orderId int64
+
+		if err := rows.Scan(
+			&orderId,
+		); err != nil {
+			rows.Close()
+			return err
+		}
+
+		orderIds = append(orderIds, orderId)
+	}
+	rows.Close()
+
+	err = releasePrescriptionProducts(context.Background(), tx, orderIds)
+	if err != nil {
+		return err
+	}
+
 	const setExpiredStatus = `UPDATE transactions SET transaction_status_id = 5
 	WHERE (date::date + INTERVAL '4 day') <= now() AND transaction_status_id != 4`
 
-	_, err := repo.db.Exec(setExpiredStatus)
+	_, err = tx.Exec(setExpiredStatus)
 	if err != nil {
 		return err
 	}
 
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -146,3 +193,31 @@ func (repo CronRepoImpl) bulkInsertStatus(tx *sql.Tx, orderIds []int64, isConfir
 	_, err := tx.Exec(stmt, valueArgs...)
 	return err
 }
+
+// RejectStaleSessionExtensions rejects the extension requests the doctor left waiting past the waiting window, so they
+// stop keeping expired sessions open and can no longer be accepted
+func (repo CronRepoImpl) RejectStaleSessionExtensions() error {
+	const rejectStale = `UPDATE consultation_session_extensions
+	SET status = $1, updated_at = now()
+	WHERE status = $2 AND created_at <= $3`
+
+	_, err := repo.db.Exec(
+		rejectStale, appconstant.ConsultationSessionExtensionStatusRejected, appconstant.ConsultationSessionExtensionStatusWaiting,
+		time.Now().Add(-appconstant.ConsultationSessionExtensionWaitingWindow),
+	)
+	return err
+}
+
+// FailStaleProductImports marks the imports that stopped making progress as failed, their goroutine is gone once the
+// server restarted so they would stay processing forever
+func (repo CronRepoImpl) FailStaleProductImports() error {
+	const failStale = `UPDATE product_imports
+	SET status = $1, error_message = $2, updated_at = now()
+	WHERE status = $3 AND updated_at <= $4`
+
+	_, err := repo.db.Exec(
+		failStale, appconstant.ProductImportStatusFailed, appconstant.ProductImportStaleErrorMessage,
+		appconstant.ProductImportStatusProcessing, time.Now().Add(-appconstant.ProductImportStaleAfter),
+	)
+	return err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type DependentRepository interface {
+	Create(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error)
+	FindById(ctx context.Context, id int64) (*entity.Dependent, error)
+	FindAllByUserId(ctx context.Context, userId int64) ([]*entity.Dependent, error)
+	Update(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type DependentRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDependentRepositoryImpl(db *sql.DB) *DependentRepositoryImpl {
+	return &DependentRepositoryImpl{db: db}
+}
+
+func (repo *DependentRepositoryImpl) Create(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error) {
+	const create = `
+	INSERT INTO dependents(user_id, name, date_of_birth, relationship)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, user_id, name, date_of_birth, relationship, created_at, updated_at, deleted_at`
+
+	row := repo.db.QueryRowContext(ctx, create, dependent.UserId, dependent.Name, dependent.DateOfBirth, dependent.Relationship)
+	var created entity.Dependent
+	err := row.Scan(
+		&created.Id, &created.UserId, &created.Name, &created.DateOfBirth, &created.Relationship,
+		&created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+	)
+	return &created, err
+}
+
+func (repo *DependentRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Dependent, error) {
+	const findById = `SELECT id, user_id, name, date_of_birth, relationship, created_at, updated_at, deleted_at
+	FROM dependents WHERE id = $1 AND deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findById, id)
+	var dependent entity.Dependent
+	err := row.Scan(
+		&dependent.Id, &dependent.UserId, &dependent.Name, &dependent.DateOfBirth, &dependent.Relationship,
+		&dependent.CreatedAt, &dependent.UpdatedAt, &dependent.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &dependent, nil
+}
+
+func (repo *DependentRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64) ([]*entity.Dependent, error) {
+	const findAllByUserId = `SELECT id, user_id, name, date_of_birth, relationship, created_at, updated_at, deleted_at
+	FROM dependents WHERE user_id = $1 AND deleted_at IS NULL ORDER BY id`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.Dependent, 0)
+	for rows.Next() {
+		var dependent entity.Dependent
+		if err := rows.Scan(
+			&dependent.Id, &dependent.UserId, &dependent.Name, &dependent.DateOfBirth, &dependent.Relationship,
+			&dependent.CreatedAt, &dependent.UpdatedAt, &dependent.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &dependent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *DependentRepositoryImpl) Update(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error) {
+	const update = `UPDATE dependents
+	SET name = $1, date_of_birth = $2, relationship = $3, updated_at = now()
+	WHERE id = $4 AND deleted_at IS NULL
+	RETURNING id, user_id, name, date_of_birth, relationship, created_at, updated_at, deleted_at`
+
+	row := repo.db.QueryRowContext(ctx, update, dependent.Name, dependent.DateOfBirth, dependent.Relationship, dependent.Id)
+	var updated entity.Dependent
+	err := row.Scan(
+		&updated.Id, &updated.UserId, &updated.Name, &updated.DateOfBirth, &updated.Relationship,
+		&updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+	)
+	return &updated, err
+}
+
+func (repo *DependentRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	const deleteQ = `UPDATE dependents SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := repo.db.ExecContext(ctx, deleteQ, id)
+	return err
+}
+
+// nullableDependent scans a LEFT JOINed dependent, the columns are NULL when the account holder is the patient
+type nullableDependent struct {
+	Name         sql.NullString
+	DateOfBirth  sql.NullTime
+	Relationship sql.NullString
+}
+
+func (d *nullableDependent) toDependent(dependentId sql.NullInt64, userId int64) *entity.Dependent {
+	if !dependentId.Valid {
+		return nil
+	}
+	return &entity.Dependent{
+		Id:           dependentId.Int64,
+		UserId:       userId,
+		Name:         d.Name.String,
+		DateOfBirth:  d.DateOfBirth.Time,
+		Relationship: d.Relationship.String,
+	}
+}
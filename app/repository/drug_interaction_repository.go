@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+)
+
+type DrugInteractionRepository interface {
+	Create(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error)
+	FindById(ctx context.Context, id int64) (*entity.DrugInteraction, error)
+	FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.DrugInteraction, error)
+	CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
+	FindAllByGenericNames(ctx context.Context, genericNames []string) ([]*entity.DrugInteraction, error)
+	Update(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error)
+	Upsert(ctx context.Context, interactions []*entity.DrugInteraction) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type DrugInteractionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDrugInteractionRepositoryImpl(db *sql.DB) *DrugInteractionRepositoryImpl {
+	return &DrugInteractionRepositoryImpl{db: db}
+}
+
+func (repo *DrugInteractionRepositoryImpl) Create(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error) {
+	const create = `
+	INSERT INTO drug_interactions(generic_name_a, generic_name_b, drug_interaction_severity_id, description)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, generic_name_a, generic_name_b, drug_interaction_severity_id, description, created_at, updated_at, deleted_at`
+
+	row := repo.db.QueryRowContext(ctx, create,
+		interaction.GenericNameA, interaction.GenericNameB, interaction.DrugInteractionSeverityId, interaction.Description,
+	)
+	if row.Err() != nil {
+		var errPgConn *pgconn.PgError
+		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+			return nil, apperror.ErrDrugInteractionUniqueConstraint
+		}
+		return nil, row.Err()
+	}
+
+	var created entity.DrugInteraction
+	err := row.Scan(
+		&created.Id, &created.GenericNameA, &created.GenericNameB, &created.DrugInteractionSeverityId, &created.Description,
+		&created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+	)
+	return &created, err
+}
+
+func (repo *DrugInteractionRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.DrugInteraction, error) {
+	const findById = `SELECT id, generic_name_a, generic_name_b, drug_interaction_severity_id, description, created_at, updated_at, deleted_at
+	FROM drug_interactions WHERE id = $1 AND deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findById, id)
+	var interaction entity.DrugInteraction
+	err := row.Scan(
+		&interaction.Id, &interaction.GenericNameA, &interaction.GenericNameB, &interaction.DrugInteractionSeverityId, &interaction.Description,
+		&interaction.CreatedAt, &interaction.UpdatedAt, &interaction.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &interaction, nil
+}
+
+func (repo *DrugInteractionRepositoryImpl) FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.DrugInteraction, error) {
+	initQuery := `SELECT id, generic_name_a, generic_name_b, drug_interaction_severity_id, description
+	FROM drug_interactions WHERE deleted_at IS NULL `
+	query, values := buildQuery(initQuery, &entity.DrugInteraction{}, param, true, true)
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.DrugInteraction, 0)
+	for rows.Next() {
+		var interaction entity.DrugInteraction
+		if err := rows.Scan(
+			&interaction.Id, &interaction.GenericNameA, &interaction.GenericNameB, &interaction.DrugInteractionSeverityId, &interaction.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &interaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *DrugInteractionRepositoryImpl) CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error) {
+	initQuery := `SELECT count(id) FROM drug_interactions WHERE deleted_at IS NULL `
+	query, values := buildQuery(initQuery, &entity.DrugInteraction{}, param, false, false)
+
+	var totalItems int64
+	row := repo.db.QueryRowContext(ctx, query, values...)
+	if err := row.Scan(&totalItems); err != nil {
+		return totalItems, err
+	}
+	return totalItems, nil
+}
+
+func (repo *DrugInteractionRepositoryImpl) FindAllByGenericNames(ctx context.Context, genericNames []string) ([]*entity.DrugInteraction, error) {
+	const findAllByGenericNames = `SELECT id, generic_name_a, generic_name_b, drug_interaction_severity_id, description
+	FROM drug_interactions
+	WHERE generic_name_a = ANY ($1::varchar[]) AND generic_name_b = ANY ($1::varchar[]) AND deleted_at IS NULL
+	ORDER BY drug_interaction_severity_id DESC, id ASC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByGenericNames, pq.Array(genericNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.DrugInteraction, 0)
+	for rows.Next() {
+		var interaction entity.DrugInteraction
+		if err := rows.Scan(
+			&interaction.Id, &interaction.GenericNameA, &interaction.GenericNameB, &interaction.DrugInteractionSeverityId, &interaction.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &interaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *DrugInteractionRepositoryImpl) Update(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error) {
+	const update = `UPDATE drug_interactions
+	SET generic_name_a = $1, generic_name_b = $2, drug_interaction_severity_id = $3, description = $4, updated_at = now()
+	WHERE id = $5 AND deleted_at IS NULL
+	RETURNING id, generic_name_a, generic_name_b, drug_interaction_severity_id, description, created_at, updated_at, deleted_at`
+
+	row := repo.db.QueryRowContext(ctx, update,
+		interaction.GenericNameA, interaction.GenericNameB, interaction.DrugInteractionSeverityId, interaction.Description, interaction.Id,
+	)
+	if row.Err() != nil {
+		var errPgConn *pgconn.PgError
+		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+			return nil, apperror.ErrDrugInteractionUniqueConstraint
+		}
+		return nil, row.Err()
+	}
+
+	var updated entity.DrugInteraction
+	err := row.Scan(
+		&updated.Id, &updated.GenericNameA, &updated.GenericNameB, &updated.DrugInteractionSeverityId, &updated.Description,
+		&updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+	)
+	return &updated, err
+}
+
+func (repo *DrugInteractionRepositoryImpl) Upsert(ctx context.Context, interactions []*entity.DrugInteraction) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const upsert = `
+	INSERT INTO drug_interactions(generic_name_a, generic_name_b, drug_interaction_severity_id, description)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (generic_name_a, generic_name_b) WHERE deleted_at IS NULL
+	DO UPDATE SET drug_interaction_severity_id = excluded.drug_interaction_severity_id, description = excluded.description, updated_at = now()`
+
+	for _, interaction := range interactions {
+		_, err = tx.ExecContext(ctx, upsert,
+			interaction.GenericNameA, interaction.GenericNameB, interaction.DrugInteractionSeverityId, interaction.Description,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *DrugInteractionRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	const deleteQ = `UPDATE drug_interactions SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := repo.db.ExecContext(ctx, deleteQ, id)
+	return err
+}
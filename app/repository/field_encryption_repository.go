@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appcrypto"
+)
+
+type FieldEncryptionRepository interface {
+	ReEncryptColumn(ctx context.Context, table string, column string, batchSize int) (int64, error)
+}
+
+type FieldEncryptionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewFieldEncryptionRepositoryImpl(db *sql.DB) *FieldEncryptionRepositoryImpl {
+	return &FieldEncryptionRepositoryImpl{db: db}
+}
+
+// ReEncryptColumn walks the table by id in batches and rewrites every value that is still plaintext or encrypted
+// with a rotated key, table and column must come from appconstant.EncryptedColumns as they are not parameterized
+func (repo *FieldEncryptionRepositoryImpl) ReEncryptColumn(ctx context.Context, table string, column string, batchSize int) (int64, error) {
+	var (
+		lastId      int64
+		reEncrypted int64
+	)
+
+	for {
+		lastIdInBatch, updated, err := repo.reEncryptBatch(ctx, table, column, lastId, batchSize)
+		if err != nil {
+			return reEncrypted, err
+		}
+		reEncrypted += updated
+		if lastIdInBatch == lastId {
+			return reEncrypted, nil
+		}
+		lastId = lastIdInBatch
+	}
+}
+
+func (repo *FieldEncryptionRepositoryImpl) reEncryptBatch(ctx context.Context, table string, column string, afterId int64, batchSize int) (int64, int64, error) {
+	findBatch := fmt.Sprintf(`SELECT id, %s FROM %s WHERE id > $1 AND %s IS NOT NULL ORDER BY id LIMIT $2 FOR UPDATE`, column, table, column)
+	update := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, table, column)
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return afterId, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, findBatch, afterId, batchSize)
+	if err != nil {
+		return afterId, 0, err
+	}
+
+	lastId := afterId
+	values := make(map[int64]string)
+	for rows.Next() {
+		var (
+			id    int64
+			value string
+		)
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return afterId, 0, err
+		}
+		lastId = id
+		if !appcrypto.AppFieldEncryptor.IsEncryptedWithActiveKey(value) {
+			values[id] = value
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return afterId, 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return afterId, 0, err
+	}
+
+	var updated int64
+	for id, value := range values {
+		if err = decryptFields(&value); err != nil {
+			return afterId, 0, fmt.Errorf("%s.%s id %d: %w", table, column, id, err)
+		}
+		if err = encryptFields(&value); err != nil {
+			return afterId, 0, err
+		}
+		if _, err = tx.ExecContext(ctx, update, value, id); err != nil {
+			return afterId, 0, err
+		}
+		updated++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return afterId, 0, err
+	}
+	return lastId, updated, nil
+}
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appcrypto"
 	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
@@ -130,3 +132,41 @@ func buildQuery(initQuery string, resourcer entity.Resourcer, param *queryparamd
 
 	return query.String(), values
 }
+
+// encryptFields encrypts clinical data in place before it is written to the database
+func encryptFields(values ...*string) error {
+	for _, value := range values {
+		encrypted, err := appcrypto.AppFieldEncryptor.Encrypt(*value)
+		if err != nil {
+			return err
+		}
+		*value = encrypted
+	}
+	return nil
+}
+
+// decryptFields decrypts clinical data in place after it is scanned from the database
+func decryptFields(values ...*string) error {
+	for _, value := range values {
+		decrypted, err := appcrypto.AppFieldEncryptor.Decrypt(*value)
+		if err != nil {
+			return err
+		}
+		*value = decrypted
+	}
+	return nil
+}
+
+func encryptNullString(value *sql.NullString) error {
+	if !value.Valid {
+		return nil
+	}
+	return encryptFields(&value.String)
+}
+
+func decryptNullString(value *sql.NullString) error {
+	if !value.Valid {
+		return nil
+	}
+	return decryptFields(&value.String)
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type MedicalProfileRepository interface {
+	FindByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) (*entity.MedicalProfile, error)
+	Upsert(ctx context.Context, profile entity.MedicalProfile) (*entity.MedicalProfile, error)
+	CreateMeasurement(ctx context.Context, measurement entity.MedicalProfileMeasurement) (*entity.MedicalProfileMeasurement, error)
+}
+
+type MedicalProfileRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewMedicalProfileRepositoryImpl(db *sql.DB) *MedicalProfileRepositoryImpl {
+	return &MedicalProfileRepositoryImpl{db: db}
+}
+
+// FindByPatient finds the profile of the account holder when dependentId is NULL, otherwise the dependent's profile
+func (repo *MedicalProfileRepositoryImpl) FindByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) (*entity.MedicalProfile, error) {
+	const findByPatient = `SELECT user_id, dependent_id, blood_type, created_at, updated_at, deleted_at
+	FROM medical_profiles WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findByPatient, userId, dependentId)
+	var profile entity.MedicalProfile
+	err := row.Scan(&profile.UserId, &profile.DependentId, &profile.BloodType, &profile.CreatedAt, &profile.UpdatedAt, &profile.DeletedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	profile.Allergies, err = repo.findAllAllergiesByPatient(ctx, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Conditions, err = repo.findAllConditionsByPatient(ctx, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Medications, err = repo.findAllMedicationsByPatient(ctx, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Measurements, err = repo.findAllMeasurementsByPatient(ctx, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (repo *MedicalProfileRepositoryImpl) Upsert(ctx context.Context, profile entity.MedicalProfile) (*entity.MedicalProfile, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	const upsertProfile = `
+	INSERT INTO medical_profiles(user_id, dependent_id, blood_type)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, COALESCE(dependent_id, 0)) DO UPDATE SET blood_type = excluded.blood_type, updated_at = now(), deleted_at = NULL`
+
+	_, err = tx.ExecContext(ctx, upsertProfile, profile.UserId, profile.DependentId, profile.BloodType)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteChildren := []string{
+		`UPDATE medical_profile_allergies SET deleted_at = now() WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL`,
+		`UPDATE medical_profile_conditions SET deleted_at = now() WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL`,
+		`UPDATE medical_profile_medications SET deleted_at = now() WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL`,
+	}
+	for _, deleteChild := range deleteChildren {
+		if _, err = tx.ExecContext(ctx, deleteChild, profile.UserId, profile.DependentId); err != nil {
+			return nil, err
+		}
+	}
+
+	const createAllergy = `INSERT INTO medical_profile_allergies(user_id, dependent_id, allergen, generic_name, reaction) VALUES ($1, $2, $3, $4, $5)`
+	for _, allergy := range profile.Allergies {
+		_, err = tx.ExecContext(ctx, createAllergy, profile.UserId, profile.DependentId, allergy.Allergen, allergy.GenericName, allergy.Reaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	const createCondition = `INSERT INTO medical_profile_conditions(user_id, dependent_id, name, diagnosed_at, note) VALUES ($1, $2, $3, $4, $5)`
+	for _, condition := range profile.Conditions {
+		_, err = tx.ExecContext(ctx, createCondition, profile.UserId, profile.DependentId, condition.Name, condition.DiagnosedAt, condition.Note)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	const createMedication = `INSERT INTO medical_profile_medications(user_id, dependent_id, name, generic_name, dosage) VALUES ($1, $2, $3, $4, $5)`
+	for _, medication := range profile.Medications {
+		_, err = tx.ExecContext(ctx, createMedication, profile.UserId, profile.DependentId, medication.Name, medication.GenericName, medication.Dosage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return repo.FindByPatient(ctx, profile.UserId, profile.DependentId)
+}
+
+func (repo *MedicalProfileRepositoryImpl) CreateMeasurement(ctx context.Context, measurement entity.MedicalProfileMeasurement) (*entity.MedicalProfileMeasurement, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	const createProfileIfNotExist = `INSERT INTO medical_profiles(user_id, dependent_id) VALUES ($1, $2)
+	ON CONFLICT (user_id, COALESCE(dependent_id, 0)) DO NOTHING`
+
+	_, err = tx.ExecContext(ctx, createProfileIfNotExist, measurement.UserId, measurement.DependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	const createMeasurement = `
+	INSERT INTO medical_profile_measurements(user_id, dependent_id, height_cm, weight_kg, measured_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, user_id, dependent_id, height_cm, weight_kg, measured_at, created_at, updated_at, deleted_at`
+
+	row := tx.QueryRowContext(ctx, createMeasurement, measurement.UserId, measurement.DependentId, measurement.HeightCm, measurement.WeightKg, measurement.MeasuredAt)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var created entity.MedicalProfileMeasurement
+	err = row.Scan(
+		&created.Id, &created.UserId, &created.DependentId, &created.HeightCm, &created.WeightKg, &created.MeasuredAt,
+		&created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (repo *MedicalProfileRepositoryImpl) findAllAllergiesByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.MedicalProfileAllergy, error) {
+	const findAll = `SELECT id, user_id, allergen, generic_name, reaction, created_at, updated_at, deleted_at
+	FROM medical_profile_allergies WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL ORDER BY id`
+
+	rows, err := repo.db.QueryContext(ctx, findAll, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicalProfileAllergy, 0)
+	for rows.Next() {
+		var allergy entity.MedicalProfileAllergy
+		if err := rows.Scan(
+			&allergy.Id, &allergy.UserId, &allergy.Allergen, &allergy.GenericName, &allergy.Reaction,
+			&allergy.CreatedAt, &allergy.UpdatedAt, &allergy.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &allergy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicalProfileRepositoryImpl) findAllConditionsByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.MedicalProfileCondition, error) {
+	const findAll = `SELECT id, user_id, name, diagnosed_at, note, created_at, updated_at, deleted_at
+	FROM medical_profile_conditions WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL ORDER BY id`
+
+	rows, err := repo.db.QueryContext(ctx, findAll, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicalProfileCondition, 0)
+	for rows.Next() {
+		var condition entity.MedicalProfileCondition
+		if err := rows.Scan(
+			&condition.Id, &condition.UserId, &condition.Name, &condition.DiagnosedAt, &condition.Note,
+			&condition.CreatedAt, &condition.UpdatedAt, &condition.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &condition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicalProfileRepositoryImpl) findAllMedicationsByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.MedicalProfileMedication, error) {
+	const findAll = `SELECT id, user_id, name, generic_name, dosage, created_at, updated_at, deleted_at
+	FROM medical_profile_medications WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL ORDER BY id`
+
+	rows, err := repo.db.QueryContext(ctx, findAll, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicalProfileMedication, 0)
+	for rows.Next() {
+		var medication entity.MedicalProfileMedication
+		if err := rows.Scan(
+			&medication.Id, &medication.UserId, &medication.Name, &medication.GenericName, &medication.Dosage,
+			&medication.CreatedAt, &medication.UpdatedAt, &medication.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &medication)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicalProfileRepositoryImpl) findAllMeasurementsByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) ([]*entity.MedicalProfileMeasurement, error) {
+	const findAll = `SELECT id, user_id, height_cm, weight_kg, measured_at, created_at, updated_at, deleted_at
+	FROM medical_profile_measurements WHERE user_id = $1 AND dependent_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL ORDER BY measured_at DESC, id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAll, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicalProfileMeasurement, 0)
+	for rows.Next() {
+		var measurement entity.MedicalProfileMeasurement
+		if err := rows.Scan(
+			&measurement.Id, &measurement.UserId, &measurement.HeightCm, &measurement.WeightKg, &measurement.MeasuredAt,
+			&measurement.CreatedAt, &measurement.UpdatedAt, &measurement.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &measurement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/util"
+)
+
+type MedicalRecordRepository interface {
+	FindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64, param *queryparamdto.GetAllParams) ([]*entity.MedicalRecord, error)
+	CountFindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64, param *queryparamdto.GetAllParams) (int64, error)
+	FindConsentBySessionId(ctx context.Context, sessionId int64) (*entity.MedicalRecordConsent, error)
+	UpsertConsent(ctx context.Context, consent entity.MedicalRecordConsent) (*entity.MedicalRecordConsent, error)
+}
+
+type MedicalRecordRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewMedicalRecordRepositoryImpl(db *sql.DB) *MedicalRecordRepositoryImpl {
+	return &MedicalRecordRepositoryImpl{db: db}
+}
+
+// medicalRecordsSubQuery aggregates every record type into the medical_records columns so the timeline can be
+// filtered, sorted and paginated as a single table
+const medicalRecordsSubQuery = `
+	SELECT 'consultation' AS type, cs.id AS id, cs.id AS session_id, cs.user_id, cs.dependent_id, cs.doctor_id, dp.name AS doctor_name,
+	       cs.created_at AS occurred_at, ds.name AS title, css.name AS description
+	FROM consultation_sessions cs
+	         INNER JOIN doctor_profiles dp ON dp.user_id = cs.doctor_id
+	         INNER JOIN doctor_specializations ds ON ds.id = dp.doctor_specialization_id
+	         INNER JOIN consultation_session_statuses css ON css.id = cs.consultation_session_status_id
+	WHERE cs.deleted_at IS NULL
+	UNION ALL
+	SELECT 'prescription', p.id, cs.id, cs.user_id, cs.dependent_id, cs.doctor_id, dp.name,
+	       p.created_at, p.diagnosis, p.symptoms
+	FROM prescriptions p
+	         INNER JOIN consultation_sessions cs ON cs.id = p.session_id
+	         INNER JOIN doctor_profiles dp ON dp.user_id = cs.doctor_id
+	WHERE p.deleted_at IS NULL AND cs.deleted_at IS NULL
+	UNION ALL
+	SELECT 'sick_leave', slf.id, cs.id, cs.user_id, cs.dependent_id, cs.doctor_id, dp.name,
+	       slf.created_at, to_char(slf.starting_date, 'YYYY-MM-DD') || ' - ' || to_char(slf.ending_date, 'YYYY-MM-DD'), slf.description
+	FROM sick_leave_forms slf
+	         INNER JOIN consultation_sessions cs ON cs.id = slf.session_id
+	         INNER JOIN doctor_profiles dp ON dp.user_id = cs.doctor_id
+	WHERE slf.deleted_at IS NULL AND cs.deleted_at IS NULL
+	UNION ALL
+	SELECT 'order', o.id, cs.id, cs.user_id, cs.dependent_id, cs.doctor_id, dp.name,
+	       o.date, string_agg(DISTINCT od.name, ', '), os.name
+	FROM orders o
+	         INNER JOIN order_details od ON od.order_id = o.id
+	         INNER JOIN prescription_products pp ON pp.id = od.prescription_product_id
+	         INNER JOIN prescriptions p ON p.id = pp.prescription_id
+	         INNER JOIN consultation_sessions cs ON cs.id = p.session_id
+	         INNER JOIN doctor_profiles dp ON dp.user_id = cs.doctor_id
+	         INNER JOIN order_status_logs osl ON osl.order_id = o.id AND osl.is_latest = true
+	         INNER JOIN order_statuses os ON os.id = osl.order_status_id
+	WHERE o.deleted_at IS NULL
+	GROUP BY o.id, cs.id, dp.name, os.name`
+
+// FindAllByPatient returns the timeline of a single patient, a NULL dependentId means the account holder is the patient
+func (repo *MedicalRecordRepositoryImpl) FindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64, param *queryparamdto.GetAllParams) ([]*entity.MedicalRecord, error) {
+	initQuery := `
+	SELECT medical_records.type, medical_records.id, medical_records.session_id, medical_records.user_id, medical_records.dependent_id,
+	       medical_records.doctor_id, medical_records.doctor_name, medical_records.occurred_at, medical_records.title,
+	       medical_records.description
+	FROM (` + medicalRecordsSubQuery + `) medical_records
+	WHERE medical_records.user_id = $1 AND medical_records.dependent_id IS NOT DISTINCT FROM $2 `
+	indexPreparedStatement := 2
+
+	query, values := buildQuery(initQuery, &entity.MedicalRecord{}, param, true, true, indexPreparedStatement)
+	values = util.AppendAtIndex(values, 0, interface{}(dependentId))
+	values = util.AppendAtIndex(values, 0, interface{}(userId))
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicalRecord, 0)
+	for rows.Next() {
+		var record entity.MedicalRecord
+		if err := rows.Scan(
+			&record.Type, &record.Id, &record.SessionId, &record.UserId, &record.DependentId, &record.DoctorId,
+			&record.DoctorName, &record.OccurredAt, &record.Title, &record.Description,
+		); err != nil {
+			return nil, err
+		}
+		if err := decryptFields(&record.Title, &record.Description); err != nil {
+			return nil, err
+		}
+		items = append(items, &record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *MedicalRecordRepositoryImpl) CountFindAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64, param *queryparamdto.GetAllParams) (int64, error) {
+	initQuery := `SELECT count(*) FROM (` + medicalRecordsSubQuery + `) medical_records
+	WHERE medical_records.user_id = $1 AND medical_records.dependent_id IS NOT DISTINCT FROM $2 `
+	indexPreparedStatement := 2
+
+	query, values := buildQuery(initQuery, &entity.MedicalRecord{}, param, false, false, indexPreparedStatement)
+	values = util.AppendAtIndex(values, 0, interface{}(dependentId))
+	values = util.AppendAtIndex(values, 0, interface{}(userId))
+
+	var totalItems int64
+	row := repo.db.QueryRowContext(ctx, query, values...)
+	if err := row.Scan(&totalItems); err != nil {
+		return totalItems, err
+	}
+	return totalItems, nil
+}
+
+func (repo *MedicalRecordRepositoryImpl) FindConsentBySessionId(ctx context.Context, sessionId int64) (*entity.MedicalRecordConsent, error) {
+	const findBySessionId = `SELECT session_id, is_granted, created_at, updated_at
+	FROM medical_record_consents WHERE session_id = $1 AND deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findBySessionId, sessionId)
+	var consent entity.MedicalRecordConsent
+	err := row.Scan(&consent.SessionId, &consent.IsGranted, &consent.CreatedAt, &consent.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (repo *MedicalRecordRepositoryImpl) UpsertConsent(ctx context.Context, consent entity.MedicalRecordConsent) (*entity.MedicalRecordConsent, error) {
+	const upsert = `
+	INSERT INTO medical_record_consents(session_id, is_granted)
+	VALUES ($1, $2)
+	ON CONFLICT (session_id) DO UPDATE SET is_granted = excluded.is_granted, updated_at = now(), deleted_at = NULL
+	RETURNING session_id, is_granted, created_at, updated_at`
+
+	row := repo.db.QueryRowContext(ctx, upsert, consent.SessionId, consent.IsGranted)
+	var upserted entity.MedicalRecordConsent
+	err := row.Scan(&upserted.SessionId, &upserted.IsGranted, &upserted.CreatedAt, &upserted.UpdatedAt)
+	return &upserted, err
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"time"
+)
+
+type MedicationReminderRepository interface {
+	Create(ctx context.Context, reminder entity.MedicationReminder) (*entity.MedicationReminder, error)
+	FindById(ctx context.Context, id int64) (*entity.MedicationReminder, error)
+	FindAllByUserId(ctx context.Context, userId int64) ([]*entity.MedicationReminder, error)
+	Delete(ctx context.Context, id int64) error
+	FindAllDosesByReminderId(ctx context.Context, reminderId int64) ([]*entity.MedicationReminderDose, error)
+	FindDoseById(ctx context.Context, id int64) (*entity.MedicationReminderDose, error)
+	UpdateDoseStatus(ctx context.Context, dose entity.MedicationReminderDose) (*entity.MedicationReminderDose, error)
+	FindAllDueDoses(ctx context.Context, now time.Time) ([]*entity.MedicationReminderDose, error)
+	UpdateDoseNotified(ctx context.Context, id int64) error
+}
+
+type MedicationReminderRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewMedicationReminderRepositoryImpl(db *sql.DB) *MedicationReminderRepositoryImpl {
+	return &MedicationReminderRepositoryImpl{db: db}
+}
+
+func (repo *MedicationReminderRepositoryImpl) Create(ctx context.Context, reminder entity.MedicationReminder) (*entity.MedicationReminder, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(tx *sql.Tx) {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}(tx)
+
+	const createReminder = `
+	INSERT INTO medication_reminders(user_id, dependent_id, prescription_product_id, medication_name, instruction, times_of_day, start_date, duration_days)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, created_at, updated_at`
+
+	row := tx.QueryRowContext(ctx, createReminder,
+		reminder.UserId, reminder.DependentId, reminder.PrescriptionProductId, reminder.MedicationName,
+		reminder.Instruction, reminder.JoinTimesOfDay(), reminder.StartDate, reminder.DurationDays,
+	)
+	err = row.Scan(&reminder.Id, &reminder.CreatedAt, &reminder.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	const createDose = `
+	INSERT INTO medication_reminder_doses(medication_reminder_id, scheduled_at, status)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at`
+
+	for _, dose := range reminder.Doses {
+		dose.MedicationReminderId = reminder.Id
+		row = tx.QueryRowContext(ctx, createDose, dose.MedicationReminderId, dose.ScheduledAt, dose.Status)
+		err = row.Scan(&dose.Id, &dose.CreatedAt, &dose.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// findMedicationReminderWithAdherence aggregates the doses so listing reminders does not load every dose
+const findMedicationReminderWithAdherence = `
+	SELECT medication_reminders.id, user_id, dependent_id, prescription_product_id, medication_name, instruction,
+		   times_of_day, start_date, duration_days, medication_reminders.created_at, medication_reminders.updated_at,
+		   medication_reminders.deleted_at,
+		   count(medication_reminder_doses.id),
+		   count(medication_reminder_doses.id) FILTER (WHERE status <> $1 OR scheduled_at <= now()),
+		   count(medication_reminder_doses.id) FILTER (WHERE status = $2),
+		   count(medication_reminder_doses.id) FILTER (WHERE status = $3),
+		   count(medication_reminder_doses.id) FILTER (WHERE status = $1 AND scheduled_at <= now())
+	FROM medication_reminders
+		LEFT JOIN medication_reminder_doses ON medication_reminders.id = medication_reminder_doses.medication_reminder_id
+	`
+
+const groupMedicationReminderWithAdherence = ` GROUP BY medication_reminders.id `
+
+func (repo *MedicationReminderRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.MedicationReminder, error) {
+	const findById = findMedicationReminderWithAdherence +
+		`WHERE medication_reminders.id = $4 AND medication_reminders.deleted_at IS NULL` + groupMedicationReminderWithAdherence
+
+	row := repo.db.QueryRowContext(ctx, findById,
+		appconstant.MedicationReminderDoseStatusPending, appconstant.MedicationReminderDoseStatusTaken,
+		appconstant.MedicationReminderDoseStatusSkipped, id,
+	)
+	reminder, err := repo.scanReminderWithAdherence(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return reminder, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64) ([]*entity.MedicationReminder, error) {
+	const findAllByUserId = findMedicationReminderWithAdherence +
+		`WHERE medication_reminders.user_id = $4 AND medication_reminders.deleted_at IS NULL` + groupMedicationReminderWithAdherence +
+		`ORDER BY medication_reminders.start_date DESC, medication_reminders.id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByUserId,
+		appconstant.MedicationReminderDoseStatusPending, appconstant.MedicationReminderDoseStatusTaken,
+		appconstant.MedicationReminderDoseStatusSkipped, userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicationReminder, 0)
+	for rows.Next() {
+		reminder, err := repo.scanReminderWithAdherence(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) scanReminderWithAdherence(row interface{ Scan(...any) error }) (*entity.MedicationReminder, error) {
+	var (
+		reminder   entity.MedicationReminder
+		adherence  entity.MedicationReminderAdherence
+		timesOfDay string
+	)
+	err := row.Scan(
+		&reminder.Id, &reminder.UserId, &reminder.DependentId, &reminder.PrescriptionProductId, &reminder.MedicationName,
+		&reminder.Instruction, &timesOfDay, &reminder.StartDate, &reminder.DurationDays, &reminder.CreatedAt,
+		&reminder.UpdatedAt, &reminder.DeletedAt,
+		&adherence.TotalDoses, &adherence.DueDoses, &adherence.TakenDoses, &adherence.SkippedDoses, &adherence.MissedDoses,
+	)
+	if err != nil {
+		return nil, err
+	}
+	reminder.SplitTimesOfDay(timesOfDay)
+	reminder.Adherence = &adherence
+	return &reminder, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	const deleteQ = `UPDATE medication_reminders SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := repo.db.ExecContext(ctx, deleteQ, id)
+	return err
+}
+
+func (repo *MedicationReminderRepositoryImpl) FindAllDosesByReminderId(ctx context.Context, reminderId int64) ([]*entity.MedicationReminderDose, error) {
+	const findAllByReminderId = `SELECT id, medication_reminder_id, scheduled_at, status, logged_at, notified_at, created_at, updated_at
+	FROM medication_reminder_doses WHERE medication_reminder_id = $1 ORDER BY scheduled_at`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByReminderId, reminderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicationReminderDose, 0)
+	for rows.Next() {
+		var dose entity.MedicationReminderDose
+		if err := rows.Scan(
+			&dose.Id, &dose.MedicationReminderId, &dose.ScheduledAt, &dose.Status, &dose.LoggedAt, &dose.NotifiedAt,
+			&dose.CreatedAt, &dose.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &dose)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) FindDoseById(ctx context.Context, id int64) (*entity.MedicationReminderDose, error) {
+	const findDoseById = `SELECT medication_reminder_doses.id, medication_reminder_id, scheduled_at, status, logged_at, notified_at,
+		   medication_reminder_doses.created_at, medication_reminder_doses.updated_at, medication_reminders.user_id
+	FROM medication_reminder_doses
+		INNER JOIN medication_reminders ON medication_reminder_doses.medication_reminder_id = medication_reminders.id
+	WHERE medication_reminder_doses.id = $1 AND medication_reminders.deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findDoseById, id)
+	var (
+		dose     entity.MedicationReminderDose
+		reminder entity.MedicationReminder
+	)
+	err := row.Scan(
+		&dose.Id, &dose.MedicationReminderId, &dose.ScheduledAt, &dose.Status, &dose.LoggedAt, &dose.NotifiedAt,
+		&dose.CreatedAt, &dose.UpdatedAt, &reminder.UserId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	reminder.Id = dose.MedicationReminderId
+	dose.MedicationReminder = &reminder
+	return &dose, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) UpdateDoseStatus(ctx context.Context, dose entity.MedicationReminderDose) (*entity.MedicationReminderDose, error) {
+	const updateDoseStatus = `UPDATE medication_reminder_doses
+	SET status = $1, logged_at = now(), updated_at = now()
+	WHERE id = $2
+	RETURNING id, medication_reminder_id, scheduled_at, status, logged_at, notified_at, created_at, updated_at`
+
+	row := repo.db.QueryRowContext(ctx, updateDoseStatus, dose.Status, dose.Id)
+	var updated entity.MedicationReminderDose
+	err := row.Scan(
+		&updated.Id, &updated.MedicationReminderId, &updated.ScheduledAt, &updated.Status, &updated.LoggedAt,
+		&updated.NotifiedAt, &updated.CreatedAt, &updated.UpdatedAt,
+	)
+	return &updated, err
+}
+
+// FindAllDueDoses returns the pending doses that have not been notified yet and are not older than the notify window,
+// the reminder user carries the patient name and the account holder's email
+func (repo *MedicationReminderRepositoryImpl) FindAllDueDoses(ctx context.Context, now time.Time) ([]*entity.MedicationReminderDose, error) {
+	const findAllDueDoses = `SELECT medication_reminder_doses.id, medication_reminder_id, scheduled_at,
+		   medication_reminders.user_id, medication_reminders.dependent_id, medication_name, instruction,
+		   COALESCE(dependents.name, user_profiles.name), users.email
+	FROM medication_reminder_doses
+		INNER JOIN medication_reminders ON medication_reminder_doses.medication_reminder_id = medication_reminders.id
+		INNER JOIN user_profiles ON medication_reminders.user_id = user_profiles.user_id
+		INNER JOIN users ON user_profiles.user_id = users.id
+		LEFT JOIN dependents ON medication_reminders.dependent_id = dependents.id
+	WHERE medication_reminder_doses.status = $1 AND medication_reminder_doses.notified_at IS NULL
+	  AND scheduled_at <= $2 AND scheduled_at > $3 AND medication_reminders.deleted_at IS NULL
+	ORDER BY scheduled_at`
+
+	rows, err := repo.db.QueryContext(ctx, findAllDueDoses,
+		appconstant.MedicationReminderDoseStatusPending, now, now.Add(-appconstant.MedicationReminderNotifyWindow),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.MedicationReminderDose, 0)
+	for rows.Next() {
+		var (
+			dose        entity.MedicationReminderDose
+			reminder    entity.MedicationReminder
+			user        entity.User
+			userProfile entity.UserProfile
+		)
+		if err := rows.Scan(
+			&dose.Id, &dose.MedicationReminderId, &dose.ScheduledAt,
+			&reminder.UserId, &reminder.DependentId, &reminder.MedicationName, &reminder.Instruction,
+			&userProfile.Name, &user.Email,
+		); err != nil {
+			return nil, err
+		}
+		reminder.Id = dose.MedicationReminderId
+		user.Id = reminder.UserId
+		user.UserProfile = &userProfile
+		reminder.User = &user
+		dose.MedicationReminder = &reminder
+		items = append(items, &dose)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *MedicationReminderRepositoryImpl) UpdateDoseNotified(ctx context.Context, id int64) error {
+	const updateDoseNotified = `UPDATE medication_reminder_doses SET notified_at = now(), updated_at = now() WHERE id = $1`
+	_, err := repo.db.ExecContext(ctx, updateDoseNotified, id)
+	return err
+}
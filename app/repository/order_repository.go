@@ -268,7 +268,7 @@ func (repo *OrderRepositoryImpl) CountFindAllOrdersByUserId(ctx context.Context,
 func (repo *OrderRepositoryImpl) FindOrderById(ctx context.Context, id int64) (*entity.Order, *entity.OrderIds, error) {
 	const findOrderById = `SELECT DISTINCT orders.id, order_statuses.id, order_statuses.name, orders.date, shipping_method_id, 
                 shipping_methods.name, shipping_cost, pharmacy_id, 
-                pharmacies.name, transactions.address, orders.total_payment, transactions.user_id, pharmacies.pharmacy_admin_id
+                pharmacies.name, transactions.address, orders.total_payment, orders.dependent_id, transactions.user_id, pharmacies.pharmacy_admin_id
 	FROM orders
 		INNER JOIN shipping_methods ON orders.shipping_method_id = shipping_methods.id
 		INNER JOIN transactions ON orders.transaction_id = transactions.id
@@ -296,6 +296,7 @@ func (repo *OrderRepositoryImpl) FindOrderById(ctx context.Context, id int64) (*
 		&pharmacy.Name,
 		&order.UserAddress,
 		&order.TotalPayment,
+		&order.DependentId,
 		&ids.UserId,
 		&ids.PharmacyAdminId,
 	)
@@ -320,7 +321,7 @@ func (repo *OrderRepositoryImpl) FindOrderById(ctx context.Context, id int64) (*
 }
 
 func (repo *OrderRepositoryImpl) findAllOrderDetailsByOrderId(ctx context.Context, orderId int64) ([]*entity.OrderDetail, error) {
-	const getAllOrderDetails = `SELECT order_details.id, quantity, name, generic_name, content, description, image, price FROM order_details
+	const getAllOrderDetails = `SELECT order_details.id, quantity, name, generic_name, content, description, image, price, prescription_product_id FROM order_details
 	INNER JOIN orders ON order_details.order_id = orders.id WHERE orders.id = $1`
 
 	rows, err := repo.db.QueryContext(ctx, getAllOrderDetails, orderId)
@@ -334,7 +335,7 @@ func (repo *OrderRepositoryImpl) findAllOrderDetailsByOrderId(ctx context.Contex
 		var orderDetail entity.OrderDetail
 		if err := rows.Scan(
 			&orderDetail.Id, &orderDetail.Quantity, &orderDetail.Name, &orderDetail.GenericName,
-			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price,
+			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price, &orderDetail.PrescriptionProductId,
 		); err != nil {
 			return nil, err
 		}
@@ -344,9 +345,62 @@ func (repo *OrderRepositoryImpl) findAllOrderDetailsByOrderId(ctx context.Contex
 		return nil, err
 	}
 
+	for _, orderDetail := range items {
+		if !orderDetail.PrescriptionProductId.Valid {
+			continue
+		}
+		orderDetail.PrescriptionProduct, err = repo.findPrescriptionProductById(ctx, orderDetail.PrescriptionProductId.Int64)
+		if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
 	return items, nil
 }
 
+func (repo *OrderRepositoryImpl) findPrescriptionProductById(ctx context.Context, id int64) (*entity.PrescriptionProduct, error) {
+	const findPrescriptionProductById = `
+	SELECT prescription_products.id, prescription_products.prescription_id, prescription_products.product_id, prescription_products.note,
+		   prescription_products.quantity, prescription_products.dose_amount, prescription_products.dose_unit, prescription_products.frequency,
+		   prescription_products.route, prescription_products.duration_days, prescription_products.meal_timing, prescription_products.redeemed_quantity,
+		   prescriptions.session_id, prescriptions.expired_at, prescriptions.created_at, consultation_sessions.dependent_id,
+		   COALESCE(dependents.name, user_profiles.name), doctor_profiles.name
+	FROM prescription_products
+		INNER JOIN prescriptions ON prescription_products.prescription_id = prescriptions.id
+		INNER JOIN consultation_sessions ON prescriptions.session_id = consultation_sessions.id
+		INNER JOIN user_profiles ON consultation_sessions.user_id = user_profiles.user_id
+		LEFT JOIN dependents ON consultation_sessions.dependent_id = dependents.id
+		INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
+	WHERE prescription_products.id = $1`
+
+	row := repo.db.QueryRowContext(ctx, findPrescriptionProductById, id)
+	var (
+		prescriptionProduct entity.PrescriptionProduct
+		prescription        entity.Prescription
+		userProfile         entity.UserProfile
+		doctorProfile       entity.DoctorProfile
+	)
+	err := row.Scan(
+		&prescriptionProduct.Id, &prescriptionProduct.PrescriptionId, &prescriptionProduct.ProductId, &prescriptionProduct.Note,
+		&prescriptionProduct.Quantity, &prescriptionProduct.DoseAmount, &prescriptionProduct.DoseUnit, &prescriptionProduct.Frequency,
+		&prescriptionProduct.Route, &prescriptionProduct.DurationDays, &prescriptionProduct.MealTiming, &prescriptionProduct.RedeemedQuantity,
+		&prescription.SessionId, &prescription.ExpiredAt, &prescription.CreatedAt, &prescription.DependentId,
+		&userProfile.Name, &doctorProfile.Name,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	prescription.Id = prescriptionProduct.PrescriptionId
+	prescription.User = &entity.User{UserProfile: &userProfile}
+	prescription.Doctor = &entity.User{DoctorProfile: &doctorProfile}
+	prescriptionProduct.Prescription = &prescription
+	return &prescriptionProduct, nil
+}
+
 func (repo *OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, orderId int64, orderLog entity.OrderStatusLog) (*entity.OrderStatusLog, error) {
 	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -360,6 +414,13 @@ func (repo *OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, orderId
 		return nil, err
 	}
 
+	if orderLog.OrderStatusId == appconstant.CanceledByPharmacyOrderStatusId ||
+		orderLog.OrderStatusId == appconstant.CanceledByUserOrderStatusId {
+		if err = releasePrescriptionProducts(ctx, tx, []int64{orderId}); err != nil {
+			return nil, err
+		}
+	}
+
 	const addStatus = `INSERT INTO order_status_logs(order_id, order_status_id, is_latest, description)
 	values ($1, $2, $3, $4) RETURNING id, order_id, order_status_id, is_latest, description`
 
@@ -430,7 +491,7 @@ WHERE o.id = $1
 		if err := rows.Scan(
 			&pharmacyProduct.Id, &pharmacyProduct.PharmacyId, &pharmacyProduct.ProductId, &pharmacyProduct.Stock, &pharmacyProduct.Price, &pharmacyProduct.IsActive,
 			&orderDetail.Id, &orderDetail.OrderId, &orderDetail.ProductId, &orderDetail.Quantity, &orderDetail.Name, &orderDetail.GenericName,
-			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price,
+			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price, &orderDetail.PrescriptionProductId,
 		); err != nil {
 			return nil, nil, err
 		}
@@ -716,6 +777,10 @@ func (repo *OrderRepositoryImpl) CancelOrder(ctx context.Context, orderId int64,
 		return nil, err
 	}
 
+	if err := releasePrescriptionProducts(ctx, tx, []int64{orderId}); err != nil {
+		return nil, err
+	}
+
 	const updateOldStatus = `UPDATE order_status_logs SET is_latest = FALSE WHERE order_id = $1 AND is_latest = true`
 	_, err = tx.ExecContext(ctx, updateOldStatus, orderId)
 	if err != nil {
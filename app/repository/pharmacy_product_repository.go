@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
@@ -12,7 +14,7 @@ import (
 )
 
 type PharmacyProductRepository interface {
-	Create(ctx context.Context, pharmacyProduct entity.PharmacyProduct) (*entity.PharmacyProduct, error)
+	Create(ctx context.Context, pharmacyProduct entity.PharmacyProduct, changedBy int64) (*entity.PharmacyProduct, error)
 	FindById(ctx context.Context, id int64) (*entity.PharmacyProduct, error)
 	FindByIdJoinPharmacy(ctx context.Context, id int64) (*entity.PharmacyProduct, error)
 	FindByIdJoinPharmacyAndProduct(ctx context.Context, id int64) (*entity.PharmacyProduct, error)
@@ -24,8 +26,13 @@ type PharmacyProductRepository interface {
 
 	FindAllJoinProducts(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.PharmacyProduct, error)
 	FindAllByProductId(ctx context.Context, productId int64) ([]*entity.PharmacyProduct, error)
+	FindAllByPharmacyIdJoinProduct(ctx context.Context, pharmacyId int64) ([]*entity.PharmacyProduct, error)
 	CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
-	Update(ctx context.Context, pharmacyProduct entity.PharmacyProduct) (*entity.PharmacyProduct, error)
+	Update(ctx context.Context, pharmacyProduct entity.PharmacyProduct, changedBy int64) (*entity.PharmacyProduct, error)
+	UpsertAllByPharmacyId(ctx context.Context, pharmacyId int64, rows []*entity.PharmacyProductImportRow, changedBy int64, isDryRun bool) error
+
+	FindAllPriceHistoriesById(ctx context.Context, id int64, param *queryparamdto.GetAllParams) ([]*entity.PharmacyProductPriceHistory, error)
+	CountFindAllPriceHistoriesById(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (int64, error)
 }
 
 type PharmacyProductRepositoryImpl struct {
@@ -36,34 +43,43 @@ func NewPharmacyProductRepository(db *sql.DB) *PharmacyProductRepositoryImpl {
 	return &PharmacyProductRepositoryImpl{db: db}
 }
 
-func (repo *PharmacyProductRepositoryImpl) Create(ctx context.Context, pharmacyProduct entity.PharmacyProduct) (*entity.PharmacyProduct, error) {
+// Create adds the pharmacy product together with the first entry of its price history
+func (repo *PharmacyProductRepositoryImpl) Create(ctx context.Context, pharmacyProduct entity.PharmacyProduct, changedBy int64) (*entity.PharmacyProduct, error) {
 	const create = `INSERT INTO pharmacy_products(pharmacy_id, product_id, is_active, price, stock)
 VALUES ($1, $2, $3, $4, $5)
 RETURNING id, pharmacy_id, product_id, is_active, price, stock
 `
 
-	row := repo.db.QueryRowContext(ctx, create,
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var created entity.PharmacyProduct
+	err = tx.QueryRowContext(ctx, create,
 		pharmacyProduct.PharmacyId,
 		pharmacyProduct.ProductId,
 		pharmacyProduct.IsActive,
 		pharmacyProduct.Price.String(),
 		pharmacyProduct.Stock,
+	).Scan(
+		&created.Id, &created.PharmacyId, &created.ProductId,
+		&created.IsActive, &created.Price, &created.Stock,
 	)
-
-	if row.Err() != nil {
+	if err != nil {
 		var errPgConn *pgconn.PgError
-		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+		if errors.As(err, &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
 			return nil, apperror.ErrPharmacyProductUniqueConstraint
 		}
-		return nil, row.Err()
+		return nil, err
 	}
 
-	var created entity.PharmacyProduct
-	err := row.Scan(
-		&created.Id, &created.PharmacyId, &created.ProductId,
-		&created.IsActive, &created.Price, &created.Stock,
-	)
-	return &created, err
+	err = createPharmacyProductPriceHistory(ctx, tx, created.Id, decimal.NullDecimal{}, created.Price, changedBy, appconstant.PharmacyProductPriceSourceManual)
+	if err != nil {
+		return nil, err
+	}
+	return &created, tx.Commit()
 }
 
 func (repo *PharmacyProductRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.PharmacyProduct, error) {
@@ -430,7 +446,10 @@ func (repo *PharmacyProductRepositoryImpl) CountFindAll(ctx context.Context, par
 	return totalItems, nil
 }
 
-func (repo *PharmacyProductRepositoryImpl) Update(ctx context.Context, pharmacyProduct entity.PharmacyProduct) (*entity.PharmacyProduct, error) {
+// Update sets the pharmacy product, a price that changes is added to its price history
+func (repo *PharmacyProductRepositoryImpl) Update(ctx context.Context, pharmacyProduct entity.PharmacyProduct, changedBy int64) (*entity.PharmacyProduct, error) {
+	const findPriceForUpdate = `SELECT price FROM pharmacy_products WHERE id = $1 FOR UPDATE`
+
 	updateById := `
 		UPDATE pharmacy_products
 		SET is_active = $1, price = $2
@@ -438,13 +457,27 @@ func (repo *PharmacyProductRepositoryImpl) Update(ctx context.Context, pharmacyP
 		RETURNING id, pharmacy_id, product_id, is_active, price, stock
 	`
 
-	row := repo.db.QueryRowContext(ctx, updateById,
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var oldPrice decimal.Decimal
+	if err := tx.QueryRowContext(ctx, findPriceForUpdate, pharmacyProduct.Id).Scan(&oldPrice); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx, updateById,
 		pharmacyProduct.IsActive,
 		pharmacyProduct.Price,
 		pharmacyProduct.Id,
 	)
 	var updated entity.PharmacyProduct
-	err := row.Scan(
+	err = row.Scan(
 		&updated.Id,
 		&updated.PharmacyId,
 		&updated.ProductId,
@@ -452,5 +485,198 @@ func (repo *PharmacyProductRepositoryImpl) Update(ctx context.Context, pharmacyP
 		&updated.Price,
 		&updated.Stock,
 	)
-	return &updated, err
+	if err != nil {
+		return nil, err
+	}
+
+	if !oldPrice.Equal(updated.Price) {
+		err = createPharmacyProductPriceHistory(ctx, tx, updated.Id, decimal.NewNullDecimal(oldPrice), updated.Price, changedBy, appconstant.PharmacyProductPriceSourceManual)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &updated, tx.Commit()
+}
+
+func (repo *PharmacyProductRepositoryImpl) FindAllByPharmacyIdJoinProduct(ctx context.Context, pharmacyId int64) ([]*entity.PharmacyProduct, error) {
+	const findAllByPharmacyId = `
+	SELECT pharmacy_products.id, pharmacy_products.pharmacy_id, pharmacy_products.product_id, pharmacy_products.is_active,
+		pharmacy_products.price, pharmacy_products.stock, products.name, products.code
+	FROM pharmacy_products
+	INNER JOIN products ON pharmacy_products.product_id = products.id
+	WHERE pharmacy_products.pharmacy_id = $1 AND pharmacy_products.deleted_at IS NULL AND products.deleted_at IS NULL
+	ORDER BY products.name, pharmacy_products.id`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByPharmacyId, pharmacyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.PharmacyProduct, 0)
+	for rows.Next() {
+		var (
+			pharmacyProduct entity.PharmacyProduct
+			product         entity.Product
+		)
+		if err := rows.Scan(
+			&pharmacyProduct.Id, &pharmacyProduct.PharmacyId, &pharmacyProduct.ProductId, &pharmacyProduct.IsActive,
+			&pharmacyProduct.Price, &pharmacyProduct.Stock, &product.Name, &product.Code,
+		); err != nil {
+			return nil, err
+		}
+		product.Id = pharmacyProduct.ProductId
+		pharmacyProduct.Product = &product
+		items = append(items, &pharmacyProduct)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpsertAllByPharmacyId writes every row in one transaction, a stock that changes is set through a stock mutation
+// so the ledger adds up to the new stock and a price that changes is added to the price history. A dry run does the
+// same work and rolls it back
+func (repo *PharmacyProductRepositoryImpl) UpsertAllByPharmacyId(ctx context.Context, pharmacyId int64, rows []*entity.PharmacyProductImportRow, changedBy int64, isDryRun bool) error {
+	const findForUpdate = `SELECT id, is_active, price, stock, deleted_at IS NOT NULL
+	FROM pharmacy_products WHERE pharmacy_id = $1 AND product_id = $2
+	FOR UPDATE`
+
+	const create = `INSERT INTO pharmacy_products(pharmacy_id, product_id, is_active, price, stock)
+	VALUES ($1, $2, $3, $4, 0)
+	RETURNING id`
+
+	const update = `UPDATE pharmacy_products SET is_active = $1, price = $2, deleted_at = NULL, updated_at = now()
+	WHERE id = $3`
+
+	const createMutation = `INSERT INTO product_stock_mutations(pharmacy_product_id, product_stock_mutation_type_id, stock)
+	VALUES ($1, $2, $3)`
+
+	const updateStock = `UPDATE pharmacy_products SET stock = $1, updated_at = now() WHERE id = $2`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		var (
+			id        int64
+			isActive  bool
+			price     decimal.Decimal
+			stock     int32
+			isDeleted bool
+		)
+		err := tx.QueryRowContext(ctx, findForUpdate, pharmacyId, row.ProductId).Scan(&id, &isActive, &price, &stock, &isDeleted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if err := tx.QueryRowContext(ctx, create, pharmacyId, row.ProductId, row.IsActive, row.Price.String()).Scan(&id); err != nil {
+				return err
+			}
+			err := createPharmacyProductPriceHistory(ctx, tx, id, decimal.NullDecimal{}, row.Price, changedBy, appconstant.PharmacyProductPriceSourceImport)
+			if err != nil {
+				return err
+			}
+			row.Status = appconstant.PharmacyProductImportRowStatusCreated
+		case err != nil:
+			return err
+		default:
+			row.Status = appconstant.PharmacyProductImportRowStatusUnchanged
+			if isDeleted || isActive != row.IsActive || !price.Equal(row.Price) || stock != row.Stock {
+				row.Status = appconstant.PharmacyProductImportRowStatusUpdated
+			}
+			if _, err := tx.ExecContext(ctx, update, row.IsActive, row.Price.String(), id); err != nil {
+				return err
+			}
+			if !price.Equal(row.Price) {
+				err := createPharmacyProductPriceHistory(ctx, tx, id, decimal.NewNullDecimal(price), row.Price, changedBy, appconstant.PharmacyProductPriceSourceImport)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		row.StockDifference = row.Stock - stock
+		if row.StockDifference == 0 {
+			continue
+		}
+		mutationTypeId, mutationStock := appconstant.StockMutationTypeAddition, row.StockDifference
+		if row.StockDifference < 0 {
+			mutationTypeId, mutationStock = appconstant.StockMutationTypeReduction, -row.StockDifference
+		}
+		if _, err := tx.ExecContext(ctx, createMutation, id, mutationTypeId, mutationStock); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, updateStock, row.Stock, id); err != nil {
+			return err
+		}
+	}
+
+	if isDryRun {
+		return nil
+	}
+	return tx.Commit()
+}
+
+func (repo *PharmacyProductRepositoryImpl) FindAllPriceHistoriesById(ctx context.Context, id int64, param *queryparamdto.GetAllParams) ([]*entity.PharmacyProductPriceHistory, error) {
+	const initQuery = `SELECT pharmacy_product_price_histories.id, pharmacy_product_id, old_price, new_price, changed_by, source,
+		pharmacy_product_price_histories.created_at, users.email
+	FROM pharmacy_product_price_histories
+	LEFT JOIN users ON pharmacy_product_price_histories.changed_by = users.id
+	WHERE pharmacy_product_id = $1 `
+
+	indexPreparedStatement := 1
+	query, values := buildQuery(initQuery, &entity.PharmacyProductPriceHistory{}, param, true, true, indexPreparedStatement)
+	values = util.AppendAtIndex(values, 0, interface{}(id))
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.PharmacyProductPriceHistory, 0)
+	for rows.Next() {
+		var history entity.PharmacyProductPriceHistory
+		if err := rows.Scan(
+			&history.Id, &history.PharmacyProductId, &history.OldPrice, &history.NewPrice, &history.ChangedBy, &history.Source,
+			&history.CreatedAt, &history.ChangedByEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &history)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *PharmacyProductRepositoryImpl) CountFindAllPriceHistoriesById(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (int64, error) {
+	const initQuery = `SELECT count(pharmacy_product_price_histories.id) FROM pharmacy_product_price_histories
+	WHERE pharmacy_product_id = $1 `
+
+	indexPreparedStatement := 1
+	query, values := buildQuery(initQuery, &entity.PharmacyProductPriceHistory{}, param, false, false, indexPreparedStatement)
+	values = util.AppendAtIndex(values, 0, interface{}(id))
+
+	var totalItems int64
+	if err := repo.db.QueryRowContext(ctx, query, values...).Scan(&totalItems); err != nil {
+		return 0, err
+	}
+	return totalItems, nil
+}
+
+// createPharmacyProductPriceHistory records that the price of the pharmacy product was set by changedBy, as part of the transaction
+// that sets it
+func createPharmacyProductPriceHistory(ctx context.Context, tx *sql.Tx, id int64, oldPrice decimal.NullDecimal, newPrice decimal.Decimal, changedBy int64, source string) error {
+	const create = `INSERT INTO pharmacy_product_price_histories(pharmacy_product_id, old_price, new_price, changed_by, source)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(ctx, create, id, oldPrice, newPrice.String(), changedBy, source)
+	return err
 }
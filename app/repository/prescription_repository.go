@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/entity"
 )
@@ -14,6 +15,7 @@ type PrescriptionRepository interface {
 	Create(ctx context.Context, prescription entity.Prescription) (*entity.Prescription, error)
 	FindBySessionId(ctx context.Context, sessionId int64) (*entity.Prescription, error)
 	FindBySessionIdDetailed(ctx context.Context, sessionId int64) (*entity.Prescription, error)
+	FindPrescriptionProductById(ctx context.Context, id int64) (*entity.PrescriptionProduct, error)
 	UpdateBySessionId(ctx context.Context, prescription entity.Prescription) (*entity.Prescription, error)
 }
 
@@ -35,11 +37,15 @@ func (repo *PrescriptionRepositoryImpl) Create(ctx context.Context, prescription
 	}(tx)
 
 	const createPrescription = `
-	INSERT INTO prescriptions(session_id, symptoms, diagnosis)
-	VALUES ($1, $2, $3)
-	RETURNING id, session_id, symptoms, diagnosis, created_at, updated_at`
+	INSERT INTO prescriptions(session_id, symptoms, diagnosis, expired_at, interaction_override_reason)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, session_id, symptoms, diagnosis, expired_at, interaction_override_reason, created_at, updated_at`
 
-	row := tx.QueryRowContext(ctx, createPrescription, prescription.SessionId, prescription.Symptoms, prescription.Diagnosis)
+	if err = encryptFields(&prescription.Symptoms, &prescription.Diagnosis); err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx, createPrescription, prescription.SessionId, prescription.Symptoms, prescription.Diagnosis, prescription.ExpiredAt, prescription.InteractionOverrideReason)
 	if row.Err() != nil {
 		var errPgConn *pgconn.PgError
 		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
@@ -51,45 +57,19 @@ func (repo *PrescriptionRepositoryImpl) Create(ctx context.Context, prescription
 	var createdPrescription entity.Prescription
 	err = row.Scan(
 		&createdPrescription.Id, &createdPrescription.SessionId,
-		&createdPrescription.Symptoms, &createdPrescription.Diagnosis,
+		&createdPrescription.Symptoms, &createdPrescription.Diagnosis, &createdPrescription.ExpiredAt, &createdPrescription.InteractionOverrideReason,
 		&createdPrescription.CreatedAt, &createdPrescription.UpdatedAt,
 	)
-
-	createPrescriptionProduct := `INSERT INTO prescription_products(prescription_id, product_id, note) VALUES `
-	values := make([]interface{}, 0)
-
-	indexPreparedStatement := 0
-	for index, prescriptionProduct := range prescription.PrescriptionProducts {
-		createPrescriptionProduct += fmt.Sprintf("($%d, $%d, $%d)", indexPreparedStatement+1, indexPreparedStatement+2, indexPreparedStatement+3)
-		indexPreparedStatement += 3
-		if index != len(prescription.PrescriptionProducts)-1 {
-			createPrescriptionProduct += ", "
-		}
-
-		values = append(values,
-			createdPrescription.Id, prescriptionProduct.ProductId, prescriptionProduct.Note,
-		)
-	}
-	createPrescriptionProduct += " RETURNING id, prescription_id, product_id, note, created_at, updated_at"
-
-	rows, err := tx.QueryContext(ctx, createPrescriptionProduct, values...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	prescriptionProducts := make([]*entity.PrescriptionProduct, 0)
-	for rows.Next() {
-		var prescriptionProduct entity.PrescriptionProduct
-		if err = rows.Scan(
-			&prescriptionProduct.Id, &prescriptionProduct.PrescriptionId, &prescriptionProduct.ProductId,
-			&prescriptionProduct.Note, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		prescriptionProducts = append(prescriptionProducts, &prescriptionProduct)
+	if err = decryptFields(&createdPrescription.Symptoms, &createdPrescription.Diagnosis); err != nil {
+		return nil, err
 	}
-	if err = rows.Err(); err != nil {
+
+	prescriptionProducts, err := repo.createPrescriptionProducts(ctx, tx, createdPrescription.Id, prescription.PrescriptionProducts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -105,19 +85,21 @@ func (repo *PrescriptionRepositoryImpl) Create(ctx context.Context, prescription
 
 func (repo *PrescriptionRepositoryImpl) FindBySessionId(ctx context.Context, sessionId int64) (*entity.Prescription, error) {
 	query := `
-	SELECT prescriptions.id, session_id, symptoms, diagnosis, prescriptions.created_at, prescriptions.updated_at,
-		   cm.prescription_product_id, cm.prescription_product_product_id, cm.note,  cm.created_at, cm.updated_at,
+	SELECT prescriptions.id, session_id, symptoms, diagnosis, expired_at, interaction_override_reason, prescriptions.created_at, prescriptions.updated_at,
+		   cm.prescription_product_id, cm.prescription_product_product_id, cm.note, cm.quantity, cm.dose_amount, cm.dose_unit,
+		   cm.frequency, cm.route, cm.duration_days, cm.meal_timing, cm.redeemed_quantity, cm.created_at, cm.updated_at,
 		   cm.product_id, cm.product_name, cm.product_generic_name, cm.product_content, cm.product_image,
 		   cm.manufacturer_name
 	FROM  prescriptions
 		LEFT JOIN LATERAL (
-			SELECT prescription_products.id AS prescription_product_id, product_id AS prescription_product_product_id, note, prescription_products.created_at, prescription_products.updated_at,
+			SELECT prescription_products.id AS prescription_product_id, product_id AS prescription_product_product_id, note,
+			   quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, redeemed_quantity, prescription_products.created_at, prescription_products.updated_at,
 				   products.id AS product_id, products.name AS product_name, products.generic_name AS product_generic_name, products.content AS product_content, products.image AS product_image,
 				   manufacturers.name AS manufacturer_name
 			FROM prescription_products
 			INNER JOIN products ON prescription_products.product_id = products.id
 			INNER JOIN manufacturers ON products.manufacturer_id = manufacturers.id
-			WHERE prescription_products.prescription_id = prescriptions.id
+			WHERE prescription_products.prescription_id = prescriptions.id AND prescription_products.deleted_at IS NULL
 			ORDER BY prescription_products.id ASC
 		) cm ON true
 	WHERE prescriptions.deleted_at IS NULL AND prescriptions.session_id = $1;`
@@ -137,8 +119,11 @@ func (repo *PrescriptionRepositoryImpl) FindBySessionId(ctx context.Context, ses
 			manufacturer        entity.Manufacturer
 		)
 		if err = rows.Scan(
-			&prescription.Id, &prescription.SessionId, &prescription.Symptoms, &prescription.Diagnosis, &prescription.CreatedAt, &prescription.UpdatedAt,
-			&prescriptionProduct.Id, &prescriptionProduct.ProductId, &prescriptionProduct.Note, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
+			&prescription.Id, &prescription.SessionId, &prescription.Symptoms, &prescription.Diagnosis, &prescription.ExpiredAt, &prescription.InteractionOverrideReason, &prescription.CreatedAt, &prescription.UpdatedAt,
+			&prescriptionProduct.Id, &prescriptionProduct.ProductId, &prescriptionProduct.Note,
+			&prescriptionProduct.Quantity, &prescriptionProduct.DoseAmount, &prescriptionProduct.DoseUnit, &prescriptionProduct.Frequency,
+			&prescriptionProduct.Route, &prescriptionProduct.DurationDays, &prescriptionProduct.MealTiming,
+			&prescriptionProduct.RedeemedQuantity, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
 			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.Image,
 			&manufacturer.Name,
 		); err != nil {
@@ -158,32 +143,39 @@ func (repo *PrescriptionRepositoryImpl) FindBySessionId(ctx context.Context, ses
 		return nil, apperror.ErrRecordNotFound
 	}
 
+	if err = decryptFields(&prescription.Symptoms, &prescription.Diagnosis); err != nil {
+		return nil, err
+	}
+
 	return &prescription, nil
 }
 
 func (repo *PrescriptionRepositoryImpl) FindBySessionIdDetailed(ctx context.Context, sessionId int64) (*entity.Prescription, error) {
 	query := `
-	SELECT prescriptions.id, session_id, symptoms, diagnosis, prescriptions.created_at, prescriptions.updated_at,
-		   user_profiles.name, user_profiles.date_of_birth, users.email,
+	SELECT prescriptions.id, session_id, symptoms, diagnosis, expired_at, interaction_override_reason, prescriptions.created_at, prescriptions.updated_at,
+		   COALESCE(dependents.name, user_profiles.name), COALESCE(dependents.date_of_birth, user_profiles.date_of_birth), users.email,
 		   doctor_profiles.name, doctor_specializations.name, doctors.email,
-		   cm.prescription_product_id, cm.prescription_product_product_id, cm.note,  cm.created_at, cm.updated_at,
+		   cm.prescription_product_id, cm.prescription_product_product_id, cm.note, cm.quantity, cm.dose_amount, cm.dose_unit,
+		   cm.frequency, cm.route, cm.duration_days, cm.meal_timing, cm.redeemed_quantity, cm.created_at, cm.updated_at,
 		   cm.product_id, cm.product_name, cm.product_generic_name, cm.product_content, cm.product_image,
 		   cm.manufacturer_name
 	FROM  prescriptions
 		INNER JOIN consultation_sessions ON prescriptions.session_id = consultation_sessions.id
 		INNER JOIN user_profiles ON consultation_sessions.user_id = user_profiles.user_id
 		INNER JOIN users ON user_profiles.user_id = users.id
+		LEFT JOIN dependents ON consultation_sessions.dependent_id = dependents.id
 		INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
 		INNER JOIN users AS doctors ON doctor_profiles.user_id = doctors.id
 		INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id
 		LEFT JOIN LATERAL (
-		SELECT prescription_products.id AS prescription_product_id, product_id AS prescription_product_product_id, note, prescription_products.created_at, prescription_products.updated_at,
+		SELECT prescription_products.id AS prescription_product_id, product_id AS prescription_product_product_id, note,
+			   quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, redeemed_quantity, prescription_products.created_at, prescription_products.updated_at,
 			   products.id AS product_id, products.name AS product_name, products.generic_name AS product_generic_name, products.content AS product_content, products.image AS product_image,
 			   manufacturers.name AS manufacturer_name
 		FROM prescription_products
 				 INNER JOIN products ON prescription_products.product_id = products.id
 				 INNER JOIN manufacturers ON products.manufacturer_id = manufacturers.id
-		WHERE prescription_products.prescription_id = prescriptions.id
+		WHERE prescription_products.prescription_id = prescriptions.id AND prescription_products.deleted_at IS NULL
 		ORDER BY prescription_products.id ASC
 		) cm ON true
 	WHERE prescriptions.deleted_at IS NULL AND prescriptions.session_id = $1`
@@ -210,10 +202,13 @@ func (repo *PrescriptionRepositoryImpl) FindBySessionIdDetailed(ctx context.Cont
 			manufacturer        entity.Manufacturer
 		)
 		if err = rows.Scan(
-			&prescription.Id, &prescription.SessionId, &prescription.Symptoms, &prescription.Diagnosis, &prescription.CreatedAt, &prescription.UpdatedAt,
+			&prescription.Id, &prescription.SessionId, &prescription.Symptoms, &prescription.Diagnosis, &prescription.ExpiredAt, &prescription.InteractionOverrideReason, &prescription.CreatedAt, &prescription.UpdatedAt,
 			&userProfile.Name, &userProfile.DateOfBirth, &user.Email,
 			&doctorProfile.Name, &doctorSpecialization.Name, &doctor.Email,
-			&prescriptionProduct.Id, &prescriptionProduct.ProductId, &prescriptionProduct.Note, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
+			&prescriptionProduct.Id, &prescriptionProduct.ProductId, &prescriptionProduct.Note,
+			&prescriptionProduct.Quantity, &prescriptionProduct.DoseAmount, &prescriptionProduct.DoseUnit, &prescriptionProduct.Frequency,
+			&prescriptionProduct.Route, &prescriptionProduct.DurationDays, &prescriptionProduct.MealTiming,
+			&prescriptionProduct.RedeemedQuantity, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
 			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.Image,
 			&manufacturer.Name,
 		); err != nil {
@@ -240,9 +235,54 @@ func (repo *PrescriptionRepositoryImpl) FindBySessionIdDetailed(ctx context.Cont
 		return nil, apperror.ErrRecordNotFound
 	}
 
+	if err = decryptFields(&prescription.Symptoms, &prescription.Diagnosis); err != nil {
+		return nil, err
+	}
+
 	return &prescription, nil
 }
 
+func (repo *PrescriptionRepositoryImpl) FindPrescriptionProductById(ctx context.Context, id int64) (*entity.PrescriptionProduct, error) {
+	const findPrescriptionProductById = `
+	SELECT prescription_products.id, prescription_products.prescription_id, prescription_products.product_id,
+		   prescription_products.note, prescription_products.quantity, prescription_products.dose_amount,
+		   prescription_products.dose_unit, prescription_products.frequency, prescription_products.route,
+		   prescription_products.duration_days, prescription_products.meal_timing, prescription_products.redeemed_quantity,
+		   prescriptions.session_id, prescriptions.expired_at, consultation_sessions.user_id, consultation_sessions.dependent_id
+	FROM prescription_products
+		INNER JOIN prescriptions ON prescription_products.prescription_id = prescriptions.id
+		INNER JOIN consultation_sessions ON prescriptions.session_id = consultation_sessions.id
+	WHERE prescription_products.id = $1 AND prescription_products.deleted_at IS NULL AND prescriptions.deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findPrescriptionProductById, id)
+	var (
+		prescriptionProduct entity.PrescriptionProduct
+		prescription        entity.Prescription
+		user                entity.User
+	)
+	err := row.Scan(
+		&prescriptionProduct.Id, &prescriptionProduct.PrescriptionId, &prescriptionProduct.ProductId,
+		&prescriptionProduct.Note, &prescriptionProduct.Quantity, &prescriptionProduct.DoseAmount,
+		&prescriptionProduct.DoseUnit, &prescriptionProduct.Frequency, &prescriptionProduct.Route,
+		&prescriptionProduct.DurationDays, &prescriptionProduct.MealTiming, &prescriptionProduct.RedeemedQuantity,
+		&prescription.SessionId, &prescription.ExpiredAt, &user.Id, &prescription.DependentId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	prescription.Id = prescriptionProduct.PrescriptionId
+	prescription.User = &user
+	prescriptionProduct.Prescription = &prescription
+	return &prescriptionProduct, nil
+}
+
+// UpdateBySessionId updates the prescription and its products in place, so the cart items, reminders and orders that
+// point at a prescription product keep pointing at it. Products that are prescribed again keep their row, products
+// that are no longer prescribed are deleted and taken off the carts
 func (repo *PrescriptionRepositoryImpl) UpdateBySessionId(ctx context.Context, prescription entity.Prescription) (*entity.Prescription, error) {
 	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -256,15 +296,23 @@ func (repo *PrescriptionRepositoryImpl) UpdateBySessionId(ctx context.Context, p
 		}
 	}(tx)
 
-	const getPrescriptionBySessionId = `SELECT id FROM prescriptions WHERE session_id = $1`
+	const updatePrescription = `
+	UPDATE prescriptions SET symptoms = $1, diagnosis = $2, expired_at = $3, interaction_override_reason = $4, updated_at = now()
+	WHERE session_id = $5 AND deleted_at IS NULL
+	RETURNING id, session_id, symptoms, diagnosis, expired_at, interaction_override_reason, created_at, updated_at`
 
-	row := repo.db.QueryRowContext(ctx, getPrescriptionBySessionId, prescription.SessionId)
-	if row.Err() != nil {
-		return nil, row.Err()
+	if err = encryptFields(&prescription.Symptoms, &prescription.Diagnosis); err != nil {
+		return nil, err
 	}
 
-	var prescriptionId int64
-	err = row.Scan(&prescriptionId)
+	var updatedPrescription entity.Prescription
+	err = tx.QueryRowContext(ctx, updatePrescription,
+		prescription.Symptoms, prescription.Diagnosis, prescription.ExpiredAt, prescription.InteractionOverrideReason, prescription.SessionId,
+	).Scan(
+		&updatedPrescription.Id, &updatedPrescription.SessionId,
+		&updatedPrescription.Symptoms, &updatedPrescription.Diagnosis, &updatedPrescription.ExpiredAt, &updatedPrescription.InteractionOverrideReason,
+		&updatedPrescription.CreatedAt, &updatedPrescription.UpdatedAt,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, apperror.ErrRecordNotFound
@@ -272,57 +320,125 @@ func (repo *PrescriptionRepositoryImpl) UpdateBySessionId(ctx context.Context, p
 		return nil, err
 	}
 
-	const deletePrescriptionProduct = `DELETE FROM prescription_products WHERE prescription_id = $1`
-
-	_, err = tx.ExecContext(ctx, deletePrescriptionProduct, prescriptionId)
-	if err != nil {
+	if err = decryptFields(&updatedPrescription.Symptoms, &updatedPrescription.Diagnosis); err != nil {
 		return nil, err
 	}
 
-	const deletePrescription = `DELETE FROM prescriptions WHERE session_id = $1`
+	const findPrescriptionProductIds = `SELECT id, product_id FROM prescription_products
+	WHERE prescription_id = $1 AND deleted_at IS NULL
+	ORDER BY id
+	FOR UPDATE`
 
-	_, err = tx.ExecContext(ctx, deletePrescription, prescription.SessionId)
+	rows, err := tx.QueryContext(ctx, findPrescriptionProductIds, updatedPrescription.Id)
 	if err != nil {
 		return nil, err
 	}
+	idsByProductId := make(map[int64][]int64)
+	for rows.Next() {
+		var id, productId int64
+		if err := rows.Scan(&id, &productId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		idsByProductId[productId] = append(idsByProductId[productId], id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	const createPrescription = `
-	INSERT INTO prescriptions(session_id, symptoms, diagnosis)
-	VALUES ($1, $2, $3)
-	RETURNING id, session_id, symptoms, diagnosis, created_at, updated_at`
+	const updatePrescriptionProduct = `UPDATE prescription_products
+	SET note = $1, quantity = $2, dose_amount = $3, dose_unit = $4, frequency = $5, route = $6, duration_days = $7, meal_timing = $8, updated_at = now()
+	WHERE id = $9
+	RETURNING id, prescription_id, product_id, note, quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, created_at, updated_at`
+
+	prescriptionProducts := make([]*entity.PrescriptionProduct, 0, len(prescription.PrescriptionProducts))
+	newProducts := make([]*entity.PrescriptionProduct, 0)
+	for _, product := range prescription.PrescriptionProducts {
+		ids := idsByProductId[product.ProductId]
+		if len(ids) == 0 {
+			newProducts = append(newProducts, product)
+			continue
+		}
+		idsByProductId[product.ProductId] = ids[1:]
+
+		var updated entity.PrescriptionProduct
+		if err := tx.QueryRowContext(ctx, updatePrescriptionProduct,
+			product.Note, product.Quantity, product.DoseAmount, product.DoseUnit, product.Frequency,
+			product.Route, product.DurationDays, product.MealTiming, ids[0],
+		).Scan(
+			&updated.Id, &updated.PrescriptionId, &updated.ProductId, &updated.Note,
+			&updated.Quantity, &updated.DoseAmount, &updated.DoseUnit, &updated.Frequency,
+			&updated.Route, &updated.DurationDays, &updated.MealTiming,
+			&updated.CreatedAt, &updated.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		prescriptionProducts = append(prescriptionProducts, &updated)
+	}
 
-	row = tx.QueryRowContext(ctx, createPrescription, prescription.SessionId, prescription.Symptoms, prescription.Diagnosis)
-	if row.Err() != nil {
-		var errPgConn *pgconn.PgError
-		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
-			return nil, apperror.ErrConsultationSessionAlreadyHasPrescription
+	removedIds := make([]int64, 0)
+	for _, ids := range idsByProductId {
+		removedIds = append(removedIds, ids...)
+	}
+	if len(removedIds) > 0 {
+		const detachCartItems = `UPDATE cart_items SET prescription_product_id = NULL WHERE prescription_product_id = ANY ($1::int[])`
+
+		const deletePrescriptionProducts = `UPDATE prescription_products SET deleted_at = now() WHERE id = ANY ($1::int[])`
+
+		if _, err := tx.ExecContext(ctx, detachCartItems, pq.Array(removedIds)); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, deletePrescriptionProducts, pq.Array(removedIds)); err != nil {
+			return nil, err
 		}
-		return nil, row.Err()
 	}
 
-	var createdPrescription entity.Prescription
-	err = row.Scan(
-		&createdPrescription.Id, &createdPrescription.SessionId,
-		&createdPrescription.Symptoms, &createdPrescription.Diagnosis,
-		&createdPrescription.CreatedAt, &createdPrescription.UpdatedAt,
-	)
+	if len(newProducts) > 0 {
+		created, err := repo.createPrescriptionProducts(ctx, tx, updatedPrescription.Id, newProducts)
+		if err != nil {
+			return nil, err
+		}
+		prescriptionProducts = append(prescriptionProducts, created...)
+	}
+
+	updatedPrescription.PrescriptionProducts = prescriptionProducts
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
 
-	createPrescriptionProduct := `INSERT INTO prescription_products(prescription_id, product_id, note) VALUES `
+	return &updatedPrescription, err
+}
+
+func (repo *PrescriptionRepositoryImpl) createPrescriptionProducts(ctx context.Context, tx *sql.Tx, prescriptionId int64, products []*entity.PrescriptionProduct) ([]*entity.PrescriptionProduct, error) {
+	const colSize = 10
+	createPrescriptionProduct := `INSERT INTO prescription_products(prescription_id, product_id, note, quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing) VALUES `
 	values := make([]interface{}, 0)
 
 	indexPreparedStatement := 0
-	for index, prescriptionProduct := range prescription.PrescriptionProducts {
-		createPrescriptionProduct += fmt.Sprintf("($%d, $%d, $%d)", indexPreparedStatement+1, indexPreparedStatement+2, indexPreparedStatement+3)
-		indexPreparedStatement += 3
-		if index != len(prescription.PrescriptionProducts)-1 {
+	for index, prescriptionProduct := range products {
+		createPrescriptionProduct += "("
+		for col := 1; col <= colSize; col++ {
+			createPrescriptionProduct += fmt.Sprintf("$%d", indexPreparedStatement+col)
+			if col != colSize {
+				createPrescriptionProduct += ", "
+			}
+		}
+		createPrescriptionProduct += ")"
+		indexPreparedStatement += colSize
+		if index != len(products)-1 {
 			createPrescriptionProduct += ", "
 		}
 
 		values = append(values,
-			createdPrescription.Id, prescriptionProduct.ProductId, prescriptionProduct.Note,
+			prescriptionId, prescriptionProduct.ProductId, prescriptionProduct.Note,
+			prescriptionProduct.Quantity, prescriptionProduct.DoseAmount, prescriptionProduct.DoseUnit, prescriptionProduct.Frequency,
+			prescriptionProduct.Route, prescriptionProduct.DurationDays, prescriptionProduct.MealTiming,
 		)
 	}
-	createPrescriptionProduct += " RETURNING id, prescription_id, product_id, note, created_at, updated_at"
+	createPrescriptionProduct += ` RETURNING id, prescription_id, product_id, note, quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, created_at, updated_at`
 
 	rows, err := tx.QueryContext(ctx, createPrescriptionProduct, values...)
 	if err != nil {
@@ -334,8 +450,10 @@ func (repo *PrescriptionRepositoryImpl) UpdateBySessionId(ctx context.Context, p
 	for rows.Next() {
 		var prescriptionProduct entity.PrescriptionProduct
 		if err = rows.Scan(
-			&prescriptionProduct.Id, &prescriptionProduct.PrescriptionId, &prescriptionProduct.ProductId,
-			&prescriptionProduct.Note, &prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
+			&prescriptionProduct.Id, &prescriptionProduct.PrescriptionId, &prescriptionProduct.ProductId, &prescriptionProduct.Note,
+			&prescriptionProduct.Quantity, &prescriptionProduct.DoseAmount, &prescriptionProduct.DoseUnit, &prescriptionProduct.Frequency,
+			&prescriptionProduct.Route, &prescriptionProduct.DurationDays, &prescriptionProduct.MealTiming,
+			&prescriptionProduct.CreatedAt, &prescriptionProduct.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -345,12 +463,49 @@ func (repo *PrescriptionRepositoryImpl) UpdateBySessionId(ctx context.Context, p
 		return nil, err
 	}
 
-	createdPrescription.PrescriptionProducts = prescriptionProducts
+	return prescriptionProducts, nil
+}
 
-	err = tx.Commit()
+func redeemPrescriptionProduct(ctx context.Context, tx *sql.Tx, prescriptionProductId int64, quantity int32) error {
+	const redeem = `UPDATE prescription_products SET redeemed_quantity = redeemed_quantity + $1, updated_at = now()
+	WHERE id = $2 AND redeemed_quantity + $1 <= quantity AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, redeem, quantity, prescriptionProductId)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
+	if rowsAffected == 0 {
+		return apperror.ErrPrescriptionProductQuantityExceeded
+	}
+	return nil
+}
 
-	return &createdPrescription, err
+// releasePrescriptionProducts gives back the quantities redeemed by canceled orders so the prescription can be used again.
+// An order is only released once, the orders that were already released are left out
+func releasePrescriptionProducts(ctx context.Context, tx *sql.Tx, orderIds []int64) error {
+	if len(orderIds) == 0 {
+		return nil
+	}
+
+	const release = `WITH released_orders AS (
+		UPDATE orders SET prescription_released = true, updated_at = now()
+		WHERE id = ANY ($1::int[]) AND prescription_released = false
+		RETURNING id
+	)
+	UPDATE prescription_products
+	SET redeemed_quantity = GREATEST(prescription_products.redeemed_quantity - released.quantity, 0), updated_at = now()
+	FROM (
+		SELECT prescription_product_id, sum(quantity) AS quantity
+		FROM order_details
+		WHERE order_id IN (SELECT id FROM released_orders) AND prescription_product_id IS NOT NULL
+		GROUP BY prescription_product_id
+	) released
+	WHERE prescription_products.id = released.prescription_product_id`
+
+	_, err := tx.ExecContext(ctx, release, pq.Array(orderIds))
+	return err
 }
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type PrescriptionTemplateRepository interface {
+	Create(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error)
+	FindById(ctx context.Context, id int64) (*entity.PrescriptionTemplate, error)
+	FindAllByDoctorId(ctx context.Context, doctorId int64) ([]*entity.PrescriptionTemplate, error)
+	Update(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error)
+	Delete(ctx context.Context, id int64) error
+
+	CreateFavoriteProduct(ctx context.Context, doctorId, productId int64) error
+	DeleteFavoriteProduct(ctx context.Context, doctorId, productId int64) error
+	FindAllFavoriteProductsByDoctorId(ctx context.Context, doctorId int64) ([]*entity.Product, error)
+}
+
+type PrescriptionTemplateRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPrescriptionTemplateRepositoryImpl(db *sql.DB) *PrescriptionTemplateRepositoryImpl {
+	return &PrescriptionTemplateRepositoryImpl{db: db}
+}
+
+const findPrescriptionTemplateDetailed = `
+	SELECT prescription_templates.id, prescription_templates.doctor_id, prescription_templates.name, prescription_templates.created_at, prescription_templates.updated_at,
+		   cm.template_product_id, cm.template_product_product_id, cm.note, cm.quantity, cm.dose_amount, cm.dose_unit,
+		   cm.frequency, cm.route, cm.duration_days, cm.meal_timing, cm.created_at, cm.updated_at,
+		   cm.product_id, cm.product_name, cm.product_generic_name, cm.product_content, cm.product_image,
+		   cm.manufacturer_name
+	FROM prescription_templates
+		INNER JOIN LATERAL (
+			SELECT prescription_template_products.id AS template_product_id, product_id AS template_product_product_id, note,
+				   quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, prescription_template_products.created_at, prescription_template_products.updated_at,
+				   products.id AS product_id, products.name AS product_name, products.generic_name AS product_generic_name, products.content AS product_content, products.image AS product_image,
+				   manufacturers.name AS manufacturer_name
+			FROM prescription_template_products
+			INNER JOIN products ON prescription_template_products.product_id = products.id
+			INNER JOIN manufacturers ON products.manufacturer_id = manufacturers.id
+			WHERE prescription_template_products.prescription_template_id = prescription_templates.id
+			ORDER BY prescription_template_products.id ASC
+		) cm ON true
+	WHERE prescription_templates.deleted_at IS NULL `
+
+func (repo *PrescriptionTemplateRepositoryImpl) Create(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	const create = `
+	INSERT INTO prescription_templates(doctor_id, name)
+	VALUES ($1, $2)
+	RETURNING id, doctor_id, name, created_at, updated_at`
+
+	var created entity.PrescriptionTemplate
+	err = tx.QueryRowContext(ctx, create, template.DoctorId, template.Name).Scan(
+		&created.Id, &created.DoctorId, &created.Name, &created.CreatedAt, &created.UpdatedAt,
+	)
+	if err != nil {
+		return nil, wrapPrescriptionTemplateError(err)
+	}
+
+	products, err := createPrescriptionTemplateProducts(ctx, tx, created.Id, template.Products)
+	if err != nil {
+		return nil, err
+	}
+	created.Products = products
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.PrescriptionTemplate, error) {
+	query := findPrescriptionTemplateDetailed + `AND prescription_templates.id = $1`
+
+	templates, err := repo.findAllDetailed(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, apperror.ErrRecordNotFound
+	}
+	return templates[0], nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) FindAllByDoctorId(ctx context.Context, doctorId int64) ([]*entity.PrescriptionTemplate, error) {
+	query := findPrescriptionTemplateDetailed + `AND prescription_templates.doctor_id = $1 ORDER BY lower(prescription_templates.name), prescription_templates.id`
+
+	return repo.findAllDetailed(ctx, query, doctorId)
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) Update(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	const update = `
+	UPDATE prescription_templates SET name = $1, updated_at = now()
+	WHERE id = $2 AND deleted_at IS NULL
+	RETURNING id, doctor_id, name, created_at, updated_at`
+
+	var updated entity.PrescriptionTemplate
+	err = tx.QueryRowContext(ctx, update, template.Name, template.Id).Scan(
+		&updated.Id, &updated.DoctorId, &updated.Name, &updated.CreatedAt, &updated.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, wrapPrescriptionTemplateError(err)
+	}
+
+	const deleteProducts = `DELETE FROM prescription_template_products WHERE prescription_template_id = $1`
+	if _, err = tx.ExecContext(ctx, deleteProducts, updated.Id); err != nil {
+		return nil, err
+	}
+
+	products, err := createPrescriptionTemplateProducts(ctx, tx, updated.Id, template.Products)
+	if err != nil {
+		return nil, err
+	}
+	updated.Products = products
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	const deleteById = `UPDATE prescription_templates SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, deleteById, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return apperror.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) CreateFavoriteProduct(ctx context.Context, doctorId, productId int64) error {
+	const create = `
+	INSERT INTO doctor_favorite_products(doctor_id, product_id) VALUES ($1, $2)
+	ON CONFLICT (doctor_id, product_id) DO NOTHING`
+
+	_, err := repo.db.ExecContext(ctx, create, doctorId, productId)
+	return err
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) DeleteFavoriteProduct(ctx context.Context, doctorId, productId int64) error {
+	const deleteFavorite = `DELETE FROM doctor_favorite_products WHERE doctor_id = $1 AND product_id = $2`
+
+	result, err := repo.db.ExecContext(ctx, deleteFavorite, doctorId, productId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return apperror.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) FindAllFavoriteProductsByDoctorId(ctx context.Context, doctorId int64) ([]*entity.Product, error) {
+	const findAll = `
+	SELECT products.id, products.name, products.generic_name, products.content, products.manufacturer_id, products.description, 
+		   products.drug_classification_id, products.product_category_id, products.drug_form, products.unit_in_pack, products.selling_unit, products.weight, products.length, products.width, products.height, products.image
+	FROM doctor_favorite_products
+		INNER JOIN products ON doctor_favorite_products.product_id = products.id
+	WHERE doctor_favorite_products.doctor_id = $1 AND products.deleted_at IS NULL
+	ORDER BY doctor_favorite_products.created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAll, doctorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.Product, 0)
+	for rows.Next() {
+		var product entity.Product
+		if err := rows.Scan(
+			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
+			&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *PrescriptionTemplateRepositoryImpl) findAllDetailed(ctx context.Context, query string, args ...interface{}) ([]*entity.PrescriptionTemplate, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]*entity.PrescriptionTemplate, 0)
+	var current *entity.PrescriptionTemplate
+	for rows.Next() {
+		var (
+			template        entity.PrescriptionTemplate
+			templateProduct entity.PrescriptionTemplateProduct
+			product         entity.Product
+			manufacturer    entity.Manufacturer
+		)
+		if err = rows.Scan(
+			&template.Id, &template.DoctorId, &template.Name, &template.CreatedAt, &template.UpdatedAt,
+			&templateProduct.Id, &templateProduct.ProductId, &templateProduct.Note,
+			&templateProduct.Quantity, &templateProduct.DoseAmount, &templateProduct.DoseUnit, &templateProduct.Frequency,
+			&templateProduct.Route, &templateProduct.DurationDays, &templateProduct.MealTiming,
+			&templateProduct.CreatedAt, &templateProduct.UpdatedAt,
+			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.Image,
+			&manufacturer.Name,
+		); err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.Id != template.Id {
+			template.Products = make([]*entity.PrescriptionTemplateProduct, 0)
+			current = &template
+			templates = append(templates, current)
+		}
+
+		product.Manufacturer = &manufacturer
+		templateProduct.PrescriptionTemplateId = current.Id
+		templateProduct.Product = &product
+		current.Products = append(current.Products, &templateProduct)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func createPrescriptionTemplateProducts(ctx context.Context, tx *sql.Tx, templateId int64, products []*entity.PrescriptionTemplateProduct) ([]*entity.PrescriptionTemplateProduct, error) {
+	const colSize = 10
+	createTemplateProduct := `INSERT INTO prescription_template_products(prescription_template_id, product_id, note, quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing) VALUES `
+	values := make([]interface{}, 0)
+
+	indexPreparedStatement := 0
+	for index, templateProduct := range products {
+		createTemplateProduct += "("
+		for col := 1; col <= colSize; col++ {
+			createTemplateProduct += fmt.Sprintf("$%d", indexPreparedStatement+col)
+			if col != colSize {
+				createTemplateProduct += ", "
+			}
+		}
+		createTemplateProduct += ")"
+		indexPreparedStatement += colSize
+		if index != len(products)-1 {
+			createTemplateProduct += ", "
+		}
+
+		values = append(values,
+			templateId, templateProduct.ProductId, templateProduct.Note,
+			templateProduct.Quantity, templateProduct.DoseAmount, templateProduct.DoseUnit, templateProduct.Frequency,
+			templateProduct.Route, templateProduct.DurationDays, templateProduct.MealTiming,
+		)
+	}
+	createTemplateProduct += ` RETURNING id, prescription_template_id, product_id, note, quantity, dose_amount, dose_unit, frequency, route, duration_days, meal_timing, created_at, updated_at`
+
+	rows, err := tx.QueryContext(ctx, createTemplateProduct, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templateProducts := make([]*entity.PrescriptionTemplateProduct, 0)
+	for rows.Next() {
+		var templateProduct entity.PrescriptionTemplateProduct
+		if err = rows.Scan(
+			&templateProduct.Id, &templateProduct.PrescriptionTemplateId, &templateProduct.ProductId, &templateProduct.Note,
+			&templateProduct.Quantity, &templateProduct.DoseAmount, &templateProduct.DoseUnit, &templateProduct.Frequency,
+			&templateProduct.Route, &templateProduct.DurationDays, &templateProduct.MealTiming,
+			&templateProduct.CreatedAt, &templateProduct.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		templateProducts = append(templateProducts, &templateProduct)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templateProducts, nil
+}
+
+func wrapPrescriptionTemplateError(err error) error {
+	var errPgConn *pgconn.PgError
+	if errors.As(err, &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+		return apperror.ErrPrescriptionTemplateNameAlreadyUsed
+	}
+	return err
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type ProductAlertRepository interface {
+	Create(ctx context.Context, alert entity.ProductAlert, latitude, longitude string) (*entity.ProductAlert, error)
+	FindById(ctx context.Context, id int64) (*entity.ProductAlert, error)
+	FindAllByUserId(ctx context.Context, userId int64) ([]*entity.ProductAlert, error)
+	Delete(ctx context.Context, id int64) error
+	FindAllMatches(ctx context.Context) ([]*entity.ProductAlertMatch, int64, error)
+	SaveMatches(ctx context.Context, lastChangeId int64, matches []*entity.ProductAlertMatch, notifications []*entity.UserNotification) error
+}
+
+type ProductAlertRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductAlertRepositoryImpl(db *sql.DB) *ProductAlertRepositoryImpl {
+	return &ProductAlertRepositoryImpl{db: db}
+}
+
+// nearbyProductAvailability is whether a product is in stock in the pharmacies around a location and its lowest
+// price there, the product id and the location are filled in by the query using it
+const nearbyProductAvailability = `SELECT count(pharmacy_products.id) > 0 AS is_available, min(pharmacy_products.price) AS lowest_price
+	FROM pharmacy_products
+	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
+	WHERE pharmacy_products.product_id = %s AND pharmacy_products.is_active = true AND pharmacy_products.stock > 0
+	AND pharmacy_products.deleted_at IS NULL AND pharmacies.deleted_at IS NULL
+	AND distance(pharmacies.latitude, pharmacies.longitude, %s, %s) <= %s`
+
+// Create subscribes the user to the product, the availability around the location is taken as already told so the
+// user is only notified about later changes
+func (repo *ProductAlertRepositoryImpl) Create(ctx context.Context, alert entity.ProductAlert, latitude, longitude string) (*entity.ProductAlert, error) {
+	create := fmt.Sprintf(`INSERT INTO product_alerts(user_id, product_id, notify_by_email, is_available, lowest_price)
+	SELECT $1, $2, $3, nearby.is_available, nearby.lowest_price
+	FROM (%s) nearby
+	RETURNING id, user_id, product_id, notify_by_email, is_available, lowest_price, created_at, updated_at, deleted_at`,
+		fmt.Sprintf(nearbyProductAvailability, "$2", "$4", "$5", "$6"),
+	)
+
+	var created entity.ProductAlert
+	err := repo.db.QueryRowContext(ctx, create,
+		alert.UserId, alert.ProductId, alert.NotifyByEmail, latitude, longitude, appconstant.ClosestPharmacyRangeRadius,
+	).Scan(
+		&created.Id, &created.UserId, &created.ProductId, &created.NotifyByEmail, &created.IsAvailable, &created.LowestPrice,
+		&created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+	)
+	if err != nil {
+		var errPgConn *pgconn.PgError
+		if errors.As(err, &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+			return nil, apperror.ErrProductAlertAlreadyExists
+		}
+		return nil, err
+	}
+	return &created, nil
+}
+
+const findProductAlertWithProduct = `SELECT product_alerts.id, user_id, product_id, notify_by_email, is_available, lowest_price,
+       product_alerts.created_at, product_alerts.updated_at, product_alerts.deleted_at,
+       products.name, products.generic_name, products.content, products.image
+	FROM product_alerts
+	INNER JOIN products ON product_alerts.product_id = products.id `
+
+func (repo *ProductAlertRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ProductAlert, error) {
+	const findById = findProductAlertWithProduct + `WHERE product_alerts.id = $1 AND product_alerts.deleted_at IS NULL`
+
+	alert, err := repo.scan(repo.db.QueryRowContext(ctx, findById, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (repo *ProductAlertRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64) ([]*entity.ProductAlert, error) {
+	const findAllByUserId = findProductAlertWithProduct +
+		`WHERE product_alerts.user_id = $1 AND product_alerts.deleted_at IS NULL ORDER BY product_alerts.created_at DESC, product_alerts.id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ProductAlert, 0)
+	for rows.Next() {
+		alert, err := repo.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *ProductAlertRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	const deleteAlert = `UPDATE product_alerts SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, deleteAlert, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperror.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindAllMatches takes the stock and price changes queued so far and lists the alerts of products that changed in a
+// pharmacy around the main address of their user, with the availability of the product there right now. The id of
+// the last change taken is returned so SaveMatches only clears the changes that were matched
+func (repo *ProductAlertRepositoryImpl) FindAllMatches(ctx context.Context) ([]*entity.ProductAlertMatch, int64, error) {
+	const findLastChangeId = `SELECT COALESCE(max(id), 0) FROM pharmacy_product_changes`
+
+	findAllMatches := fmt.Sprintf(`WITH changed_pharmacies AS (SELECT DISTINCT pharmacy_products.product_id, pharmacies.latitude, pharmacies.longitude
+		FROM pharmacy_product_changes
+		INNER JOIN pharmacy_products ON pharmacy_product_changes.pharmacy_product_id = pharmacy_products.id
+		INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
+		WHERE pharmacy_product_changes.id <= $1),
+	candidates AS (SELECT DISTINCT product_alerts.id, addresses.latitude, addresses.longitude
+		FROM product_alerts
+		INNER JOIN addresses ON product_alerts.user_id = addresses.profile_id AND addresses.status = $2 AND addresses.deleted_at IS NULL
+		INNER JOIN changed_pharmacies ON product_alerts.product_id = changed_pharmacies.product_id
+		WHERE product_alerts.deleted_at IS NULL
+		AND distance(changed_pharmacies.latitude, changed_pharmacies.longitude, addresses.latitude, addresses.longitude) <= $3)
+	SELECT product_alerts.id, product_alerts.user_id, product_alerts.product_id, product_alerts.notify_by_email,
+	       product_alerts.is_available, product_alerts.lowest_price, products.name, users.email,
+	       nearby.is_available, nearby.lowest_price
+	FROM candidates
+	INNER JOIN product_alerts ON candidates.id = product_alerts.id
+	INNER JOIN products ON product_alerts.product_id = products.id
+	INNER JOIN users ON product_alerts.user_id = users.id
+	CROSS JOIN LATERAL (%s) nearby
+	ORDER BY product_alerts.id`,
+		fmt.Sprintf(nearbyProductAvailability, "product_alerts.product_id", "candidates.latitude", "candidates.longitude", "$3"),
+	)
+
+	var lastChangeId int64
+	if err := repo.db.QueryRowContext(ctx, findLastChangeId).Scan(&lastChangeId); err != nil {
+		return nil, 0, err
+	}
+
+	matches := make([]*entity.ProductAlertMatch, 0)
+	if lastChangeId == 0 {
+		return matches, 0, nil
+	}
+
+	rows, err := repo.db.QueryContext(ctx, findAllMatches,
+		lastChangeId, appconstant.MainAddressStatusId, appconstant.ClosestPharmacyRangeRadius,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		alert := entity.ProductAlert{Product: &entity.Product{}}
+		match := entity.ProductAlertMatch{Alert: &alert}
+		if err := rows.Scan(
+			&alert.Id, &alert.UserId, &alert.ProductId, &alert.NotifyByEmail, &alert.IsAvailable, &alert.LowestPrice,
+			&alert.Product.Name, &match.Email, &match.IsAvailable, &match.LowestPrice,
+		); err != nil {
+			return nil, 0, err
+		}
+		alert.Product.Id = alert.ProductId
+		matches = append(matches, &match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return matches, lastChangeId, nil
+}
+
+// SaveMatches records the availability of the matches as told, adds the in-app notifications and clears the
+// changes up to lastChangeId, all at once so a failed run is matched again on the next one
+func (repo *ProductAlertRepositoryImpl) SaveMatches(ctx context.Context, lastChangeId int64, matches []*entity.ProductAlertMatch, notifications []*entity.UserNotification) error {
+	const updateAlert = `UPDATE product_alerts SET is_available = $1, lowest_price = $2, updated_at = now()
+	WHERE id = $3 AND deleted_at IS NULL`
+
+	const createNotification = `INSERT INTO user_notifications(user_id, product_id, title, message)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, is_read, created_at, updated_at`
+
+	const deleteChanges = `DELETE FROM pharmacy_product_changes WHERE id <= $1`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, match := range matches {
+		if _, err := tx.ExecContext(ctx, updateAlert, match.IsAvailable, match.LowestPrice, match.Alert.Id); err != nil {
+			return err
+		}
+	}
+
+	for _, notification := range notifications {
+		if err := tx.QueryRowContext(ctx, createNotification,
+			notification.UserId, notification.ProductId, notification.Title, notification.Message,
+		).Scan(&notification.Id, &notification.IsRead, &notification.CreatedAt, &notification.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteChanges, lastChangeId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *ProductAlertRepositoryImpl) scan(row interface{ Scan(...any) error }) (*entity.ProductAlert, error) {
+	alert := entity.ProductAlert{Product: &entity.Product{}}
+	if err := row.Scan(
+		&alert.Id, &alert.UserId, &alert.ProductId, &alert.NotifyByEmail, &alert.IsAvailable, &alert.LowestPrice,
+		&alert.CreatedAt, &alert.UpdatedAt, &alert.DeletedAt,
+		&alert.Product.Name, &alert.Product.GenericName, &alert.Product.Content, &alert.Product.Image,
+	); err != nil {
+		return nil, err
+	}
+	alert.Product.Id = alert.ProductId
+	return &alert, nil
+}
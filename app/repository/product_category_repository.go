@@ -17,6 +17,7 @@ type ProductCategoryRepository interface {
 	FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.ProductCategory, error)
 	CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
 	Update(ctx context.Context, category entity.ProductCategory) (*entity.ProductCategory, error)
+	UpdateParent(ctx context.Context, id int64, parentId sql.NullInt64) (*entity.ProductCategory, error)
 	Delete(ctx context.Context, id int64) error
 }
 
@@ -30,12 +31,12 @@ func NewProductCategoryRepositoryImpl(db *sql.DB) *ProductCategoryRepositoryImpl
 
 func (repo *ProductCategoryRepositoryImpl) Create(ctx context.Context, category entity.ProductCategory) (*entity.ProductCategory, error) {
 	const create = `
-		INSERT INTO product_categories(name)
-		VALUES ($1)
-		RETURNING id, name, created_at, updated_at, deleted_at
+		INSERT INTO product_categories(name, parent_id)
+		VALUES ($1, $2)
+		RETURNING id, name, parent_id, created_at, updated_at, deleted_at
 		`
 
-	row := repo.db.QueryRowContext(ctx, create, category.Name)
+	row := repo.db.QueryRowContext(ctx, create, category.Name, category.ParentId)
 	if row.Err() != nil {
 		var errPgConn *pgconn.PgError
 		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
@@ -46,13 +47,13 @@ func (repo *ProductCategoryRepositoryImpl) Create(ctx context.Context, category
 
 	var created entity.ProductCategory
 	err := row.Scan(
-		&created.Id, &created.Name, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+		&created.Id, &created.Name, &created.ParentId, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
 	)
 	return &created, err
 }
 
 func (repo *ProductCategoryRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ProductCategory, error) {
-	const getById = `SELECT id, name, created_at, updated_at, deleted_at FROM product_categories WHERE id = $1 AND deleted_at IS NULL`
+	const getById = `SELECT id, name, parent_id, created_at, updated_at, deleted_at FROM product_categories WHERE id = $1 AND deleted_at IS NULL`
 
 	row := repo.db.QueryRowContext(ctx, getById, id)
 	if row.Err() != nil {
@@ -61,7 +62,7 @@ func (repo *ProductCategoryRepositoryImpl) FindById(ctx context.Context, id int6
 
 	var category entity.ProductCategory
 	err := row.Scan(
-		&category.Id, &category.Name, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt,
+		&category.Id, &category.Name, &category.ParentId, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -74,7 +75,7 @@ func (repo *ProductCategoryRepositoryImpl) FindById(ctx context.Context, id int6
 
 func (repo *ProductCategoryRepositoryImpl) FindAllWithoutParams(ctx context.Context) ([]*entity.ProductCategory, error) {
 	const findAll = `
-		SELECT id, name, created_at, updated_at, deleted_at FROM product_categories WHERE deleted_at IS NULL
+		SELECT id, name, parent_id, created_at, updated_at, deleted_at FROM product_categories WHERE deleted_at IS NULL
 		`
 
 	rows, err := repo.db.QueryContext(ctx, findAll)
@@ -87,7 +88,7 @@ func (repo *ProductCategoryRepositoryImpl) FindAllWithoutParams(ctx context.Cont
 	for rows.Next() {
 		var category entity.ProductCategory
 		if err := rows.Scan(
-			&category.Id, &category.Name, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt,
+			&category.Id, &category.Name, &category.ParentId, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -103,7 +104,7 @@ func (repo *ProductCategoryRepositoryImpl) FindAllWithoutParams(ctx context.Cont
 }
 
 func (repo *ProductCategoryRepositoryImpl) FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.ProductCategory, error) {
-	initQuery := `SELECT id, name FROM product_categories WHERE deleted_at IS NULL `
+	initQuery := `SELECT id, name, parent_id FROM product_categories WHERE deleted_at IS NULL `
 	query, values := buildQuery(initQuery, &entity.ProductCategory{}, param, true, true)
 
 	rows, err := repo.db.QueryContext(ctx, query, values...)
@@ -116,7 +117,7 @@ func (repo *ProductCategoryRepositoryImpl) FindAll(ctx context.Context, param *q
 	for rows.Next() {
 		var category entity.ProductCategory
 		if err := rows.Scan(
-			&category.Id, &category.Name,
+			&category.Id, &category.Name, &category.ParentId,
 		); err != nil {
 			return nil, err
 		}
@@ -157,7 +158,7 @@ func (repo *ProductCategoryRepositoryImpl) CountFindAll(ctx context.Context, par
 
 func (repo *ProductCategoryRepositoryImpl) Update(ctx context.Context, category entity.ProductCategory) (*entity.ProductCategory, error) {
 	const update = `UPDATE product_categories SET name = $1, updated_at = now() WHERE id = $2
-		RETURNING id, name, created_at, updated_at, deleted_at`
+		RETURNING id, name, parent_id, created_at, updated_at, deleted_at`
 
 	row := repo.db.QueryRowContext(ctx, update, category.Name, category.Id)
 	if row.Err() != nil {
@@ -170,11 +171,52 @@ func (repo *ProductCategoryRepositoryImpl) Update(ctx context.Context, category
 
 	var updated entity.ProductCategory
 	err := row.Scan(
-		&updated.Id, &updated.Name, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+		&updated.Id, &updated.Name, &updated.ParentId, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
 	)
 	return &updated, err
 }
 
+// UpdateParent moves the category under another parent, its descendants move along since they keep their own parents.
+// Moves are serialized by a lock that conflicts with itself, and the update is skipped when the category is an
+// ancestor of the new parent, so two concurrent moves can never create a cycle
+func (repo *ProductCategoryRepositoryImpl) UpdateParent(ctx context.Context, id int64, parentId sql.NullInt64) (*entity.ProductCategory, error) {
+	const lockCategories = `LOCK TABLE product_categories IN SHARE ROW EXCLUSIVE MODE`
+	const updateParent = `UPDATE product_categories SET parent_id = $1, updated_at = now()
+		WHERE id = $2 AND ($1::BIGINT IS NULL OR NOT $2 = ANY (product_category_path_ids($1)))
+		RETURNING id, name, parent_id, created_at, updated_at, deleted_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockCategories); err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx, updateParent, parentId, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var updated entity.ProductCategory
+	err = row.Scan(
+		&updated.Id, &updated.Name, &updated.ParentId, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrProductCategoryInvalidParent
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
 func (repo *ProductCategoryRepositoryImpl) Delete(ctx context.Context, id int64) error {
 	const checkProducts = `SELECT count(*) FROM products WHERE product_category_id = $1`
 	row := repo.db.QueryRowContext(ctx, checkProducts, id)
@@ -187,6 +229,16 @@ func (repo *ProductCategoryRepositoryImpl) Delete(ctx context.Context, id int64)
 		return apperror.ErrProductCategoryStillUsedByProducts
 	}
 
+	const checkChildren = `SELECT count(*) FROM product_categories WHERE parent_id = $1 AND deleted_at IS NULL`
+	row = repo.db.QueryRowContext(ctx, checkChildren, id)
+	err = row.Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperror.ErrProductCategoryStillHasChildren
+	}
+
 	const deletePC = `UPDATE product_categories SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
 	_, err = repo.db.ExecContext(ctx, deletePC, id)
 	return err
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type ProductImageRepository interface {
+	Create(ctx context.Context, image entity.ProductImage) (*entity.ProductImage, error)
+	ReplaceCover(ctx context.Context, image entity.ProductImage) (*entity.ProductImage, error)
+	FindById(ctx context.Context, id int64) (*entity.ProductImage, error)
+	FindAllByProductIds(ctx context.Context, productIds []int64) ([]*entity.ProductImage, error)
+	UpdatePositions(ctx context.Context, productId int64, imageIds []int64) ([]*entity.ProductImage, error)
+	Delete(ctx context.Context, image entity.ProductImage) error
+}
+
+type ProductImageRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductImageRepositoryImpl(db *sql.DB) *ProductImageRepositoryImpl {
+	return &ProductImageRepositoryImpl{db: db}
+}
+
+// Create adds the image at the end of the gallery of its product
+func (repo *ProductImageRepositoryImpl) Create(ctx context.Context, image entity.ProductImage) (*entity.ProductImage, error) {
+	const create = `INSERT INTO product_images(product_id, position, original_url, medium_url, thumbnail_url)
+	SELECT $1, COALESCE(MAX(position), 0) + 1, $2, $3, $4
+	FROM product_images WHERE product_id = $1 AND deleted_at IS NULL
+	RETURNING id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductForImages(ctx, tx, image.ProductId); err != nil {
+		return nil, err
+	}
+
+	var created entity.ProductImage
+	err = tx.QueryRowContext(ctx, create, image.ProductId, image.OriginalUrl, image.MediumUrl, image.ThumbnailUrl).Scan(
+		&created.Id, &created.ProductId, &created.Position, &created.OriginalUrl, &created.MediumUrl, &created.ThumbnailUrl,
+		&created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateProductCoverImage(ctx, tx, image.ProductId); err != nil {
+		return nil, err
+	}
+	return &created, tx.Commit()
+}
+
+// ReplaceCover sets the renditions of the first image of the gallery, the image is added when the gallery is empty
+func (repo *ProductImageRepositoryImpl) ReplaceCover(ctx context.Context, image entity.ProductImage) (*entity.ProductImage, error) {
+	const replaceCover = `UPDATE product_images SET original_url = $1, medium_url = $2, thumbnail_url = $3, updated_at = now()
+	WHERE id = (SELECT id FROM product_images WHERE product_id = $4 AND deleted_at IS NULL ORDER BY position, id LIMIT 1)
+	RETURNING id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at`
+
+	const create = `INSERT INTO product_images(product_id, position, original_url, medium_url, thumbnail_url)
+	VALUES ($1, 1, $2, $3, $4)
+	RETURNING id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductForImages(ctx, tx, image.ProductId); err != nil {
+		return nil, err
+	}
+
+	var replaced entity.ProductImage
+	err = tx.QueryRowContext(ctx, replaceCover, image.OriginalUrl, image.MediumUrl, image.ThumbnailUrl, image.ProductId).Scan(
+		&replaced.Id, &replaced.ProductId, &replaced.Position, &replaced.OriginalUrl, &replaced.MediumUrl, &replaced.ThumbnailUrl,
+		&replaced.CreatedAt, &replaced.UpdatedAt, &replaced.DeletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.QueryRowContext(ctx, create, image.ProductId, image.OriginalUrl, image.MediumUrl, image.ThumbnailUrl).Scan(
+			&replaced.Id, &replaced.ProductId, &replaced.Position, &replaced.OriginalUrl, &replaced.MediumUrl, &replaced.ThumbnailUrl,
+			&replaced.CreatedAt, &replaced.UpdatedAt, &replaced.DeletedAt,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateProductCoverImage(ctx, tx, image.ProductId); err != nil {
+		return nil, err
+	}
+	return &replaced, tx.Commit()
+}
+
+func (repo *ProductImageRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ProductImage, error) {
+	const findById = `SELECT id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at
+	FROM product_images WHERE id = $1 AND deleted_at IS NULL`
+
+	var image entity.ProductImage
+	err := repo.db.QueryRowContext(ctx, findById, id).Scan(
+		&image.Id, &image.ProductId, &image.Position, &image.OriginalUrl, &image.MediumUrl, &image.ThumbnailUrl,
+		&image.CreatedAt, &image.UpdatedAt, &image.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (repo *ProductImageRepositoryImpl) FindAllByProductIds(ctx context.Context, productIds []int64) ([]*entity.ProductImage, error) {
+	images := make([]*entity.ProductImage, 0)
+	if len(productIds) == 0 {
+		return images, nil
+	}
+
+	placeholders := make([]string, 0, len(productIds))
+	values := make([]interface{}, 0, len(productIds))
+	for index, productId := range productIds {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", index+1))
+		values = append(values, productId)
+	}
+	findAllByProductIds := fmt.Sprintf(`SELECT id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at
+	FROM product_images WHERE product_id IN (%s) AND deleted_at IS NULL
+	ORDER BY product_id, position, id`, strings.Join(placeholders, ","))
+
+	rows, err := repo.db.QueryContext(ctx, findAllByProductIds, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var image entity.ProductImage
+		if err := rows.Scan(
+			&image.Id, &image.ProductId, &image.Position, &image.OriginalUrl, &image.MediumUrl, &image.ThumbnailUrl,
+			&image.CreatedAt, &image.UpdatedAt, &image.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		images = append(images, &image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// UpdatePositions orders the gallery of the product as listed, imageIds has to list every image of the product once
+func (repo *ProductImageRepositoryImpl) UpdatePositions(ctx context.Context, productId int64, imageIds []int64) ([]*entity.ProductImage, error) {
+	const findIds = `SELECT id FROM product_images WHERE product_id = $1 AND deleted_at IS NULL`
+
+	const updatePosition = `UPDATE product_images SET position = $1, updated_at = now() WHERE id = $2
+	RETURNING id, product_id, position, original_url, medium_url, thumbnail_url, created_at, updated_at, deleted_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductForImages(ctx, tx, productId); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, findIds, productId)
+	if err != nil {
+		return nil, err
+	}
+	currentIds := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		currentIds[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(imageIds) != len(currentIds) {
+		return nil, apperror.ErrProductImageOrderMismatch
+	}
+	for _, id := range imageIds {
+		if !currentIds[id] {
+			return nil, apperror.ErrProductImageOrderMismatch
+		}
+		// listing an image twice would leave another image out
+		delete(currentIds, id)
+	}
+
+	images := make([]*entity.ProductImage, 0, len(imageIds))
+	for index, id := range imageIds {
+		var image entity.ProductImage
+		if err := tx.QueryRowContext(ctx, updatePosition, index+1, id).Scan(
+			&image.Id, &image.ProductId, &image.Position, &image.OriginalUrl, &image.MediumUrl, &image.ThumbnailUrl,
+			&image.CreatedAt, &image.UpdatedAt, &image.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		images = append(images, &image)
+	}
+
+	if err := updateProductCoverImage(ctx, tx, productId); err != nil {
+		return nil, err
+	}
+	return images, tx.Commit()
+}
+
+// Delete removes the image from the gallery, the last image of a product cannot be removed
+func (repo *ProductImageRepositoryImpl) Delete(ctx context.Context, image entity.ProductImage) error {
+	const countImages = `SELECT count(id) FROM product_images WHERE product_id = $1 AND deleted_at IS NULL`
+
+	const deleteImage = `UPDATE product_images SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductForImages(ctx, tx, image.ProductId); err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.QueryRowContext(ctx, countImages, image.ProductId).Scan(&count); err != nil {
+		return err
+	}
+	if count <= 1 {
+		return apperror.ErrProductImageLastImage
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteImage, image.Id); err != nil {
+		return err
+	}
+
+	if err := updateProductCoverImage(ctx, tx, image.ProductId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockProductForImages serializes the changes to the gallery of one product
+func lockProductForImages(ctx context.Context, tx *sql.Tx, productId int64) error {
+	const lockProduct = `SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, lockProduct, productId).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrRecordNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// updateProductCoverImage keeps products.image on the original rendition of the first image of the gallery
+func updateProductCoverImage(ctx context.Context, tx *sql.Tx, productId int64) error {
+	const updateCover = `UPDATE products
+	SET image = COALESCE((SELECT original_url FROM product_images
+		WHERE product_id = $1 AND deleted_at IS NULL ORDER BY position, id LIMIT 1), image),
+		updated_at = now()
+	WHERE id = $1`
+
+	_, err := tx.ExecContext(ctx, updateCover, productId)
+	return err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type ProductImportRepository interface {
+	Create(ctx context.Context, productImport entity.ProductImport) (*entity.ProductImport, error)
+	FindById(ctx context.Context, id int64) (*entity.ProductImport, error)
+	FindAllByAdminId(ctx context.Context, adminId int64) ([]*entity.ProductImport, error)
+	UpdateProgress(ctx context.Context, productImport entity.ProductImport, rows []*entity.ProductImportRow) error
+}
+
+type ProductImportRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductImportRepositoryImpl(db *sql.DB) *ProductImportRepositoryImpl {
+	return &ProductImportRepositoryImpl{db: db}
+}
+
+func (repo *ProductImportRepositoryImpl) Create(ctx context.Context, productImport entity.ProductImport) (*entity.ProductImport, error) {
+	const create = `INSERT INTO product_imports(admin_id, file_name, is_dry_run, status, total_rows)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, admin_id, file_name, is_dry_run, status, total_rows, processed_rows, succeeded_rows, failed_rows, error_message, created_at, updated_at`
+
+	row := repo.db.QueryRowContext(ctx, create,
+		productImport.AdminId, productImport.FileName, productImport.IsDryRun, productImport.Status, productImport.TotalRows,
+	)
+	var created entity.ProductImport
+	err := row.Scan(
+		&created.Id, &created.AdminId, &created.FileName, &created.IsDryRun, &created.Status, &created.TotalRows,
+		&created.ProcessedRows, &created.SucceededRows, &created.FailedRows, &created.ErrorMessage, &created.CreatedAt, &created.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (repo *ProductImportRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ProductImport, error) {
+	const findById = `SELECT id, admin_id, file_name, is_dry_run, status, total_rows, processed_rows, succeeded_rows, failed_rows, error_message, created_at, updated_at
+	FROM product_imports WHERE id = $1`
+
+	const findRows = `SELECT id, product_import_id, row_number, status, product_id, errors
+	FROM product_import_rows WHERE product_import_id = $1 ORDER BY row_number`
+
+	row := repo.db.QueryRowContext(ctx, findById, id)
+	var productImport entity.ProductImport
+	err := row.Scan(
+		&productImport.Id, &productImport.AdminId, &productImport.FileName, &productImport.IsDryRun, &productImport.Status, &productImport.TotalRows,
+		&productImport.ProcessedRows, &productImport.SucceededRows, &productImport.FailedRows, &productImport.ErrorMessage, &productImport.CreatedAt, &productImport.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := repo.db.QueryContext(ctx, findRows, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productImport.Rows = make([]*entity.ProductImportRow, 0)
+	for rows.Next() {
+		var (
+			importRow entity.ProductImportRow
+			errs      string
+		)
+		if err := rows.Scan(
+			&importRow.Id, &importRow.ProductImportId, &importRow.RowNumber, &importRow.Status, &importRow.ProductId, &errs,
+		); err != nil {
+			return nil, err
+		}
+		if errs != "" {
+			importRow.Errors = strings.Split(errs, appconstant.ProductImportRowErrorsSeparator)
+		}
+		productImport.Rows = append(productImport.Rows, &importRow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &productImport, nil
+}
+
+func (repo *ProductImportRepositoryImpl) FindAllByAdminId(ctx context.Context, adminId int64) ([]*entity.ProductImport, error) {
+	const findAllByAdminId = `SELECT id, admin_id, file_name, is_dry_run, status, total_rows, processed_rows, succeeded_rows, failed_rows, error_message, created_at, updated_at
+	FROM product_imports WHERE admin_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByAdminId, adminId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ProductImport, 0)
+	for rows.Next() {
+		var productImport entity.ProductImport
+		if err := rows.Scan(
+			&productImport.Id, &productImport.AdminId, &productImport.FileName, &productImport.IsDryRun, &productImport.Status, &productImport.TotalRows,
+			&productImport.ProcessedRows, &productImport.SucceededRows, &productImport.FailedRows, &productImport.ErrorMessage, &productImport.CreatedAt, &productImport.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &productImport)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpdateProgress saves the report of a batch of processed rows together with the new counters of the import
+func (repo *ProductImportRepositoryImpl) UpdateProgress(ctx context.Context, productImport entity.ProductImport, rows []*entity.ProductImportRow) error {
+	const updateProgress = `UPDATE product_imports
+	SET status = $1, processed_rows = $2, succeeded_rows = $3, failed_rows = $4, error_message = $5, updated_at = now()
+	WHERE id = $6`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(rows) > 0 {
+		var query strings.Builder
+		query.WriteString("INSERT INTO product_import_rows(product_import_id, row_number, status, product_id, errors) VALUES ")
+		values := make([]interface{}, 0, len(rows)*5)
+		for index, row := range rows {
+			if index > 0 {
+				query.WriteString(", ")
+			}
+			offset := index * 5
+			query.WriteString(fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4, offset+5))
+			values = append(values, productImport.Id, row.RowNumber, row.Status, row.ProductId, row.JoinErrors())
+		}
+
+		if _, err := tx.ExecContext(ctx, query.String(), values...); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, updateProgress,
+		productImport.Status, productImport.ProcessedRows, productImport.SucceededRows, productImport.FailedRows,
+		productImport.ErrorMessage, productImport.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type ProductRecommendationRepository interface {
+	RefreshCoPurchases(ctx context.Context) (int64, error)
+	FindAllRelatedForUser(ctx context.Context, productIds []int64, limit int, param *queryparamdto.GetAllParams) ([]*entity.Product, error)
+}
+
+type ProductRecommendationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductRecommendationRepositoryImpl(db *sql.DB) *ProductRecommendationRepositoryImpl {
+	return &ProductRecommendationRepositoryImpl{db: db}
+}
+
+// RefreshCoPurchases recomputes every co-purchase score from the confirmed orders. Products bought in the same
+// transaction are bought together, pairs are scored by the cosine similarity of their transactions so products that
+// are in almost every transaction do not dominate. Only the best pairs of each product are kept
+func (repo *ProductRecommendationRepositoryImpl) RefreshCoPurchases(ctx context.Context) (int64, error) {
+	const deleteAll = `DELETE FROM product_co_purchases`
+
+	const refresh = `WITH baskets AS (SELECT DISTINCT orders.transaction_id, order_details.product_id
+		FROM order_details
+		INNER JOIN orders ON order_details.order_id = orders.id
+		INNER JOIN order_status_logs ON orders.id = order_status_logs.order_id AND order_status_logs.is_latest = true
+		WHERE order_status_logs.order_status_id = $1 AND orders.deleted_at IS NULL AND order_details.deleted_at IS NULL),
+	product_baskets AS (SELECT product_id, count(*) AS basket_count FROM baskets GROUP BY product_id),
+	pairs AS (SELECT a.product_id, b.product_id AS related_product_id, count(*) AS co_purchase_count
+		FROM baskets a
+		INNER JOIN baskets b ON a.transaction_id = b.transaction_id AND a.product_id <> b.product_id
+		GROUP BY a.product_id, b.product_id
+		HAVING count(*) >= $2),
+	scored AS (SELECT pairs.product_id, pairs.related_product_id, pairs.co_purchase_count,
+		pairs.co_purchase_count::float8 / sqrt(pa.basket_count::float8 * pb.basket_count::float8) AS score
+		FROM pairs
+		INNER JOIN product_baskets pa ON pairs.product_id = pa.product_id
+		INNER JOIN product_baskets pb ON pairs.related_product_id = pb.product_id),
+	ranked AS (SELECT *, row_number() OVER (PARTITION BY product_id ORDER BY score DESC, related_product_id) AS rank
+		FROM scored)
+	INSERT INTO product_co_purchases(product_id, related_product_id, co_purchase_count, score)
+	SELECT product_id, related_product_id, co_purchase_count, score FROM ranked WHERE rank <= $3`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteAll); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, refresh,
+		appconstant.ConfirmedUserOrderStatusId, appconstant.ProductCoPurchaseMinCount, appconstant.ProductCoPurchaseMaxPerProduct,
+	)
+	if err != nil {
+		return 0, err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return inserted, tx.Commit()
+}
+
+// FindAllRelatedForUser lists the products most often bought together with any of the given products, the given
+// products themselves are left out. Only products in stock in the pharmacies matched by param are listed
+func (repo *ProductRecommendationRepositoryImpl) FindAllRelatedForUser(ctx context.Context, productIds []int64, limit int, param *queryparamdto.GetAllParams) ([]*entity.Product, error) {
+	items := make([]*entity.Product, 0)
+	if len(productIds) == 0 {
+		return items, nil
+	}
+
+	placeholders := make([]string, 0, len(productIds))
+	values := make([]interface{}, 0, len(productIds))
+	for index, productId := range productIds {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", index+1))
+		values = append(values, productId)
+	}
+	productIdsIn := strings.Join(placeholders, ",")
+
+	initQuery := fmt.Sprintf(`SELECT products.id, products.name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image,
+       rating_average, rating_count, min(pharmacy_products.price), max(pharmacy_products.price)
+	FROM products
+	INNER JOIN (SELECT related_product_id, sum(score) AS score FROM product_co_purchases
+		WHERE product_id IN (%s) GROUP BY related_product_id) related ON products.id = related.related_product_id
+	INNER JOIN pharmacy_products ON products.id = pharmacy_products.product_id
+	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
+	WHERE products.id NOT IN (%s) AND products.deleted_at IS NULL
+	AND pharmacy_products.is_active = true AND pharmacy_products.stock > 0 AND pharmacy_products.deleted_at IS NULL `, productIdsIn, productIdsIn)
+
+	pageId := 1
+	relatedParam := *param
+	relatedParam.SortClauses = append([]appdb.SortClause{appdb.NewSort("max(related.score)", appdb.OrderDesc)}, param.SortClauses...)
+	relatedParam.PageSize = &limit
+	relatedParam.PageId = &pageId
+
+	query, paramValues := buildQuery(initQuery, &entity.Product{}, &relatedParam, true, true, len(productIds))
+	values = append(values, paramValues...)
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product entity.Product
+		if err := rows.Scan(
+			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
+			&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image,
+			&product.RatingAverage, &product.RatingCount, &product.MinimumPrice, &product.MaximumPrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
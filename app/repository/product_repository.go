@@ -6,24 +6,32 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgconn"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/util"
+	"strconv"
+	"strings"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, product entity.Product) (*entity.Product, error)
 	FindById(ctx context.Context, id int64) (*entity.Product, error)
 	FindByIdForUser(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (*entity.Product, error)
+	FindByUniqueKey(ctx context.Context, product entity.Product) (*entity.Product, error)
+	FindByCode(ctx context.Context, code string) (*entity.Product, error)
 
 	FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.Product, error)
 	FindAllForUser(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.Product, error)
 	FindAllForAdmin(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) ([]*entity.Product, error)
+	FindAllForDoctor(ctx context.Context, doctorId int64, param *queryparamdto.GetAllParams) ([]*entity.Product, error)
 
 	CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
 	CountFindAllForUser(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
 	CountFindAllForAdmin(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) (int64, error)
+	FindAllFacetsForUser(ctx context.Context, params *queryparamdto.ProductFacetParams) (*entity.ProductFacets, error)
 
 	Update(ctx context.Context, product entity.Product) (*entity.Product, error)
 	Delete(ctx context.Context, id int64) error
@@ -40,18 +48,18 @@ func NewProductRepositoryImpl(db *sql.DB) *ProductRepositoryImpl {
 func (repo *ProductRepositoryImpl) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	const create = `INSERT INTO products
 		(name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form,
- 		unit_in_pack, selling_unit, weight, length, width, height, image)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-		RETURNING id, name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image, created_at, updated_at, deleted_at`
+ 		unit_in_pack, selling_unit, weight, length, width, height, image, code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image, code, created_at, updated_at, deleted_at`
 
 	row := repo.db.QueryRowContext(ctx, create,
 		product.Name, product.GenericName, product.Content, product.ManufacturerId, product.Description, product.DrugClassificationId, product.ProductCategoryId,
-		product.DrugForm, product.UnitInPack, product.SellingUnit, product.Weight, product.Length, product.Width, product.Height, product.Image,
+		product.DrugForm, product.UnitInPack, product.SellingUnit, product.Weight, product.Length, product.Width, product.Height, product.Image, product.Code,
 	)
 	if row.Err() != nil {
 		var errPgConn *pgconn.PgError
 		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
-			return nil, apperror.ErrProductUniqueConstraint
+			return nil, wrapProductUniqueError(errPgConn)
 		}
 		return nil, row.Err()
 	}
@@ -59,14 +67,14 @@ func (repo *ProductRepositoryImpl) Create(ctx context.Context, product entity.Pr
 	var created entity.Product
 	err := row.Scan(
 		&created.Id, &created.Name, &created.GenericName, &created.Content, &created.ManufacturerId, &created.Description, &created.DrugClassificationId, &created.ProductCategoryId, &created.DrugForm,
-		&created.UnitInPack, &created.SellingUnit, &created.Weight, &created.Length, &created.Width, &created.Height, &created.Image, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+		&created.UnitInPack, &created.SellingUnit, &created.Weight, &created.Length, &created.Width, &created.Height, &created.Image, &created.Code, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
 	)
 	return &created, err
 }
 
 func (repo *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Product, error) {
 	const getById = `SELECT p.id, p.name, p.generic_name, p.content, p.manufacturer_id, p.description, 
-    	p.drug_classification_id, p.product_category_id, p.drug_form, p.unit_in_pack, p.selling_unit, p.weight, p.length, p.width, p.height, p.image, p.created_at, p.updated_at, p.deleted_at, pc.name, m.name, dc.name
+    	p.drug_classification_id, p.product_category_id, p.drug_form, p.unit_in_pack, p.selling_unit, p.weight, p.length, p.width, p.height, p.image, p.code, p.rating_average, p.rating_count, p.created_at, p.updated_at, p.deleted_at, pc.name, m.name, dc.name
 	FROM products p
 	INNER JOIN product_categories pc ON p.product_category_id = pc.id
 	INNER JOIN manufacturers m ON p.manufacturer_id = m.id
@@ -87,7 +95,7 @@ func (repo *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*ent
 	)
 	err := row.Scan(
 		&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
-		&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image, &product.CreatedAt, &product.UpdatedAt, &product.DeletedAt,
+		&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image, &product.Code, &product.RatingAverage, &product.RatingCount, &product.CreatedAt, &product.UpdatedAt, &product.DeletedAt,
 		&productCategory.Name, &manufacturer.Name, &drugClassification.Name,
 	)
 	if err != nil {
@@ -102,11 +110,47 @@ func (repo *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*ent
 	return &product, err
 }
 
+// FindByUniqueKey finds the product, deleted or not, holding the name, generic name, content and manufacturer
+// of the given product, which are unique together
+func (repo *ProductRepositoryImpl) FindByUniqueKey(ctx context.Context, product entity.Product) (*entity.Product, error) {
+	const findByUniqueKey = `SELECT id, name, generic_name, content, manufacturer_id, deleted_at
+	FROM products
+	WHERE name = $1 AND generic_name = $2 AND content = $3 AND manufacturer_id = $4`
+
+	row := repo.db.QueryRowContext(ctx, findByUniqueKey, product.Name, product.GenericName, product.Content, product.ManufacturerId)
+	var found entity.Product
+	err := row.Scan(&found.Id, &found.Name, &found.GenericName, &found.Content, &found.ManufacturerId, &found.DeletedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (repo *ProductRepositoryImpl) FindByCode(ctx context.Context, code string) (*entity.Product, error) {
+	const findByCode = `SELECT id, name, generic_name, content, manufacturer_id, code
+	FROM products
+	WHERE code = $1 AND deleted_at IS NULL`
+
+	row := repo.db.QueryRowContext(ctx, findByCode, code)
+	var found entity.Product
+	err := row.Scan(&found.Id, &found.Name, &found.GenericName, &found.Content, &found.ManufacturerId, &found.Code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
 func (repo *ProductRepositoryImpl) FindByIdForUser(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (*entity.Product, error) {
 	initQuery := `SELECT products.id, products.name, products.generic_name, products.content, products.manufacturer_id, 
        products.description, products.drug_classification_id, products.product_category_id, products.drug_form, 
        products.unit_in_pack, products.selling_unit, products.weight, products.length, products.width, products.height, 
-       products.image, products.created_at, products.updated_at, products.deleted_at, 
+       products.image, products.rating_average, products.rating_count, products.created_at, products.updated_at, products.deleted_at, 
        product_categories.name, manufacturers.name, drug_classifications.name,
 		min(pharmacy_products.price), max(pharmacy_products.price)
 	FROM products
@@ -134,7 +178,7 @@ func (repo *ProductRepositoryImpl) FindByIdForUser(ctx context.Context, id int64
 	)
 	err := row.Scan(
 		&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
-		&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image, &product.CreatedAt, &product.UpdatedAt, &product.DeletedAt,
+		&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image, &product.RatingAverage, &product.RatingCount, &product.CreatedAt, &product.UpdatedAt, &product.DeletedAt,
 		&productCategory.Name, &manufacturer.Name, &drugClassification.Name, &product.MinimumPrice, &product.MaximumPrice,
 	)
 	if err != nil {
@@ -156,7 +200,7 @@ func (repo *ProductRepositoryImpl) FindAll(ctx context.Context, param *querypara
 	FROM products 
 	WHERE products.deleted_at IS NULL `
 
-	query, values := buildQuery(initQuery, &entity.Product{}, param, true, true)
+	query, values := buildProductSearchQuery(initQuery, param, true, true, 0)
 
 	rows, err := repo.db.QueryContext(ctx, query, values...)
 	if err != nil {
@@ -187,7 +231,7 @@ func (repo *ProductRepositoryImpl) CountFindAll(ctx context.Context, param *quer
 	SELECT count(products.id)
 	FROM products 
 	WHERE products.deleted_at IS NULL `
-	query, values := buildQuery(initQuery, &entity.Product{}, param, false, false)
+	query, values := buildProductSearchQuery(initQuery, param, false, false, 0)
 
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) as c", query)
 
@@ -215,12 +259,12 @@ func (repo *ProductRepositoryImpl) CountFindAll(ctx context.Context, param *quer
 
 func (repo *ProductRepositoryImpl) FindAllForUser(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.Product, error) {
 	initQuery := `SELECT products.id, products.name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image,
-       min(pharmacy_products.price), max(pharmacy_products.price)
+       rating_average, rating_count, min(pharmacy_products.price), max(pharmacy_products.price)
 	FROM products 
 	INNER JOIN pharmacy_products ON products.id = pharmacy_products.product_id
 	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
 	WHERE products.deleted_at IS NULL AND pharmacy_products.is_active = true `
-	query, values := buildQuery(initQuery, &entity.Product{}, param, true, true)
+	query, values := buildProductSearchQuery(initQuery, param, true, true, 0)
 
 	rows, err := repo.db.QueryContext(ctx, query, values...)
 	if err != nil {
@@ -234,7 +278,7 @@ func (repo *ProductRepositoryImpl) FindAllForUser(ctx context.Context, param *qu
 		if err := rows.Scan(
 			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
 			&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image,
-			&product.MinimumPrice, &product.MaximumPrice,
+			&product.RatingAverage, &product.RatingCount, &product.MinimumPrice, &product.MaximumPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -253,7 +297,7 @@ func (repo *ProductRepositoryImpl) CountFindAllForUser(ctx context.Context, para
 	INNER JOIN pharmacy_products ON products.id = pharmacy_products.product_id
 	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
 	WHERE products.deleted_at IS NULL AND pharmacy_products.is_active = true `
-	query, values := buildQuery(initQuery, &entity.Product{}, param, false, false)
+	query, values := buildProductSearchQuery(initQuery, param, false, false, 0)
 
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) as c", query)
 
@@ -292,7 +336,7 @@ func (repo *ProductRepositoryImpl) FindAllForAdmin(ctx context.Context, pharmacy
 		WHERE products.deleted_at IS NULL `
 	indexPreparedStatement := 1
 
-	query, values := buildQuery(initQuery, &entity.Product{}, param, true, true, indexPreparedStatement)
+	query, values := buildProductSearchQuery(initQuery, param, true, true, indexPreparedStatement)
 	values = util.AppendAtIndex(values, 0, interface{}(pharmacyId))
 
 	rows, err := repo.db.QueryContext(ctx, query, values...)
@@ -319,6 +363,56 @@ func (repo *ProductRepositoryImpl) FindAllForAdmin(ctx context.Context, pharmacy
 	return items, nil
 }
 
+func (repo *ProductRepositoryImpl) FindAllForDoctor(ctx context.Context, doctorId int64, param *queryparamdto.GetAllParams) ([]*entity.Product, error) {
+	initQuery := `
+	SELECT products.id, products.name, products.generic_name, products.content, products.manufacturer_id, products.description, 
+		   products.drug_classification_id, products.product_category_id, products.drug_form, products.unit_in_pack, products.selling_unit, products.weight, products.length, products.width, products.height, products.image
+	FROM products
+		LEFT JOIN doctor_favorite_products ON products.id = doctor_favorite_products.product_id AND doctor_favorite_products.doctor_id = $1
+		LEFT JOIN (
+			SELECT prescription_products.product_id, max(prescriptions.created_at) AS last_prescribed_at
+			FROM prescription_products
+			INNER JOIN prescriptions ON prescription_products.prescription_id = prescriptions.id
+			INNER JOIN consultation_sessions ON prescriptions.session_id = consultation_sessions.id
+			WHERE consultation_sessions.doctor_id = $1 AND prescriptions.deleted_at IS NULL AND prescription_products.deleted_at IS NULL
+			GROUP BY prescription_products.product_id
+		) recent ON products.id = recent.product_id
+	WHERE products.deleted_at IS NULL `
+	indexPreparedStatement := 1
+
+	doctorParam := *param
+	doctorParam.SortClauses = append([]appdb.SortClause{
+		appdb.NewSort("doctor_favorite_products.created_at IS NULL", appdb.OrderAsc),
+		appdb.NewSort("recent.last_prescribed_at", appdb.OrderDescNullsLast),
+	}, param.SortClauses...)
+
+	query, values := buildProductSearchQuery(initQuery, &doctorParam, true, true, indexPreparedStatement)
+	values = util.AppendAtIndex(values, 0, interface{}(doctorId))
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.Product, 0)
+	for rows.Next() {
+		var product entity.Product
+		if err := rows.Scan(
+			&product.Id, &product.Name, &product.GenericName, &product.Content, &product.ManufacturerId, &product.Description, &product.DrugClassificationId, &product.ProductCategoryId, &product.DrugForm,
+			&product.UnitInPack, &product.SellingUnit, &product.Weight, &product.Length, &product.Width, &product.Height, &product.Image,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
 func (repo *ProductRepositoryImpl) CountFindAllForAdmin(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) (int64, error) {
 	initQuery := `
 	SELECT COUNT(products.id)
@@ -331,7 +425,7 @@ func (repo *ProductRepositoryImpl) CountFindAllForAdmin(ctx context.Context, pha
 		WHERE products.deleted_at IS NULL `
 	indexPreparedStatement := 1
 
-	query, values := buildQuery(initQuery, &entity.Product{}, param, false, false, indexPreparedStatement)
+	query, values := buildProductSearchQuery(initQuery, param, false, false, indexPreparedStatement)
 	values = util.AppendAtIndex(values, 0, interface{}(pharmacyId))
 
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) as c", query)
@@ -358,23 +452,150 @@ func (repo *ProductRepositoryImpl) CountFindAllForAdmin(ctx context.Context, pha
 	return totalItems, nil
 }
 
+func (repo *ProductRepositoryImpl) FindAllFacetsForUser(ctx context.Context, params *queryparamdto.ProductFacetParams) (*entity.ProductFacets, error) {
+	var (
+		facets = new(entity.ProductFacets)
+		err    error
+	)
+
+	facets.DrugClassifications, err = repo.findFacetForUser(ctx, "drug_classifications.id", "drug_classifications.name",
+		"INNER JOIN drug_classifications ON products.drug_classification_id = drug_classifications.id", params.DrugClassification)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.ProductCategories, err = repo.findFacetForUser(ctx, "product_categories.id", "product_categories.name",
+		"INNER JOIN product_categories ON products.product_category_id = product_categories.id", params.ProductCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Manufacturers, err = repo.findFacetForUser(ctx, "manufacturers.id", "manufacturers.name",
+		"INNER JOIN manufacturers ON products.manufacturer_id = manufacturers.id", params.Manufacturer)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.DrugForms, err = repo.findFacetForUser(ctx, "0", "products.drug_form", "", params.DrugForm)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.PriceRanges, err = repo.findPriceFacetForUser(ctx, params.PriceRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// findFacetForUser counts the distinct products available in the filtered pharmacies for every value of a facet
+func (repo *ProductRepositoryImpl) findFacetForUser(ctx context.Context, idColumn, nameColumn, join string, param *queryparamdto.GetAllParams) ([]*entity.ProductFacetValue, error) {
+	initQuery := fmt.Sprintf(`SELECT %s, %s, count(DISTINCT products.id)
+	FROM products 
+	INNER JOIN pharmacy_products ON products.id = pharmacy_products.product_id
+	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
+	%s
+	WHERE products.deleted_at IS NULL AND pharmacy_products.is_active = true `, idColumn, nameColumn, join)
+
+	facetParam := *param
+	facetParam.GroupClauses = []appdb.GroupClause{appdb.NewGroupClause("1"), appdb.NewGroupClause("2")}
+	query, values := buildProductSearchQuery(initQuery, &facetParam, false, false, 0)
+	query += " ORDER BY 3 DESC, 2 ASC"
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ProductFacetValue, 0)
+	for rows.Next() {
+		var item entity.ProductFacetValue
+		if err := rows.Scan(&item.Id, &item.Name, &item.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// findPriceFacetForUser counts the distinct products having at least one offer in each price bucket
+func (repo *ProductRepositoryImpl) findPriceFacetForUser(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.ProductPriceFacetValue, error) {
+	bounds := appconstant.ProductPriceFacetBounds
+	thresholds := make([]string, 0, len(bounds))
+	for _, bound := range bounds {
+		thresholds = append(thresholds, strconv.FormatInt(bound, 10))
+	}
+	bucketColumn := fmt.Sprintf("width_bucket(pharmacy_products.price, ARRAY[%s]::NUMERIC[])", strings.Join(thresholds, ","))
+
+	initQuery := fmt.Sprintf(`SELECT %s, count(DISTINCT products.id)
+	FROM products 
+	INNER JOIN pharmacy_products ON products.id = pharmacy_products.product_id
+	INNER JOIN pharmacies ON pharmacy_products.pharmacy_id = pharmacies.id
+	WHERE products.deleted_at IS NULL AND pharmacy_products.is_active = true `, bucketColumn)
+
+	facetParam := *param
+	facetParam.GroupClauses = []appdb.GroupClause{appdb.NewGroupClause("1")}
+	query, values := buildProductSearchQuery(initQuery, &facetParam, false, false, 0)
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int64)
+	for rows.Next() {
+		var (
+			bucket int
+			count  int64
+		)
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, err
+		}
+		counts[bucket] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items := make([]*entity.ProductPriceFacetValue, 0, len(bounds)+1)
+	for bucket := 0; bucket <= len(bounds); bucket++ {
+		item := entity.ProductPriceFacetValue{MinPrice: "0", Count: counts[bucket]}
+		if bucket > 0 {
+			item.MinPrice = thresholds[bucket-1]
+		}
+		if bucket < len(bounds) {
+			item.MaxPrice = thresholds[bucket]
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
 func (repo *ProductRepositoryImpl) Update(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	const updateById = `
 		UPDATE products
 		SET name=$1, generic_name=$2, content=$3, manufacturer_id=$4, description=$5, drug_classification_id=$6, product_category_id=$7, drug_form=$8, 
-			unit_in_pack=$9, selling_unit=$10, weight=$11, length=$12, width=$13, height=$14, image=$15, updated_at = now()
-		WHERE id = $16
-		RETURNING id, name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image, created_at, updated_at, deleted_at
+			unit_in_pack=$9, selling_unit=$10, weight=$11, length=$12, width=$13, height=$14, image=$15, code=$16, updated_at = now()
+		WHERE id = $17
+		RETURNING id, name, generic_name, content, manufacturer_id, description, drug_classification_id, product_category_id, drug_form, unit_in_pack, selling_unit, weight, length, width, height, image, code, created_at, updated_at, deleted_at
 		`
 
 	row := repo.db.QueryRowContext(ctx, updateById,
 		product.Name, product.GenericName, product.Content, product.ManufacturerId, product.Description, product.DrugClassificationId, product.ProductCategoryId, product.DrugForm,
-		product.UnitInPack, product.SellingUnit, product.Weight, product.Length, product.Width, product.Height, product.Image, product.Id,
+		product.UnitInPack, product.SellingUnit, product.Weight, product.Length, product.Width, product.Height, product.Image, product.Code, product.Id,
 	)
 	if row.Err() != nil {
 		var errPgConn *pgconn.PgError
 		if errors.As(row.Err(), &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
-			return nil, apperror.ErrProductUniqueConstraint
+			return nil, wrapProductUniqueError(errPgConn)
 		}
 		return nil, row.Err()
 	}
@@ -382,7 +603,7 @@ func (repo *ProductRepositoryImpl) Update(ctx context.Context, product entity.Pr
 	var updated entity.Product
 	err := row.Scan(
 		&updated.Id, &updated.Name, &updated.GenericName, &updated.Content, &updated.ManufacturerId, &updated.Description, &updated.DrugClassificationId, &updated.ProductCategoryId, &updated.DrugForm,
-		&updated.UnitInPack, &updated.SellingUnit, &updated.Weight, &updated.Length, &updated.Width, &updated.Height, &updated.Image, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+		&updated.UnitInPack, &updated.SellingUnit, &updated.Weight, &updated.Length, &updated.Width, &updated.Height, &updated.Image, &updated.Code, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
 	)
 	return &updated, err
 }
@@ -427,3 +648,38 @@ func (repo *ProductRepositoryImpl) Delete(ctx context.Context, id int64) error {
 
 	return err
 }
+
+const (
+	productSearchTsQuery   = `(plainto_tsquery('simple', $%[1]d) || plainto_tsquery('indonesian', $%[1]d))`
+	productSearchCondition = ` AND (products.search_vector @@ %[1]s OR $%[2]d <%% products.name OR $%[2]d <%% products.generic_name) `
+	productSearchRank      = `ts_rank(products.search_vector, %[1]s) + greatest(word_similarity($%[2]d, products.name), word_similarity($%[2]d, products.generic_name))`
+)
+
+// buildProductSearchQuery wraps buildQuery and narrows the products to param.Search, falling back to trigram
+// similarity on the names so misspelled terms still match. Matches are ranked by relevance after any requested sort.
+// The search term is bound right after initIndex, so fixed arguments are still prepended at index 0 by the caller.
+func buildProductSearchQuery(initQuery string, param *queryparamdto.GetAllParams, setLimit bool, setPaginated bool, initIndex int) (string, []interface{}) {
+	if util.IsEmptyString(param.Search) {
+		return buildQuery(initQuery, &entity.Product{}, param, setLimit, setPaginated, initIndex)
+	}
+
+	searchIndex := initIndex + 1
+	tsQuery := fmt.Sprintf(productSearchTsQuery, searchIndex)
+	initQuery += fmt.Sprintf(productSearchCondition, tsQuery, searchIndex)
+
+	searchParam := *param
+	searchParam.SortClauses = append(
+		append([]appdb.SortClause{}, param.SortClauses...),
+		appdb.NewSort(fmt.Sprintf(productSearchRank, tsQuery, searchIndex), appdb.OrderDesc),
+	)
+
+	query, values := buildQuery(initQuery, &entity.Product{}, &searchParam, setLimit, setPaginated, searchIndex)
+	return query, append([]interface{}{param.Search}, values...)
+}
+
+func wrapProductUniqueError(errPgConn *pgconn.PgError) error {
+	if errPgConn.ConstraintName == appconstant.ProductCodeUniqueIndex {
+		return apperror.ErrProductCodeAlreadyUsed
+	}
+	return apperror.ErrProductUniqueConstraint
+}
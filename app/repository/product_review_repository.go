@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"strings"
+)
+
+type ProductReviewRepository interface {
+	FindReviewableOrderDetail(ctx context.Context, userId, orderDetailId int64) (*entity.OrderDetail, error)
+	Create(ctx context.Context, review entity.ProductReview) (*entity.ProductReview, error)
+	FindById(ctx context.Context, id int64) (*entity.ProductReview, error)
+	FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.ProductReview, error)
+	CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
+	UpdateStatus(ctx context.Context, review entity.ProductReview) (*entity.ProductReview, error)
+}
+
+type ProductReviewRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductReviewRepositoryImpl(db *sql.DB) *ProductReviewRepositoryImpl {
+	return &ProductReviewRepositoryImpl{db: db}
+}
+
+// FindReviewableOrderDetail finds the order detail when it was bought by the user in an order they confirmed as
+// received, an order detail can only be reviewed once
+func (repo *ProductReviewRepositoryImpl) FindReviewableOrderDetail(ctx context.Context, userId, orderDetailId int64) (*entity.OrderDetail, error) {
+	const findReviewable = `SELECT order_details.id, order_details.order_id, order_details.product_id, order_details.name,
+       EXISTS (SELECT 1 FROM product_reviews WHERE product_reviews.order_detail_id = order_details.id)
+	FROM order_details
+	INNER JOIN orders ON order_details.order_id = orders.id
+	INNER JOIN transactions ON orders.transaction_id = transactions.id
+	INNER JOIN order_status_logs ON orders.id = order_status_logs.order_id AND order_status_logs.is_latest = true
+	WHERE order_details.id = $1 AND transactions.user_id = $2 AND order_status_logs.order_status_id = $3
+	AND order_details.deleted_at IS NULL AND orders.deleted_at IS NULL`
+
+	var (
+		detail     entity.OrderDetail
+		isReviewed bool
+	)
+	err := repo.db.QueryRowContext(ctx, findReviewable, orderDetailId, userId, appconstant.ConfirmedUserOrderStatusId).Scan(
+		&detail.Id, &detail.OrderId, &detail.ProductId, &detail.Name, &isReviewed,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	if isReviewed {
+		return nil, apperror.ErrProductReviewAlreadyExists
+	}
+	return &detail, nil
+}
+
+func (repo *ProductReviewRepositoryImpl) Create(ctx context.Context, review entity.ProductReview) (*entity.ProductReview, error) {
+	const create = `INSERT INTO product_reviews(user_id, product_id, order_detail_id, rating, comment, status)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at, updated_at`
+
+	const createPhoto = `INSERT INTO product_review_photos(product_review_id, position, url)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, create,
+		review.UserId, review.ProductId, review.OrderDetailId, review.Rating, review.Comment, review.Status,
+	).Scan(&review.Id, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		var errPgConn *pgconn.PgError
+		if errors.As(err, &errPgConn) && errPgConn.Code == apperror.PgconnErrCodeUniqueConstraintViolation {
+			return nil, apperror.ErrProductReviewAlreadyExists
+		}
+		return nil, err
+	}
+
+	for index, photo := range review.Photos {
+		photo.ProductReviewId = review.Id
+		photo.Position = int32(index + 1)
+		if err := tx.QueryRowContext(ctx, createPhoto, photo.ProductReviewId, photo.Position, photo.Url).Scan(
+			&photo.Id, &photo.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateProductRating(ctx, tx, review.ProductId); err != nil {
+		return nil, err
+	}
+	return &review, tx.Commit()
+}
+
+const findProductReviewWithReviewer = `SELECT product_reviews.id, product_reviews.user_id, product_reviews.product_id,
+       product_reviews.order_detail_id, product_reviews.rating, product_reviews.comment, product_reviews.status,
+       product_reviews.moderation_note, product_reviews.moderated_at, product_reviews.created_at,
+       product_reviews.updated_at, product_reviews.deleted_at, COALESCE(user_profiles.name, '')
+	FROM product_reviews
+	LEFT JOIN user_profiles ON product_reviews.user_id = user_profiles.user_id
+	WHERE product_reviews.deleted_at IS NULL `
+
+func (repo *ProductReviewRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.ProductReview, error) {
+	const findById = findProductReviewWithReviewer + `AND product_reviews.id = $1`
+
+	review, err := repo.scan(repo.db.QueryRowContext(ctx, findById, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	if err := repo.attachPhotos(ctx, []*entity.ProductReview{review}); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (repo *ProductReviewRepositoryImpl) FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.ProductReview, error) {
+	query, values := buildQuery(findProductReviewWithReviewer, &entity.ProductReview{}, param, true, true)
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ProductReview, 0)
+	for rows.Next() {
+		review, err := repo.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := repo.attachPhotos(ctx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *ProductReviewRepositoryImpl) CountFindAll(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error) {
+	initQuery := `SELECT count(id) FROM product_reviews WHERE product_reviews.deleted_at IS NULL `
+	query, values := buildQuery(initQuery, &entity.ProductReview{}, param, false, false)
+
+	var totalItems int64
+	row := repo.db.QueryRowContext(ctx, query, values...)
+	if err := row.Scan(&totalItems); err != nil {
+		return totalItems, err
+	}
+	return totalItems, nil
+}
+
+// UpdateStatus publishes or hides the review and recomputes the rating of its product
+func (repo *ProductReviewRepositoryImpl) UpdateStatus(ctx context.Context, review entity.ProductReview) (*entity.ProductReview, error) {
+	const updateStatus = `UPDATE product_reviews SET status = $1, moderation_note = $2, moderated_at = now(), updated_at = now()
+	WHERE id = $3 AND deleted_at IS NULL
+	RETURNING product_id`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var productId int64
+	err = tx.QueryRowContext(ctx, updateStatus, review.Status, review.ModerationNote, review.Id).Scan(&productId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	if err := updateProductRating(ctx, tx, productId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return repo.FindById(ctx, review.Id)
+}
+
+func (repo *ProductReviewRepositoryImpl) attachPhotos(ctx context.Context, reviews []*entity.ProductReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	reviewsById := make(map[int64]*entity.ProductReview, len(reviews))
+	placeholders := make([]string, 0, len(reviews))
+	values := make([]interface{}, 0, len(reviews))
+	for index, review := range reviews {
+		review.Photos = make([]*entity.ProductReviewPhoto, 0)
+		reviewsById[review.Id] = review
+		placeholders = append(placeholders, fmt.Sprintf("$%d", index+1))
+		values = append(values, review.Id)
+	}
+	findAllPhotos := fmt.Sprintf(`SELECT id, product_review_id, position, url, created_at
+	FROM product_review_photos WHERE product_review_id IN (%s) AND deleted_at IS NULL
+	ORDER BY product_review_id, position`, strings.Join(placeholders, ","))
+
+	rows, err := repo.db.QueryContext(ctx, findAllPhotos, values...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var photo entity.ProductReviewPhoto
+		if err := rows.Scan(&photo.Id, &photo.ProductReviewId, &photo.Position, &photo.Url, &photo.CreatedAt); err != nil {
+			return err
+		}
+		review := reviewsById[photo.ProductReviewId]
+		review.Photos = append(review.Photos, &photo)
+	}
+	return rows.Err()
+}
+
+func (repo *ProductReviewRepositoryImpl) scan(row interface{ Scan(...any) error }) (*entity.ProductReview, error) {
+	review := entity.ProductReview{UserProfile: &entity.UserProfile{}}
+	if err := row.Scan(
+		&review.Id, &review.UserId, &review.ProductId, &review.OrderDetailId, &review.Rating, &review.Comment, &review.Status,
+		&review.ModerationNote, &review.ModeratedAt, &review.CreatedAt, &review.UpdatedAt, &review.DeletedAt,
+		&review.UserProfile.Name,
+	); err != nil {
+		return nil, err
+	}
+	review.UserProfile.UserId = review.UserId
+	return &review, nil
+}
+
+// updateProductRating keeps the rating of the product on its published reviews
+func updateProductRating(ctx context.Context, tx *sql.Tx, productId int64) error {
+	const updateRating = `UPDATE products
+	SET rating_average = COALESCE(rating.average, 0), rating_count = rating.count
+	FROM (SELECT round(avg(rating), 2)::float8 AS average, count(id) AS count FROM product_reviews
+		WHERE product_id = $1 AND status = $2 AND deleted_at IS NULL) rating
+	WHERE products.id = $1`
+
+	_, err := tx.ExecContext(ctx, updateRating, productId, appconstant.ProductReviewStatusPublished)
+	return err
+}
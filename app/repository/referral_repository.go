@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type ReferralRepository interface {
+	Create(ctx context.Context, referral entity.Referral) (*entity.Referral, error)
+	FindById(ctx context.Context, id int64) (*entity.Referral, error)
+	FindByReferredSessionId(ctx context.Context, sessionId int64) (*entity.Referral, error)
+	FindAllByUserId(ctx context.Context, userId int64) ([]*entity.Referral, error)
+	CreateReferredSession(ctx context.Context, referralId int64, session entity.ConsultationSession) (*entity.ConsultationSession, error)
+}
+
+type ReferralRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReferralRepositoryImpl(db *sql.DB) *ReferralRepositoryImpl {
+	return &ReferralRepositoryImpl{db: db}
+}
+
+func (repo *ReferralRepositoryImpl) Create(ctx context.Context, referral entity.Referral) (*entity.Referral, error) {
+	const create = `INSERT INTO referrals(session_id, referring_doctor_id, user_id, dependent_id, doctor_specialization_id, target_doctor_id, clinical_summary)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at, updated_at`
+
+	summary := referral.ClinicalSummary
+	if err := encryptFields(&summary); err != nil {
+		return nil, err
+	}
+
+	row := repo.db.QueryRowContext(ctx, create,
+		referral.SessionId, referral.ReferringDoctorId, referral.UserId, referral.DependentId,
+		referral.DoctorSpecializationId, referral.TargetDoctorId, summary,
+	)
+	err := row.Scan(&referral.Id, &referral.CreatedAt, &referral.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &referral, nil
+}
+
+const findReferral = `SELECT referrals.id, session_id, referring_doctor_id, user_id, dependent_id, doctor_specialization_id,
+	   target_doctor_id, clinical_summary, referred_session_id, referrals.created_at, referrals.updated_at, referrals.deleted_at,
+	   doctor_specializations.name
+FROM referrals
+	LEFT JOIN doctor_specializations ON referrals.doctor_specialization_id = doctor_specializations.id
+WHERE referrals.deleted_at IS NULL `
+
+func (repo *ReferralRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Referral, error) {
+	const findById = findReferral + `AND referrals.id = $1`
+	return repo.findOne(ctx, findById, id)
+}
+
+func (repo *ReferralRepositoryImpl) FindByReferredSessionId(ctx context.Context, sessionId int64) (*entity.Referral, error) {
+	const findByReferredSessionId = findReferral + `AND referrals.referred_session_id = $1`
+	return repo.findOne(ctx, findByReferredSessionId, sessionId)
+}
+
+func (repo *ReferralRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64) ([]*entity.Referral, error) {
+	const findAllByUserId = findReferral + `AND referrals.user_id = $1 ORDER BY referrals.created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.Referral, 0)
+	for rows.Next() {
+		referral, err := repo.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, referral)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *ReferralRepositoryImpl) findOne(ctx context.Context, query string, args ...any) (*entity.Referral, error) {
+	row := repo.db.QueryRowContext(ctx, query, args...)
+	referral, err := repo.scan(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return referral, nil
+}
+
+func (repo *ReferralRepositoryImpl) scan(row interface{ Scan(...any) error }) (*entity.Referral, error) {
+	var (
+		referral           entity.Referral
+		specializationName sql.NullString
+	)
+	err := row.Scan(
+		&referral.Id, &referral.SessionId, &referral.ReferringDoctorId, &referral.UserId, &referral.DependentId,
+		&referral.DoctorSpecializationId, &referral.TargetDoctorId, &referral.ClinicalSummary, &referral.ReferredSessionId,
+		&referral.CreatedAt, &referral.UpdatedAt, &referral.DeletedAt, &specializationName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decryptFields(&referral.ClinicalSummary); err != nil {
+		return nil, err
+	}
+
+	if referral.DoctorSpecializationId.Valid {
+		referral.DoctorSpecialization = &entity.DoctorSpecialization{
+			Id:   referral.DoctorSpecializationId.Int64,
+			Name: specializationName.String,
+		}
+	}
+	return &referral, nil
+}
+
+// CreateReferredSession opens the session and links it to the referral in one transaction,
+// a referral that already has a session is rejected so it can only be used once
+func (repo *ReferralRepositoryImpl) CreateReferredSession(ctx context.Context, referralId int64, session entity.ConsultationSession) (*entity.ConsultationSession, error) {
+	const createSession = `INSERT INTO consultation_sessions(user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at)
+	VALUES ($1, $2, $3, $4, ` + sessionExpiresAtFromDoctor + `) RETURNING
+	id, user_id, dependent_id, doctor_id, consultation_session_status_id, expires_at, created_at, updated_at`
+
+	const linkReferral = `UPDATE referrals SET referred_session_id = $1, updated_at = now()
+	WHERE id = $2 AND referred_session_id IS NULL AND deleted_at IS NULL`
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(tx *sql.Tx) {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}(tx)
+
+	row := tx.QueryRowContext(ctx, createSession, session.UserId, session.DependentId, session.DoctorId, session.ConsultationSessionStatusId)
+	var created entity.ConsultationSession
+	err = row.Scan(
+		&created.Id, &created.UserId, &created.DependentId, &created.DoctorId, &created.ConsultationSessionStatusId,
+		&created.ExpiresAt, &created.CreatedAt, &created.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, linkReferral, created.Id, referralId)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if linked == 0 {
+		err = apperror.ErrReferralAlreadyUsed
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
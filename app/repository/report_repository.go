@@ -14,6 +14,8 @@ type ReportRepository interface {
 	CountSalesAllPharmacy(ctx context.Context, year int64, param *queryparamdto.GetAllParams) (int64, error)
 	FindSalesAllPharmacyMonthly(ctx context.Context, year int64, param *queryparamdto.GetAllParams) ([]*entity.SellReportMonthly, error)
 	CountSalesAllPharmacyMonthly(ctx context.Context, year int64, param *queryparamdto.GetAllParams) (int64, error)
+	FindSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.SellPriceReport, error)
+	CountSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error)
 }
 
 type ReportRepositoryImpl struct {
@@ -175,6 +177,64 @@ func (repo ReportRepositoryImpl) CountSalesAllPharmacyMonthly(ctx context.Contex
 	return int64(len(items)), err
 }
 
+// FindSellPrices lists the confirmed sales with the price the product was sold at and its current price in the same
+// pharmacy
+func (repo ReportRepositoryImpl) FindSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.SellPriceReport, error) {
+	const sellPrices = `SELECT orders.id, order_details.id, orders.date, pharmacies.id, pharmacies.name, pharmacy_products.id,
+		order_details.product_id, order_details.name, order_details.quantity, order_details.price, pharmacy_products.price,
+		pharmacy_products.price - order_details.price
+	FROM order_details
+			 INNER JOIN orders ON order_details.order_id = orders.id
+			 INNER JOIN pharmacies ON orders.pharmacy_id = pharmacies.id
+			 INNER JOIN pharmacy_products ON pharmacy_products.pharmacy_id = orders.pharmacy_id AND pharmacy_products.product_id = order_details.product_id
+			 INNER JOIN order_status_logs ON orders.id = order_status_logs.order_id
+	WHERE order_status_logs.is_latest = true AND order_status_logs.order_status_id = 4 `
+
+	query, values := buildQuery(sellPrices, &entity.OrderDetail{}, param, true, true)
+
+	rows, err := repo.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.SellPriceReport, 0)
+	for rows.Next() {
+		var sellPrice entity.SellPriceReport
+
+		if err := rows.Scan(
+			&sellPrice.OrderId, &sellPrice.OrderDetailId, &sellPrice.OrderDate, &sellPrice.PharmacyId, &sellPrice.PharmacyName, &sellPrice.PharmacyProductId,
+			&sellPrice.ProductId, &sellPrice.ProductName, &sellPrice.Quantity, &sellPrice.SoldPrice, &sellPrice.CurrentPrice,
+			&sellPrice.PriceDifference,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &sellPrice)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, err
+}
+
+func (repo ReportRepositoryImpl) CountSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) (int64, error) {
+	const sellPrices = `SELECT count(order_details.id)
+	FROM order_details
+			 INNER JOIN orders ON order_details.order_id = orders.id
+			 INNER JOIN pharmacies ON orders.pharmacy_id = pharmacies.id
+			 INNER JOIN pharmacy_products ON pharmacy_products.pharmacy_id = orders.pharmacy_id AND pharmacy_products.product_id = order_details.product_id
+			 INNER JOIN order_status_logs ON orders.id = order_status_logs.order_id
+	WHERE order_status_logs.is_latest = true AND order_status_logs.order_status_id = 4 `
+
+	query, values := buildQuery(sellPrices, &entity.OrderDetail{}, param, false, false)
+
+	var totalItems int64
+	if err := repo.db.QueryRowContext(ctx, query, values...).Scan(&totalItems); err != nil {
+		return 0, err
+	}
+	return totalItems, nil
+}
+
 func NewReportRepositoryImpl(db *sql.DB) *ReportRepositoryImpl {
 	repo := ReportRepositoryImpl{db: db}
 	return &repo
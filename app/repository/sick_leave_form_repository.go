@@ -13,7 +13,9 @@ type SickLeaveFormRepository interface {
 	Create(ctx context.Context, form entity.SickLeaveForm) (*entity.SickLeaveForm, error)
 	FindBySessionId(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error)
 	FindBySessionIdDetailed(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error)
+	FindByIdDetailed(ctx context.Context, id int64) (*entity.SickLeaveForm, error)
 	UpdateBySessionId(ctx context.Context, form entity.SickLeaveForm) (*entity.SickLeaveForm, error)
+	CreateVerification(ctx context.Context, verification entity.SickLeaveFormVerification) (*entity.SickLeaveFormVerification, error)
 }
 
 type SickLeaveFormRepositoryImpl struct {
@@ -30,6 +32,10 @@ func (repo *SickLeaveFormRepositoryImpl) Create(ctx context.Context, form entity
 	VALUES ($1, $2, $3, $4)
 	RETURNING id, session_id, starting_date, ending_date, description, created_at, updated_at`
 
+	if err := encryptFields(&form.Description); err != nil {
+		return nil, err
+	}
+
 	row := repo.db.QueryRowContext(ctx, createSickLeave, form.SessionId, form.StartingDate, form.EndingDate, form.Description)
 	if row.Err() != nil {
 		var errPgConn *pgconn.PgError
@@ -41,8 +47,14 @@ func (repo *SickLeaveFormRepositoryImpl) Create(ctx context.Context, form entity
 
 	var created entity.SickLeaveForm
 	err := row.Scan(&created.Id, &created.SessionId, &created.StartingDate, &created.EndingDate, &created.Description, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
 
-	return &created, err
+	if err = decryptFields(&created.Description); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
 func (repo *SickLeaveFormRepositoryImpl) FindBySessionId(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error) {
@@ -65,27 +77,42 @@ func (repo *SickLeaveFormRepositoryImpl) FindBySessionId(ctx context.Context, se
 		}
 		return nil, err
 	}
+
+	if err = decryptFields(&form.Description); err != nil {
+		return nil, err
+	}
 	return &form, nil
 }
 
-func (repo *SickLeaveFormRepositoryImpl) FindBySessionIdDetailed(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error) {
-	const findById = `
+// findSickLeaveFormDetailed names the dependent as the patient when the session was booked for one
+const findSickLeaveFormDetailed = `
 	SELECT sick_leave_forms.id, sick_leave_forms.session_id, starting_date, ending_date,
 		   description, sick_leave_forms.created_at, sick_leave_forms.updated_at,
 		   prescriptions.symptoms, prescriptions.diagnosis,
-		   user_profiles.name, user_profiles.date_of_birth, users1.email,
+		   COALESCE(dependents.name, user_profiles.name), COALESCE(dependents.date_of_birth, user_profiles.date_of_birth), users1.email,
 		   doctor_profiles.name, doctor_specializations.name, users2.email
 	FROM sick_leave_forms
 			 INNER JOIN consultation_sessions ON sick_leave_forms.session_id = consultation_sessions.id
 			 INNER JOIN prescriptions ON consultation_sessions.id = prescriptions.session_id
 			 INNER JOIN user_profiles ON consultation_sessions.user_id = user_profiles.user_id
 			 INNER JOIN users AS users1 ON user_profiles.user_id = users1.id
+			 LEFT JOIN dependents ON consultation_sessions.dependent_id = dependents.id
 			 INNER JOIN doctor_profiles ON consultation_sessions.doctor_id = doctor_profiles.user_id
 			 INNER JOIN users AS users2 ON doctor_profiles.user_id = users2.id
-			 INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id
-	WHERE sick_leave_forms.session_id = $1;`
+			 INNER JOIN doctor_specializations ON doctor_profiles.doctor_specialization_id = doctor_specializations.id `
 
-	row := repo.db.QueryRowContext(ctx, findById, sessionId)
+func (repo *SickLeaveFormRepositoryImpl) FindBySessionIdDetailed(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error) {
+	const findBySessionId = findSickLeaveFormDetailed + `WHERE sick_leave_forms.session_id = $1;`
+	return repo.findDetailed(ctx, findBySessionId, sessionId)
+}
+
+func (repo *SickLeaveFormRepositoryImpl) FindByIdDetailed(ctx context.Context, id int64) (*entity.SickLeaveForm, error) {
+	const findById = findSickLeaveFormDetailed + `WHERE sick_leave_forms.id = $1 AND sick_leave_forms.deleted_at IS NULL;`
+	return repo.findDetailed(ctx, findById, id)
+}
+
+func (repo *SickLeaveFormRepositoryImpl) findDetailed(ctx context.Context, query string, args ...any) (*entity.SickLeaveForm, error) {
+	row := repo.db.QueryRowContext(ctx, query, args...)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
@@ -112,6 +139,10 @@ func (repo *SickLeaveFormRepositoryImpl) FindBySessionIdDetailed(ctx context.Con
 		return nil, err
 	}
 
+	if err = decryptFields(&form.Description, &prescription.Symptoms, &prescription.Diagnosis); err != nil {
+		return nil, err
+	}
+
 	user.UserProfile = &userProfile
 	doctorProfile.DoctorSpecialization = &doctorSpecialization
 	doctor.DoctorProfile = &doctorProfile
@@ -130,9 +161,36 @@ func (repo *SickLeaveFormRepositoryImpl) UpdateBySessionId(ctx context.Context,
 	WHERE session_id = $4
 	RETURNING id, session_id, starting_date, ending_date, description, created_at, updated_at`
 
+	if err := encryptFields(&form.Description); err != nil {
+		return nil, err
+	}
+
 	row := repo.db.QueryRowContext(ctx, updateSickLeave, form.StartingDate, form.EndingDate, form.Description, form.SessionId)
 	var updated entity.SickLeaveForm
 	err := row.Scan(&updated.Id, &updated.SessionId, &updated.StartingDate, &updated.EndingDate, &updated.Description, &updated.CreatedAt, &updated.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decryptFields(&updated.Description); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (repo *SickLeaveFormRepositoryImpl) CreateVerification(ctx context.Context, verification entity.SickLeaveFormVerification) (*entity.SickLeaveFormVerification, error) {
+	const createVerification = `
+	INSERT INTO sick_leave_form_verifications(sick_leave_form_id, is_valid, ip_address, user_agent)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, sick_leave_form_id, is_valid, ip_address, user_agent, created_at, updated_at`
 
-	return &updated, err
+	row := repo.db.QueryRowContext(ctx, createVerification,
+		verification.SickLeaveFormId, verification.IsValid, verification.IpAddress, verification.UserAgent,
+	)
+
+	var created entity.SickLeaveFormVerification
+	err := row.Scan(
+		&created.Id, &created.SickLeaveFormId, &created.IsValid, &created.IpAddress, &created.UserAgent, &created.CreatedAt, &created.UpdatedAt,
+	)
+	return &created, err
 }
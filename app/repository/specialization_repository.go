@@ -28,25 +28,25 @@ func NewDoctorSpecializationRepositoryImpl(db *sql.DB) *DoctorSpecializationRepo
 }
 
 func (repo *DoctorSpecializationRepositoryImpl) Create(ctx context.Context, specialization entity.DoctorSpecialization) (*entity.DoctorSpecialization, error) {
-	const create = `INSERT INTO doctor_specializations(name, image)
-	VALUES ($1, $2) RETURNING id, name, image, created_at, updated_at, deleted_at`
+	const create = `INSERT INTO doctor_specializations(name, image, session_duration_minutes)
+	VALUES ($1, $2, $3) RETURNING id, name, image, session_duration_minutes, created_at, updated_at, deleted_at`
 
-	row := repo.db.QueryRowContext(ctx, create, specialization.Name, specialization.Image)
+	row := repo.db.QueryRowContext(ctx, create, specialization.Name, specialization.Image, specialization.SessionDurationMinutes)
 	var created entity.DoctorSpecialization
 	err := row.Scan(
-		&created.Id, &created.Name, &created.Image, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
+		&created.Id, &created.Name, &created.Image, &created.SessionDurationMinutes, &created.CreatedAt, &created.UpdatedAt, &created.DeletedAt,
 	)
 
 	return &created, err
 }
 
 func (repo *DoctorSpecializationRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.DoctorSpecialization, error) {
-	const findById = `SELECT id, name, image, created_at, updated_at, deleted_at FROM doctor_specializations WHERE id = $1 AND deleted_at IS NULL`
+	const findById = `SELECT id, name, image, session_duration_minutes, created_at, updated_at, deleted_at FROM doctor_specializations WHERE id = $1 AND deleted_at IS NULL`
 
 	row := repo.db.QueryRowContext(ctx, findById, id)
 	var specialization entity.DoctorSpecialization
 	err := row.Scan(
-		&specialization.Id, &specialization.Name, &specialization.Image, &specialization.CreatedAt, &specialization.UpdatedAt, &specialization.DeletedAt,
+		&specialization.Id, &specialization.Name, &specialization.Image, &specialization.SessionDurationMinutes, &specialization.CreatedAt, &specialization.UpdatedAt, &specialization.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -59,7 +59,7 @@ func (repo *DoctorSpecializationRepositoryImpl) FindById(ctx context.Context, id
 }
 
 func (repo *DoctorSpecializationRepositoryImpl) FindAll(ctx context.Context, param *queryparamdto.GetAllParams) ([]*entity.DoctorSpecialization, error) {
-	initQuery := `SELECT id, name, image FROM doctor_specializations WHERE deleted_at IS NULL `
+	initQuery := `SELECT id, name, image, session_duration_minutes FROM doctor_specializations WHERE deleted_at IS NULL `
 	query, values := buildQuery(initQuery, &entity.DoctorSpecialization{}, param, true, true)
 
 	rows, err := repo.db.QueryContext(ctx, query, values...)
@@ -72,7 +72,7 @@ func (repo *DoctorSpecializationRepositoryImpl) FindAll(ctx context.Context, par
 	for rows.Next() {
 		var specialization entity.DoctorSpecialization
 		if err := rows.Scan(
-			&specialization.Id, &specialization.Name, &specialization.Image,
+			&specialization.Id, &specialization.Name, &specialization.Image, &specialization.SessionDurationMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -144,12 +144,13 @@ func (repo *DoctorSpecializationRepositoryImpl) FindAllWithoutParams(ctx context
 
 func (repo *DoctorSpecializationRepositoryImpl) Update(ctx context.Context, specialization entity.DoctorSpecialization) (*entity.DoctorSpecialization, error) {
 	const update = `UPDATE doctor_specializations
-	SET name = $1, image = $2 WHERE id = $3 AND deleted_at IS NULL RETURNING id, name, image, created_at, updated_at, deleted_at`
+	SET name = $1, image = $2, session_duration_minutes = $3 WHERE id = $4 AND deleted_at IS NULL
+	RETURNING id, name, image, session_duration_minutes, created_at, updated_at, deleted_at`
 
-	row := repo.db.QueryRowContext(ctx, update, specialization.Name, specialization.Image, specialization.Id)
+	row := repo.db.QueryRowContext(ctx, update, specialization.Name, specialization.Image, specialization.SessionDurationMinutes, specialization.Id)
 	var updated entity.DoctorSpecialization
 	err := row.Scan(
-		&updated.Id, &updated.Name, &updated.Image, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
+		&updated.Id, &updated.Name, &updated.Image, &updated.SessionDurationMinutes, &updated.CreatedAt, &updated.UpdatedAt, &updated.DeletedAt,
 	)
 
 	return &updated, err
@@ -46,17 +46,48 @@ func (repo *TransactionRepositoryImpl) FindTotalPaymentAndStatusByTransactionId(
 }
 
 func (repo *TransactionRepositoryImpl) UpdateTransaction(ctx context.Context, transaction entity.Transaction) (*entity.Transaction, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if transaction.TransactionStatus.Id == appconstant.CanceledTransactionStatusId {
+		const findOrderIds = `SELECT id FROM orders WHERE transaction_id = $1`
+		rows, err := tx.QueryContext(ctx, findOrderIds, transaction.Id)
+		if err != nil {
+			return nil, err
+		}
+		orderIds := make([]int64, 0)
+		for rows.Next() {
+			var orderId int64
+			if err := rows.Scan(&orderId); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			orderIds = append(orderIds, orderId)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := releasePrescriptionProducts(ctx, tx, orderIds); err != nil {
+			return nil, err
+		}
+	}
+
 	const updateTransaction = `UPDATE transactions 
 	SET payment_proof = $1, transaction_status_id = $2, updated_at = now() WHERE id = $3 AND deleted_at IS NULL
 	RETURNING id, date, payment_proof, transaction_status_id, payment_method_id, address, user_id, total_payment`
 
-	row := repo.db.QueryRowContext(ctx, updateTransaction,
+	row := tx.QueryRowContext(ctx, updateTransaction,
 		transaction.PaymentProof,
 		transaction.TransactionStatus.Id,
 		transaction.Id,
 	)
 	var updatedTransaction entity.Transaction
-	err := row.Scan(
+	err = row.Scan(
 		&updatedTransaction.Id,
 		&updatedTransaction.Date,
 		&updatedTransaction.PaymentProof,
@@ -66,7 +97,14 @@ func (repo *TransactionRepositoryImpl) UpdateTransaction(ctx context.Context, tr
 		&updatedTransaction.UserId,
 		&updatedTransaction.TotalPayment,
 	)
-	return &updatedTransaction, err
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &updatedTransaction, nil
 }
 
 func (repo *TransactionRepositoryImpl) FindTransactionById(ctx context.Context, id int64) (*entity.Transaction, error) {
@@ -286,12 +324,12 @@ func (repo *TransactionRepositoryImpl) Create(ctx context.Context, transaction e
 
 	for _, order := range transaction.Orders {
 		const createOrder = `
-		INSERT INTO orders(date, pharmacy_id, no_of_items, pharmacy_address, shipping_method_id, shipping_cost, total_payment, transaction_id)
-		values (now(), $1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO orders(date, pharmacy_id, no_of_items, pharmacy_address, shipping_method_id, shipping_cost, total_payment, transaction_id, dependent_id)
+		values (now(), $1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id`
 		row = tx.QueryRowContext(ctx, createOrder,
 			order.PharmacyId, order.NoOfItems, order.PharmacyAddress, order.ShippingMethodId, order.ShippingCost,
-			order.TotalPayment, createdTransaction.Id,
+			order.TotalPayment, createdTransaction.Id, order.DependentId,
 		)
 		var orderId int64
 		err = row.Scan(
@@ -303,17 +341,24 @@ func (repo *TransactionRepositoryImpl) Create(ctx context.Context, transaction e
 
 		for _, detail := range order.OrderDetails {
 			const createDetail = `
-			INSERT INTO order_details(order_id, product_id, quantity, name, generic_name, content, description, image, price)
-			values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			INSERT INTO order_details(order_id, product_id, quantity, name, generic_name, content, description, image, price, prescription_product_id)
+			values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 			_, err = tx.ExecContext(ctx, createDetail,
 				orderId, detail.ProductId, detail.Quantity, detail.Name, detail.GenericName, detail.Content,
-				detail.Description, detail.Image, detail.Price,
+				detail.Description, detail.Image, detail.Price, detail.PrescriptionProductId,
 			)
 			if err != nil {
 				return nil, err
 			}
 
+			if detail.PrescriptionProductId.Valid {
+				err = redeemPrescriptionProduct(ctx, tx, detail.PrescriptionProductId.Int64, detail.Quantity)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 		}
 
 		const addStatus = `INSERT INTO order_status_logs(order_id, order_status_id, is_latest, description)
@@ -375,7 +420,7 @@ func (repo *TransactionRepositoryImpl) findAllOrdersByTransactionId(ctx context.
 }
 
 func (repo *TransactionRepositoryImpl) findAllOrderDetailsByOrderId(ctx context.Context, orderId int64) ([]*entity.OrderDetail, error) {
-	const getAllOrderDetails = `SELECT order_details.id, quantity, name, generic_name, content, description, image, price FROM order_details
+	const getAllOrderDetails = `SELECT order_details.id, quantity, name, generic_name, content, description, image, price, prescription_product_id FROM order_details
 	INNER JOIN orders ON order_details.order_id = orders.id WHERE orders.id = $1`
 
 	rows, err := repo.db.QueryContext(ctx, getAllOrderDetails, orderId)
@@ -389,7 +434,7 @@ func (repo *TransactionRepositoryImpl) findAllOrderDetailsByOrderId(ctx context.
 		var orderDetail entity.OrderDetail
 		if err := rows.Scan(
 			&orderDetail.Id, &orderDetail.Quantity, &orderDetail.Name, &orderDetail.GenericName,
-			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price,
+			&orderDetail.Content, &orderDetail.Description, &orderDetail.Image, &orderDetail.Price, &orderDetail.PrescriptionProductId,
 		); err != nil {
 			return nil, err
 		}
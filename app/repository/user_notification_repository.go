@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+)
+
+type UserNotificationRepository interface {
+	FindById(ctx context.Context, id int64) (*entity.UserNotification, error)
+	FindAllByUserId(ctx context.Context, userId int64, limit int) ([]*entity.UserNotification, error)
+	UpdateAsRead(ctx context.Context, id int64) (*entity.UserNotification, error)
+}
+
+type UserNotificationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUserNotificationRepositoryImpl(db *sql.DB) *UserNotificationRepositoryImpl {
+	return &UserNotificationRepositoryImpl{db: db}
+}
+
+func (repo *UserNotificationRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.UserNotification, error) {
+	const findById = `SELECT id, user_id, product_id, title, message, is_read, created_at, updated_at
+	FROM user_notifications WHERE id = $1`
+
+	notification, err := repo.scan(repo.db.QueryRowContext(ctx, findById, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return notification, nil
+}
+
+// FindAllByUserId lists the latest notifications of the user first
+func (repo *UserNotificationRepositoryImpl) FindAllByUserId(ctx context.Context, userId int64, limit int) ([]*entity.UserNotification, error) {
+	const findAllByUserId = `SELECT id, user_id, product_id, title, message, is_read, created_at, updated_at
+	FROM user_notifications WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`
+
+	rows, err := repo.db.QueryContext(ctx, findAllByUserId, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.UserNotification, 0)
+	for rows.Next() {
+		notification, err := repo.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *UserNotificationRepositoryImpl) UpdateAsRead(ctx context.Context, id int64) (*entity.UserNotification, error) {
+	const updateAsRead = `UPDATE user_notifications SET is_read = true, updated_at = now() WHERE id = $1
+	RETURNING id, user_id, product_id, title, message, is_read, created_at, updated_at`
+
+	notification, err := repo.scan(repo.db.QueryRowContext(ctx, updateAsRead, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (repo *UserNotificationRepositoryImpl) scan(row interface{ Scan(...any) error }) (*entity.UserNotification, error) {
+	var notification entity.UserNotification
+	if err := row.Scan(
+		&notification.Id, &notification.UserId, &notification.ProductId, &notification.Title, &notification.Message,
+		&notification.IsRead, &notification.CreatedAt, &notification.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
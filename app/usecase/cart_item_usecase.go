@@ -16,21 +16,31 @@ type CartItemUseCase interface {
 	GetAllByUserId(ctx context.Context) (*entity.PaginatedItems, error)
 	Edit(ctx context.Context, existingCartItem entity.CartItem, cartItem entity.CartItem) (*entity.CartItem, error)
 	Remove(ctx context.Context, productIds []int64) error
-	Checkout(ctx context.Context, param *queryparamdto.GetAllParams, cartItemId ...int64) (*entity.PaginatedItems, error)
+	Checkout(ctx context.Context, param, suggestionParam *queryparamdto.GetAllParams, cartItemId ...int64) (*entity.PaginatedItems, error)
 }
 
 type CartItemUseCaseImpl struct {
 	cartItemRepo        repository.CartItemRepository
 	productRepo         repository.ProductRepository
 	pharmacyProductRepo repository.PharmacyProductRepository
+	prescriptionRepo    repository.PrescriptionRepository
+	recommendationRepo  repository.ProductRecommendationRepository
 }
 
 func NewCartItemUseCaseImpl(
 	cartItemRepo repository.CartItemRepository,
 	productRepo repository.ProductRepository,
 	pharmacyProductRepo repository.PharmacyProductRepository,
+	prescriptionRepo repository.PrescriptionRepository,
+	recommendationRepo repository.ProductRecommendationRepository,
 ) *CartItemUseCaseImpl {
-	return &CartItemUseCaseImpl{cartItemRepo: cartItemRepo, productRepo: productRepo, pharmacyProductRepo: pharmacyProductRepo}
+	return &CartItemUseCaseImpl{
+		cartItemRepo:        cartItemRepo,
+		productRepo:         productRepo,
+		pharmacyProductRepo: pharmacyProductRepo,
+		prescriptionRepo:    prescriptionRepo,
+		recommendationRepo:  recommendationRepo,
+	}
 }
 
 func (uc *CartItemUseCaseImpl) Add(ctx context.Context, cartItem entity.CartItem) (*entity.CartItem, error) {
@@ -41,14 +51,25 @@ func (uc *CartItemUseCaseImpl) Add(ctx context.Context, cartItem entity.CartItem
 	if err != nil && errors.Is(err, apperror.ErrRecordNotFound) {
 		return nil, apperror.NewNotFound(product, "Id", cartItem.ProductId)
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	found, err := uc.cartItemRepo.FindByUserIdAndProductId(ctx, cartItem.UserId, cartItem.ProductId)
-	if err == nil {
-		return uc.Edit(ctx, *found, cartItem)
-	}
 	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
 		return nil, err
 	}
+	if found != nil && !cartItem.PrescriptionProductId.Valid {
+		cartItem.PrescriptionProductId = found.PrescriptionProductId
+	}
+
+	if product.DrugClassificationId == appconstant.DrugClassificationIdObatKeras && !cartItem.PrescriptionProductId.Valid {
+		return nil, apperror.ErrPrescriptionRequired
+	}
+
+	if found != nil {
+		return uc.Edit(ctx, *found, cartItem)
+	}
 
 	if cartItem.Quantity <= 0 {
 		return nil, apperror.ErrProductAddedToCartMustHaveAtLeastOne
@@ -66,6 +87,12 @@ func (uc *CartItemUseCaseImpl) Add(ctx context.Context, cartItem entity.CartItem
 		return nil, apperror.ErrProductStockNotEnoughToAddToCart
 	}
 
+	if cartItem.PrescriptionProductId.Valid {
+		if err := uc.validatePrescriptionProduct(ctx, cartItem); err != nil {
+			return nil, err
+		}
+	}
+
 	created, err := uc.cartItemRepo.Create(ctx, cartItem)
 	if err != nil {
 		return nil, err
@@ -121,6 +148,15 @@ func (uc *CartItemUseCaseImpl) Edit(ctx context.Context, existingCartItem entity
 		return nil, apperror.ErrProductAddedToCartMustHaveAtLeastOne
 	}
 
+	if !cartItem.PrescriptionProductId.Valid {
+		cartItem.PrescriptionProductId = existingCartItem.PrescriptionProductId
+	}
+	if cartItem.PrescriptionProductId.Valid {
+		if err := uc.validatePrescriptionProduct(ctx, cartItem); err != nil {
+			return nil, err
+		}
+	}
+
 	updated, err := uc.cartItemRepo.Update(ctx, cartItem)
 	if err != nil {
 		return nil, err
@@ -138,7 +174,7 @@ func (uc *CartItemUseCaseImpl) Remove(ctx context.Context, productIds []int64) e
 	return nil
 }
 
-func (uc *CartItemUseCaseImpl) Checkout(ctx context.Context, param *queryparamdto.GetAllParams, cartItemId ...int64) (*entity.PaginatedItems, error) {
+func (uc *CartItemUseCaseImpl) Checkout(ctx context.Context, param, suggestionParam *queryparamdto.GetAllParams, cartItemId ...int64) (*entity.PaginatedItems, error) {
 	cartItems, err := uc.cartItemRepo.FindByMultipleIds(ctx, cartItemId...)
 	if err != nil {
 		return nil, err
@@ -172,9 +208,30 @@ func (uc *CartItemUseCaseImpl) Checkout(ctx context.Context, param *queryparamdt
 		}
 	}
 
+	productIds := make([]int64, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+		productIds = append(productIds, cartItem.ProductId)
+	}
+	suggestions, err := uc.recommendationRepo.FindAllRelatedForUser(ctx, productIds, appconstant.CartSuggestionsLimit, suggestionParam)
+	if err != nil {
+		return nil, err
+	}
+
 	paginatedItems := entity.NewPaginationInfo(
 		int64(len(cartItems)), 1, int64(len(cartItems)), 1, cartItems,
 	)
+	paginatedItems.Suggestions = suggestions
 
 	return paginatedItems, nil
 }
+
+func (uc *CartItemUseCaseImpl) validatePrescriptionProduct(ctx context.Context, cartItem entity.CartItem) error {
+	prescriptionProduct, err := uc.prescriptionRepo.FindPrescriptionProductById(ctx, cartItem.PrescriptionProductId.Int64)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(prescriptionProduct, "Id", cartItem.PrescriptionProductId.Int64)
+		}
+		return err
+	}
+	return validatePrescriptionProductRedemption(prescriptionProduct, cartItem.UserId, cartItem.ProductId, cartItem.Quantity)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type ClinicalNoteUseCase interface {
+	GetBySessionId(ctx context.Context, sessionId int64) (*entity.ClinicalNote, error)
+	GetAllVersionsBySessionId(ctx context.Context, sessionId int64) ([]*entity.ClinicalNoteVersion, error)
+	EditBySessionId(ctx context.Context, sessionId int64, note entity.ClinicalNote) (*entity.ClinicalNote, error)
+}
+
+type ClinicalNoteUseCaseImpl struct {
+	repo        repository.ClinicalNoteRepository
+	sessionRepo repository.ConsultationSessionRepository
+}
+
+func NewClinicalNoteUseCaseImpl(repo repository.ClinicalNoteRepository, sessionRepo repository.ConsultationSessionRepository) *ClinicalNoteUseCaseImpl {
+	return &ClinicalNoteUseCaseImpl{repo: repo, sessionRepo: sessionRepo}
+}
+
+func (uc *ClinicalNoteUseCaseImpl) GetBySessionId(ctx context.Context, sessionId int64) (*entity.ClinicalNote, error) {
+	if _, err := uc.findTreatedSession(ctx, sessionId); err != nil {
+		return nil, err
+	}
+
+	note, err := uc.repo.FindBySessionId(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(note, "SessionId", sessionId)
+		}
+		return nil, err
+	}
+	return note, nil
+}
+
+func (uc *ClinicalNoteUseCaseImpl) GetAllVersionsBySessionId(ctx context.Context, sessionId int64) ([]*entity.ClinicalNoteVersion, error) {
+	if _, err := uc.findTreatedSession(ctx, sessionId); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindAllVersionsBySessionId(ctx, sessionId)
+}
+
+func (uc *ClinicalNoteUseCaseImpl) EditBySessionId(ctx context.Context, sessionId int64, note entity.ClinicalNote) (*entity.ClinicalNote, error) {
+	sessionDb, err := uc.findTreatedSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	note.SessionId = sessionDb.Id
+	note.DoctorId = sessionDb.DoctorId
+	return uc.repo.Save(ctx, note)
+}
+
+// findTreatedSession only lets the doctor of the session through, patients never reach clinical notes
+func (uc *ClinicalNoteUseCaseImpl) findTreatedSession(ctx context.Context, sessionId int64) (*entity.ConsultationSession, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", sessionId)
+		}
+		return nil, err
+	}
+
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	return sessionDb, nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appcloud"
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type ConsultationSessionExtensionUseCase interface {
+	Add(ctx context.Context, sessionId int64) (*entity.ConsultationSessionExtension, error)
+	GetAllBySessionId(ctx context.Context, sessionId int64) ([]*entity.ConsultationSessionExtension, error)
+	EditStatus(ctx context.Context, sessionId int64, id int64, isAccepted bool) (*entity.ConsultationSessionExtension, error)
+}
+
+type ConsultationSessionExtensionUseCaseImpl struct {
+	extensionRepo           repository.ConsultationSessionExtensionRepository
+	sessionRepo             repository.ConsultationSessionRepository
+	uploader                appcloud.FileUploader
+	cloudFolderPaymentProof string
+}
+
+func NewConsultationSessionExtensionUseCaseImpl(
+	extensionRepo repository.ConsultationSessionExtensionRepository,
+	sessionRepo repository.ConsultationSessionRepository,
+	uploader appcloud.FileUploader,
+) *ConsultationSessionExtensionUseCaseImpl {
+	return &ConsultationSessionExtensionUseCaseImpl{
+		extensionRepo:           extensionRepo,
+		sessionRepo:             sessionRepo,
+		uploader:                uploader,
+		cloudFolderPaymentProof: appconfig.Config.GcloudStoragePaymentProofs,
+	}
+}
+
+func (uc *ConsultationSessionExtensionUseCaseImpl) Add(ctx context.Context, sessionId int64) (*entity.ConsultationSessionExtension, error) {
+	sessionDb, err := uc.findSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+
+	if sessionDb.ConsultationSessionStatusId != appconstant.ConsultationSessionStatusOngoing {
+		return nil, apperror.ErrChatAlreadyEnded
+	}
+
+	proof := ctx.Value(appconstant.FormPaymentProof)
+	if proof == nil {
+		return nil, apperror.ErrConsultationSessionExtensionPaymentProofRequired
+	}
+
+	url, err := uc.uploader.UploadFromFileHeader(ctx, proof, uc.cloudFolderPaymentProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.extensionRepo.Create(ctx, entity.ConsultationSessionExtension{SessionId: sessionId, PaymentProof: url})
+}
+
+func (uc *ConsultationSessionExtensionUseCaseImpl) GetAllBySessionId(ctx context.Context, sessionId int64) ([]*entity.ConsultationSessionExtension, error) {
+	sessionDb, err := uc.findSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.UserId != userId && sessionDb.DoctorId != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	return uc.extensionRepo.FindAllBySessionId(ctx, sessionId)
+}
+
+// EditStatus lets the doctor who receives the fee check the payment proof, accepting it extends the session
+func (uc *ConsultationSessionExtensionUseCaseImpl) EditStatus(ctx context.Context, sessionId int64, id int64, isAccepted bool) (*entity.ConsultationSessionExtension, error) {
+	sessionDb, err := uc.findSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+
+	extensionDb, err := uc.extensionRepo.FindById(ctx, id)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return nil, err
+	}
+	if extensionDb == nil || extensionDb.SessionId != sessionId {
+		return nil, apperror.NewNotFound(&entity.ConsultationSessionExtension{}, "Id", id)
+	}
+
+	status := appconstant.ConsultationSessionExtensionStatusRejected
+	if isAccepted {
+		status = appconstant.ConsultationSessionExtensionStatusAccepted
+	}
+
+	return uc.extensionRepo.UpdateStatus(ctx, id, status)
+}
+
+func (uc *ConsultationSessionExtensionUseCaseImpl) findSession(ctx context.Context, sessionId int64) (*entity.ConsultationSession, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(&entity.ConsultationSession{}, "Id", sessionId)
+		}
+		return nil, err
+	}
+	return sessionDb, nil
+}
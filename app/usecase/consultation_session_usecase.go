@@ -5,6 +5,7 @@ import (
 	"errors"
 	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/applogger"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/repository"
@@ -17,6 +18,7 @@ type ConsultationSessionUseCase interface {
 	GetAllByUserIdOrDoctorId(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	EditTime(ctx context.Context, id int64) (*entity.ConsultationSession, error)
 	EditStatusAsEnded(ctx context.Context, id int64) (*entity.ConsultationSession, error)
+	EditAllExpiredAsEnded(ctx context.Context) ([]*entity.ConsultationSession, error)
 }
 
 type ConsultationSessionUseCaseImpl struct {
@@ -24,6 +26,8 @@ type ConsultationSessionUseCaseImpl struct {
 	prescriptionRepo repository.PrescriptionRepository
 	sickLeaveRepo    repository.SickLeaveFormRepository
 	userRepo         repository.UserRepository
+	dependentRepo    repository.DependentRepository
+	clinicalNoteRepo repository.ClinicalNoteRepository
 }
 
 func NewConsultationSessionUseCaseImpl(
@@ -31,9 +35,12 @@ func NewConsultationSessionUseCaseImpl(
 	prescriptionRepo repository.PrescriptionRepository,
 	sickLeaveRepo repository.SickLeaveFormRepository,
 	userRepo repository.UserRepository,
+	dependentRepo repository.DependentRepository,
+	clinicalNoteRepo repository.ClinicalNoteRepository,
 ) *ConsultationSessionUseCaseImpl {
 	return &ConsultationSessionUseCaseImpl{
 		sessionRepo: sessionRepo, prescriptionRepo: prescriptionRepo, sickLeaveRepo: sickLeaveRepo, userRepo: userRepo,
+		dependentRepo: dependentRepo, clinicalNoteRepo: clinicalNoteRepo,
 	}
 }
 
@@ -48,7 +55,11 @@ func (uc *ConsultationSessionUseCaseImpl) Add(ctx context.Context, session entit
 	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
 	session.UserId = userId
 
-	sessionDb, err := uc.sessionRepo.FindByUserIdAndDoctorId(ctx, session.UserId, session.DoctorId)
+	if err = validateDependentOfUser(ctx, uc.dependentRepo, userId, session.DependentId); err != nil {
+		return nil, err
+	}
+
+	sessionDb, err := uc.sessionRepo.FindByPatientAndDoctorId(ctx, session.UserId, session.DependentId, session.DoctorId)
 	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
 		return nil, err
 	}
@@ -110,6 +121,15 @@ func (uc *ConsultationSessionUseCaseImpl) GetById(ctx context.Context, id int64)
 		sessionDb.SickLeaveForm = sickLeave
 	}
 
+	// the clinical note is private to the treating doctor, the ownership check above already ran for doctors
+	if roleId == appconstant.UserRoleIdDoctor {
+		clinicalNote, err := uc.clinicalNoteRepo.FindBySessionId(ctx, sessionDb.Id)
+		if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, err
+		}
+		sessionDb.ClinicalNote = clinicalNote
+	}
+
 	return sessionDb, nil
 }
 
@@ -182,3 +202,26 @@ func (uc *ConsultationSessionUseCaseImpl) EditStatusAsEnded(ctx context.Context,
 	}
 	return updated, nil
 }
+
+// EditAllExpiredAsEnded ends every session that ran out of time on behalf of its doctor, a session that fails to end
+// is logged and retried on the next run
+func (uc *ConsultationSessionUseCaseImpl) EditAllExpiredAsEnded(ctx context.Context) ([]*entity.ConsultationSession, error) {
+	expiredSessions, err := uc.sessionRepo.FindAllExpired(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ended := make([]*entity.ConsultationSession, 0)
+	for _, session := range expiredSessions {
+		doctorCtx := context.WithValue(ctx, appconstant.ContextKeyUserId, session.DoctorId)
+		doctorCtx = context.WithValue(doctorCtx, appconstant.ContextKeyRoleId, int64(appconstant.UserRoleIdDoctor))
+
+		updated, err := uc.EditStatusAsEnded(doctorCtx, session.Id)
+		if err != nil {
+			applogger.Log.Errorf("error ending expired consultation session %d: %v", session.Id, err)
+			continue
+		}
+		ended = append(ended, updated)
+	}
+	return ended, nil
+}
@@ -1,9 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"github.com/robfig/cron/v3"
 	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/applogger"
 	"halodeksik-be/app/repository"
+	"halodeksik-be/app/util"
+	"time"
 )
 
 type CronUseCase interface {
@@ -11,11 +15,21 @@ type CronUseCase interface {
 	ValidateTransactions()
 	ValidateOrders()
 	ValidateOrdersConfirmed()
+	SendMedicationReminders()
+	EndExpiredConsultationSessions()
+	RefreshProductCoPurchases()
+	NotifyProductAlerts()
+	FailStaleProductImports()
 }
 
 type CronUseCaseImpl struct {
-	cronRepo repository.CronRepository
-	cronJob  *cron.Cron
+	cronRepo           repository.CronRepository
+	reminderRepo       repository.MedicationReminderRepository
+	recommendationRepo repository.ProductRecommendationRepository
+	reminderNotifier   util.ReminderNotifier
+	sessionUC          ConsultationSessionUseCase
+	productAlertUC     ProductAlertUseCase
+	cronJob            *cron.Cron
 }
 
 func (uc CronUseCaseImpl) ValidateTransactions() {
@@ -39,10 +53,84 @@ func (uc CronUseCaseImpl) ValidateOrdersConfirmed() {
 	}
 }
 
-func NewCronUseCase(cronRepo repository.CronRepository) *CronUseCaseImpl {
+// SendMedicationReminders marks a dose as notified only once the notifier succeeded, so failed doses are retried
+// on the next run until they fall out of the notify window
+func (uc CronUseCaseImpl) SendMedicationReminders() {
+	ctx := context.Background()
+	doses, err := uc.reminderRepo.FindAllDueDoses(ctx, time.Now())
+	if err != nil {
+		applogger.Log.Error(err.Error())
+		return
+	}
+
+	for _, dose := range doses {
+		reminder := dose.MedicationReminder
+		err = uc.reminderNotifier.Notify(util.ReminderNotification{
+			Email:          reminder.User.Email,
+			PatientName:    reminder.User.UserProfile.Name,
+			MedicationName: reminder.MedicationName,
+			Instruction:    reminder.Instruction,
+			ScheduledAt:    dose.ScheduledAt,
+		})
+		if err != nil {
+			applogger.Log.Error(err.Error())
+			continue
+		}
+
+		err = uc.reminderRepo.UpdateDoseNotified(ctx, dose.Id)
+		if err != nil {
+			applogger.Log.Error(err.Error())
+		}
+	}
+}
+
+// EndExpiredConsultationSessions goes through EditStatusAsEnded so a timed out session ends exactly like one the
+// doctor ended, stale extension requests are rejected first so they no longer keep their session open
+func (uc CronUseCaseImpl) EndExpiredConsultationSessions() {
+	err := uc.cronRepo.RejectStaleSessionExtensions()
+	if err != nil {
+		applogger.Log.Error(err.Error())
+	}
+
+	_, err = uc.sessionUC.EditAllExpiredAsEnded(context.Background())
+	if err != nil {
+		applogger.Log.Error(err.Error())
+	}
+}
+
+// RefreshProductCoPurchases recomputes the frequently bought together products from the confirmed orders
+func (uc CronUseCaseImpl) RefreshProductCoPurchases() {
+	_, err := uc.recommendationRepo.RefreshCoPurchases(context.Background())
+	if err != nil {
+		applogger.Log.Error(err.Error())
+	}
+}
+
+// NotifyProductAlerts matches the queued stock and price changes against the product alerts of the users
+func (uc CronUseCaseImpl) NotifyProductAlerts() {
+	err := uc.productAlertUC.NotifyMatches(context.Background())
+	if err != nil {
+		applogger.Log.Error(err.Error())
+	}
+}
+
+// FailStaleProductImports marks the product imports that stopped making progress as failed
+func (uc CronUseCaseImpl) FailStaleProductImports() {
+	err := uc.cronRepo.FailStaleProductImports()
+	if err != nil {
+		applogger.Log.Error(err.Error())
+	}
+}
+
+func NewCronUseCase(cronRepo repository.CronRepository, reminderRepo repository.MedicationReminderRepository, recommendationRepo repository.ProductRecommendationRepository, reminderNotifier util.ReminderNotifier, sessionUC ConsultationSessionUseCase, productAlertUC ProductAlertUseCase) *CronUseCaseImpl {
 	return &CronUseCaseImpl{
-		cronRepo: cronRepo,
-		cronJob:  cron.New(),
+		cronRepo:           cronRepo,
+		reminderRepo:       reminderRepo,
+		recommendationRepo: recommendationRepo,
+		reminderNotifier:   reminderNotifier,
+		sessionUC:          sessionUC,
+		productAlertUC:     productAlertUC,
+		cronJob:            cron.New(),
 	}
 }
 
@@ -62,6 +150,31 @@ func (uc CronUseCaseImpl) StartCron() error {
 		return err
 	}
 
+	_, err = uc.cronJob.AddFunc(appconstant.CronEveryMinuteTimer, uc.SendMedicationReminders)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.cronJob.AddFunc(appconstant.CronEveryMinuteTimer, uc.EndExpiredConsultationSessions)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.cronJob.AddFunc(appconstant.CronDailyTimer, uc.RefreshProductCoPurchases)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.cronJob.AddFunc(appconstant.CronEveryMinuteTimer, uc.NotifyProductAlerts)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.cronJob.AddFunc(appconstant.CronEveryMinuteTimer, uc.FailStaleProductImports)
+	if err != nil {
+		return err
+	}
+
 	uc.cronJob.Start()
 
 	return nil
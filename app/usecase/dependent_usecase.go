@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type DependentUseCase interface {
+	Add(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error)
+	GetById(ctx context.Context, id int64) (*entity.Dependent, error)
+	GetAllMine(ctx context.Context) ([]*entity.Dependent, error)
+	Edit(ctx context.Context, id int64, dependent entity.Dependent) (*entity.Dependent, error)
+	Remove(ctx context.Context, id int64) error
+}
+
+type DependentUseCaseImpl struct {
+	repo repository.DependentRepository
+}
+
+func NewDependentUseCaseImpl(repo repository.DependentRepository) *DependentUseCaseImpl {
+	return &DependentUseCaseImpl{repo: repo}
+}
+
+func (uc *DependentUseCaseImpl) Add(ctx context.Context, dependent entity.Dependent) (*entity.Dependent, error) {
+	dependent.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.Create(ctx, dependent)
+}
+
+func (uc *DependentUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.Dependent, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return findDependentOfUser(ctx, uc.repo, userId, id)
+}
+
+func (uc *DependentUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.Dependent, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.FindAllByUserId(ctx, userId)
+}
+
+func (uc *DependentUseCaseImpl) Edit(ctx context.Context, id int64, dependent entity.Dependent) (*entity.Dependent, error) {
+	if _, err := uc.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	dependent.Id = id
+	return uc.repo.Update(ctx, dependent)
+}
+
+func (uc *DependentUseCaseImpl) Remove(ctx context.Context, id int64) error {
+	if _, err := uc.GetById(ctx, id); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+// findDependentOfUser makes sure the dependent is registered under the account holder before it is named as a patient
+func findDependentOfUser(ctx context.Context, repo repository.DependentRepository, userId int64, dependentId int64) (*entity.Dependent, error) {
+	dependent, err := repo.FindById(ctx, dependentId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(dependent, "Id", dependentId)
+		}
+		return nil, err
+	}
+	if dependent.UserId != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	return dependent, nil
+}
+
+// validateDependentOfUser is a no-op when the account holder is the patient
+func validateDependentOfUser(ctx context.Context, repo repository.DependentRepository, userId int64, dependentId sql.NullInt64) error {
+	if !dependentId.Valid {
+		return nil
+	}
+	_, err := findDependentOfUser(ctx, repo, userId, dependentId.Int64)
+	return err
+}
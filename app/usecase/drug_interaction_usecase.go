@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"io"
+	"strings"
+)
+
+type DrugInteractionUseCase interface {
+	Add(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error)
+	GetById(ctx context.Context, id int64) (*entity.DrugInteraction, error)
+	GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	Edit(ctx context.Context, id int64, interaction entity.DrugInteraction) (*entity.DrugInteraction, error)
+	Remove(ctx context.Context, id int64) error
+	Import(ctx context.Context, file io.Reader) (*entity.DrugInteractionImportResult, error)
+}
+
+type DrugInteractionUseCaseImpl struct {
+	repo repository.DrugInteractionRepository
+}
+
+func NewDrugInteractionUseCaseImpl(repo repository.DrugInteractionRepository) *DrugInteractionUseCaseImpl {
+	return &DrugInteractionUseCaseImpl{repo: repo}
+}
+
+func (uc *DrugInteractionUseCaseImpl) Add(ctx context.Context, interaction entity.DrugInteraction) (*entity.DrugInteraction, error) {
+	if err := uc.validate(&interaction); err != nil {
+		return nil, err
+	}
+
+	created, err := uc.repo.Create(ctx, interaction)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *DrugInteractionUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.DrugInteraction, error) {
+	interaction, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(interaction, "Id", id)
+		}
+		return nil, err
+	}
+	return interaction, nil
+}
+
+func (uc *DrugInteractionUseCaseImpl) GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	interactions, err := uc.repo.FindAll(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := uc.repo.CountFindAll(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		totalItems, totalPages, int64(len(interactions)), int64(*param.PageId), interactions,
+	)
+	return paginatedItems, nil
+}
+
+func (uc *DrugInteractionUseCaseImpl) Edit(ctx context.Context, id int64, interaction entity.DrugInteraction) (*entity.DrugInteraction, error) {
+	if _, err := uc.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validate(&interaction); err != nil {
+		return nil, err
+	}
+	interaction.Id = id
+
+	updated, err := uc.repo.Update(ctx, interaction)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (uc *DrugInteractionUseCaseImpl) Remove(ctx context.Context, id int64) error {
+	if _, err := uc.GetById(ctx, id); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *DrugInteractionUseCaseImpl) Import(ctx context.Context, file io.Reader) (*entity.DrugInteractionImportResult, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = appconstant.DrugInteractionImportColumnSize
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.ErrDrugInteractionImportInvalidFile
+	}
+	expectedHeader := []string{"generic_name_a", "generic_name_b", "severity", "description"}
+	for index, column := range header {
+		if !strings.EqualFold(strings.TrimSpace(column), expectedHeader[index]) {
+			return nil, apperror.ErrDrugInteractionImportInvalidFile
+		}
+	}
+
+	result := &entity.DrugInteractionImportResult{Errors: make([]*entity.DrugInteractionImportError, 0)}
+	interactionsByPair := make(map[string]*entity.DrugInteraction)
+	pairs := make([]string, 0)
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		result.TotalRows++
+		if err != nil {
+			result.Errors = append(result.Errors, &entity.DrugInteractionImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		interaction := entity.DrugInteraction{
+			GenericNameA: record[0],
+			GenericNameB: record[1],
+			Description:  strings.TrimSpace(record[3]),
+		}
+		interaction.DrugInteractionSeverityId = drugInteractionSeverityIdByName(record[2])
+
+		if interaction.Description == "" {
+			result.Errors = append(result.Errors, &entity.DrugInteractionImportError{Row: row, Message: "description is required"})
+			continue
+		}
+		if err := uc.validate(&interaction); err != nil {
+			result.Errors = append(result.Errors, &entity.DrugInteractionImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		// a pair appearing more than once in the file keeps its last row
+		pair := fmt.Sprintf("%s|%s", interaction.GenericNameA, interaction.GenericNameB)
+		if _, ok := interactionsByPair[pair]; !ok {
+			pairs = append(pairs, pair)
+		}
+		interactionsByPair[pair] = &interaction
+	}
+
+	interactions := make([]*entity.DrugInteraction, 0, len(pairs))
+	for _, pair := range pairs {
+		interactions = append(interactions, interactionsByPair[pair])
+	}
+
+	if len(interactions) > 0 {
+		if err := uc.repo.Upsert(ctx, interactions); err != nil {
+			return nil, err
+		}
+	}
+	result.Imported = len(interactions)
+
+	return result, nil
+}
+
+func (uc *DrugInteractionUseCaseImpl) validate(interaction *entity.DrugInteraction) error {
+	interaction.Normalize()
+	if interaction.GenericNameA == "" || interaction.GenericNameA == interaction.GenericNameB {
+		return apperror.ErrDrugInteractionSameGenericName
+	}
+	if _, ok := appconstant.DrugInteractionSeverityNames[interaction.DrugInteractionSeverityId]; !ok {
+		return apperror.ErrDrugInteractionInvalidSeverity
+	}
+	return nil
+}
+
+func drugInteractionSeverityIdByName(name string) int64 {
+	for id, severityName := range appconstant.DrugInteractionSeverityNames {
+		if strings.EqualFold(strings.TrimSpace(name), severityName) {
+			return id
+		}
+	}
+	return 0
+}
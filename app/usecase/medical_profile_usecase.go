@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type MedicalProfileUseCase interface {
+	GetByUserId(ctx context.Context, userId int64) (*entity.MedicalProfile, error)
+	GetByDependentId(ctx context.Context, dependentId int64) (*entity.MedicalProfile, error)
+	Edit(ctx context.Context, profile entity.MedicalProfile) (*entity.MedicalProfile, error)
+	AddMeasurement(ctx context.Context, measurement entity.MedicalProfileMeasurement) (*entity.MedicalProfile, error)
+}
+
+type MedicalProfileUseCaseImpl struct {
+	repo          repository.MedicalProfileRepository
+	sessionRepo   repository.ConsultationSessionRepository
+	dependentRepo repository.DependentRepository
+}
+
+func NewMedicalProfileUseCaseImpl(
+	repo repository.MedicalProfileRepository,
+	sessionRepo repository.ConsultationSessionRepository,
+	dependentRepo repository.DependentRepository,
+) *MedicalProfileUseCaseImpl {
+	return &MedicalProfileUseCaseImpl{repo: repo, sessionRepo: sessionRepo, dependentRepo: dependentRepo}
+}
+
+// GetByUserId returns the account holder's own medical profile
+func (uc *MedicalProfileUseCaseImpl) GetByUserId(ctx context.Context, userId int64) (*entity.MedicalProfile, error) {
+	return uc.getByPatient(ctx, userId, sql.NullInt64{})
+}
+
+func (uc *MedicalProfileUseCaseImpl) GetByDependentId(ctx context.Context, dependentId int64) (*entity.MedicalProfile, error) {
+	dependent, err := uc.dependentRepo.FindById(ctx, dependentId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(dependent, "Id", dependentId)
+		}
+		return nil, err
+	}
+	return uc.getByPatient(ctx, dependent.UserId, appdb.NewSqlNullInt64(dependent.Id))
+}
+
+// getByPatient returns the patient's medical profile, doctors can only read it while they have an ongoing session with
+// that same patient
+func (uc *MedicalProfileUseCaseImpl) getByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64) (*entity.MedicalProfile, error) {
+	roleId := ctx.Value(appconstant.ContextKeyRoleId).(int64)
+	loggedInUserId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	switch roleId {
+	case appconstant.UserRoleIdUser:
+		if userId != loggedInUserId {
+			return nil, apperror.ErrForbiddenViewEntity
+		}
+	case appconstant.UserRoleIdDoctor:
+		session, err := uc.sessionRepo.FindByPatientAndDoctorId(ctx, userId, dependentId, loggedInUserId)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return nil, apperror.ErrForbiddenViewEntity
+			}
+			return nil, err
+		}
+		if session.ConsultationSessionStatusId != appconstant.ConsultationSessionStatusOngoing {
+			return nil, apperror.ErrForbiddenViewEntity
+		}
+	default:
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	return findMedicalProfileByPatient(ctx, uc.repo, userId, dependentId)
+}
+
+func (uc *MedicalProfileUseCaseImpl) Edit(ctx context.Context, profile entity.MedicalProfile) (*entity.MedicalProfile, error) {
+	profile.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if err := validateDependentOfUser(ctx, uc.dependentRepo, profile.UserId, profile.DependentId); err != nil {
+		return nil, err
+	}
+	return uc.repo.Upsert(ctx, profile)
+}
+
+func (uc *MedicalProfileUseCaseImpl) AddMeasurement(ctx context.Context, measurement entity.MedicalProfileMeasurement) (*entity.MedicalProfile, error) {
+	measurement.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if err := validateDependentOfUser(ctx, uc.dependentRepo, measurement.UserId, measurement.DependentId); err != nil {
+		return nil, err
+	}
+	if _, err := uc.repo.CreateMeasurement(ctx, measurement); err != nil {
+		return nil, err
+	}
+	return findMedicalProfileByPatient(ctx, uc.repo, measurement.UserId, measurement.DependentId)
+}
+
+// findMedicalProfileByPatient returns an empty profile for patients who have not filled in their medical profile yet
+func findMedicalProfileByPatient(ctx context.Context, repo repository.MedicalProfileRepository, userId int64, dependentId sql.NullInt64) (*entity.MedicalProfile, error) {
+	profile, err := repo.FindByPatient(ctx, userId, dependentId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return &entity.MedicalProfile{UserId: userId, DependentId: dependentId}, nil
+		}
+		return nil, err
+	}
+	return profile, nil
+}
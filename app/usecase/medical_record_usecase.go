@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/fhirdto"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"time"
+)
+
+type MedicalRecordUseCase interface {
+	GetAllMine(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetAllBySessionId(ctx context.Context, sessionId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetConsentBySessionId(ctx context.Context, sessionId int64) (*entity.MedicalRecordConsent, error)
+	EditConsentBySessionId(ctx context.Context, sessionId int64, consent entity.MedicalRecordConsent) (*entity.MedicalRecordConsent, error)
+	ExportFhirMine(ctx context.Context) (*fhirdto.Bundle, error)
+	ExportFhirBySessionId(ctx context.Context, sessionId int64) (*fhirdto.Bundle, error)
+}
+
+type MedicalRecordUseCaseImpl struct {
+	repo             repository.MedicalRecordRepository
+	sessionRepo      repository.ConsultationSessionRepository
+	profileRepo      repository.ProfileRepository
+	prescriptionRepo repository.PrescriptionRepository
+	fhirBaseUrl      string
+}
+
+func NewMedicalRecordUseCaseImpl(
+	repo repository.MedicalRecordRepository,
+	sessionRepo repository.ConsultationSessionRepository,
+	profileRepo repository.ProfileRepository,
+	prescriptionRepo repository.PrescriptionRepository,
+) *MedicalRecordUseCaseImpl {
+	return &MedicalRecordUseCaseImpl{
+		repo:             repo,
+		sessionRepo:      sessionRepo,
+		profileRepo:      profileRepo,
+		prescriptionRepo: prescriptionRepo,
+		fhirBaseUrl:      fmt.Sprintf("%s/fhir", appconfig.Config.AppUri),
+	}
+}
+
+func (uc *MedicalRecordUseCaseImpl) GetAllMine(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.getAllByPatient(ctx, userId, sql.NullInt64{}, param)
+}
+
+// GetAllBySessionId returns the timeline of the session's patient, only for the doctor of an ongoing session that
+// the patient has granted access to
+func (uc *MedicalRecordUseCaseImpl) GetAllBySessionId(ctx context.Context, sessionId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	sessionDb, err := uc.findSharedSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	return uc.getAllByPatient(ctx, sessionDb.UserId, sessionDb.DependentId, param)
+}
+
+func (uc *MedicalRecordUseCaseImpl) GetConsentBySessionId(ctx context.Context, sessionId int64) (*entity.MedicalRecordConsent, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", sessionId)
+		}
+		return nil, err
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.UserId != userId && sessionDb.DoctorId != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	consent, err := uc.repo.FindConsentBySessionId(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return &entity.MedicalRecordConsent{SessionId: sessionId}, nil
+		}
+		return nil, err
+	}
+	return consent, nil
+}
+
+func (uc *MedicalRecordUseCaseImpl) EditConsentBySessionId(ctx context.Context, sessionId int64, consent entity.MedicalRecordConsent) (*entity.MedicalRecordConsent, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", sessionId)
+		}
+		return nil, err
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+
+	consent.SessionId = sessionId
+	return uc.repo.UpsertConsent(ctx, consent)
+}
+
+func (uc *MedicalRecordUseCaseImpl) getAllByPatient(ctx context.Context, userId int64, dependentId sql.NullInt64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	records, err := uc.repo.FindAllByPatient(ctx, userId, dependentId, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := uc.repo.CountFindAllByPatient(ctx, userId, dependentId, param)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		totalItems, totalPages, int64(len(records)), int64(*param.PageId), records,
+	)
+	return paginatedItems, nil
+}
+
+func (uc *MedicalRecordUseCaseImpl) ExportFhirMine(ctx context.Context) (*fhirdto.Bundle, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.exportFhirByPatient(ctx, userId, nil)
+}
+
+// ExportFhirBySessionId exports the patient's records under the same consent rules as GetAllBySessionId
+func (uc *MedicalRecordUseCaseImpl) ExportFhirBySessionId(ctx context.Context, sessionId int64) (*fhirdto.Bundle, error) {
+	sessionDb, err := uc.findSharedSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	return uc.exportFhirByPatient(ctx, sessionDb.UserId, sessionDb.Dependent)
+}
+
+// findSharedSession returns the session if the logged in doctor is treating it and the patient has granted consent
+func (uc *MedicalRecordUseCaseImpl) findSharedSession(ctx context.Context, sessionId int64) (*entity.ConsultationSession, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", sessionId)
+		}
+		return nil, err
+	}
+
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	if sessionDb.ConsultationSessionStatusId != appconstant.ConsultationSessionStatusOngoing {
+		return nil, apperror.ErrChatAlreadyEnded
+	}
+
+	consent, err := uc.repo.FindConsentBySessionId(ctx, sessionId)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return nil, err
+	}
+	if consent == nil || !consent.IsGranted {
+		return nil, apperror.ErrMedicalRecordConsentNotGranted
+	}
+
+	return sessionDb, nil
+}
+
+// exportFhirByPatient exports the records of a single patient, a nil dependent means the account holder is the patient
+func (uc *MedicalRecordUseCaseImpl) exportFhirByPatient(ctx context.Context, userId int64, dependent *entity.Dependent) (*fhirdto.Bundle, error) {
+	var patient *fhirdto.Patient
+	dependentId := sql.NullInt64{}
+	if dependent != nil {
+		patient = dependent.ToFhirPatient(uc.fhirBaseUrl)
+		dependentId = sql.NullInt64{Int64: dependent.Id, Valid: true}
+	} else {
+		user, err := uc.profileRepo.FindUserProfileByUserId(ctx, userId)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound(user, "Id", userId)
+			}
+			return nil, err
+		}
+		patient = user.ToFhirPatient(uc.fhirBaseUrl)
+	}
+
+	sessions, err := uc.sessionRepo.FindAllByPatient(ctx, userId, dependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := newFhirBundle()
+	bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(fhirdto.ResourceTypePatient, patient.Id, patient))
+
+	practitioners := make(map[int64]bool)
+	for _, session := range sessions {
+		if !practitioners[session.DoctorId] {
+			doctor, err := uc.profileRepo.FindDoctorProfileByUserId(ctx, session.DoctorId)
+			if err != nil {
+				return nil, err
+			}
+			practitioner := doctor.ToFhirPractitioner(uc.fhirBaseUrl)
+			bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(fhirdto.ResourceTypePractitioner, practitioner.Id, practitioner))
+			practitioners[session.DoctorId] = true
+		}
+
+		encounter := session.ToFhirEncounter()
+		bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(fhirdto.ResourceTypeEncounter, encounter.Id, encounter))
+
+		prescription, err := uc.prescriptionRepo.FindBySessionIdDetailed(ctx, session.Id)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		condition := prescription.ToFhirCondition(session)
+		bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(fhirdto.ResourceTypeCondition, condition.Id, condition))
+		for _, prescriptionProduct := range prescription.PrescriptionProducts {
+			medicationRequest := prescriptionProduct.ToFhirMedicationRequest(prescription, session)
+			bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(
+				fhirdto.ResourceTypeMedicationRequest, medicationRequest.Id, medicationRequest,
+			))
+		}
+	}
+
+	return bundle, nil
+}
+
+func newFhirBundle() *fhirdto.Bundle {
+	return &fhirdto.Bundle{
+		ResourceType: fhirdto.ResourceTypeBundle,
+		Type:         appconstant.FhirBundleTypeCollection,
+		Timestamp:    time.Now().Format(time.RFC3339),
+		Entry:        make([]fhirdto.BundleEntry, 0),
+	}
+}
+
+func (uc *MedicalRecordUseCaseImpl) fhirBundleEntry(resourceType string, id string, resource interface{}) fhirdto.BundleEntry {
+	return fhirdto.BundleEntry{
+		FullUrl:  fmt.Sprintf("%s/%s/%s", uc.fhirBaseUrl, resourceType, id),
+		Resource: resource,
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"encoding/json"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/dto/fhirdto"
+	"halodeksik-be/app/entity"
+	"testing"
+	"time"
+)
+
+func TestMedicalRecordUseCaseImpl_fhirBundleEntry(t *testing.T) {
+	uc := &MedicalRecordUseCaseImpl{fhirBaseUrl: "http://localhost/fhir"}
+	createdAt := time.Date(2023, 11, 1, 9, 0, 0, 0, time.UTC)
+	session := &entity.ConsultationSession{Id: 11, UserId: 7, DoctorId: 3, CreatedAt: createdAt}
+	prescription := &entity.Prescription{Id: 13, SessionId: session.Id, ExpiredAt: createdAt, CreatedAt: createdAt}
+
+	tests := []struct {
+		name            string
+		resourceType    string
+		id              string
+		resource        interface{}
+		expectedFullUrl string
+		expectedSubject string
+	}{
+		{
+			name:            "patient",
+			resourceType:    fhirdto.ResourceTypePatient,
+			id:              "7",
+			resource:        (&entity.User{Id: 7}).ToFhirPatient(uc.fhirBaseUrl),
+			expectedFullUrl: "http://localhost/fhir/Patient/7",
+		},
+		{
+			name:            "dependent patient",
+			resourceType:    fhirdto.ResourceTypePatient,
+			id:              "dependent-5",
+			resource:        (&entity.Dependent{Id: 5, UserId: 7}).ToFhirPatient(uc.fhirBaseUrl),
+			expectedFullUrl: "http://localhost/fhir/Patient/dependent-5",
+		},
+		{
+			name:            "practitioner",
+			resourceType:    fhirdto.ResourceTypePractitioner,
+			id:              "3",
+			resource:        (&entity.User{Id: 3}).ToFhirPractitioner(uc.fhirBaseUrl),
+			expectedFullUrl: "http://localhost/fhir/Practitioner/3",
+		},
+		{
+			name:            "encounter",
+			resourceType:    fhirdto.ResourceTypeEncounter,
+			id:              "11",
+			resource:        session.ToFhirEncounter(),
+			expectedFullUrl: "http://localhost/fhir/Encounter/11",
+			expectedSubject: "Patient/7",
+		},
+		{
+			name:            "condition",
+			resourceType:    fhirdto.ResourceTypeCondition,
+			id:              "13",
+			resource:        prescription.ToFhirCondition(session),
+			expectedFullUrl: "http://localhost/fhir/Condition/13",
+			expectedSubject: "Patient/7",
+		},
+	}
+
+	bundle := newFhirBundle()
+	for _, tt := range tests {
+		bundle.Entry = append(bundle.Entry, uc.fhirBundleEntry(tt.resourceType, tt.id, tt.resource))
+	}
+
+	raw, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+
+	var got struct {
+		ResourceType string `json:"resourceType"`
+		Type         string `json:"type"`
+		Entry        []struct {
+			FullUrl  string `json:"fullUrl"`
+			Resource struct {
+				ResourceType string             `json:"resourceType"`
+				Subject      *fhirdto.Reference `json:"subject"`
+			} `json:"resource"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("failed to unmarshal bundle: %v", err)
+	}
+
+	if got.ResourceType != fhirdto.ResourceTypeBundle {
+		t.Errorf("resourceType = %s, want %s", got.ResourceType, fhirdto.ResourceTypeBundle)
+	}
+	if got.Type != appconstant.FhirBundleTypeCollection {
+		t.Errorf("type = %s, want %s", got.Type, appconstant.FhirBundleTypeCollection)
+	}
+	if len(got.Entry) != len(tests) {
+		t.Fatalf("len(entry) = %d, want %d", len(got.Entry), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := got.Entry[i]
+			if entry.FullUrl != tt.expectedFullUrl {
+				t.Errorf("fullUrl = %s, want %s", entry.FullUrl, tt.expectedFullUrl)
+			}
+			if entry.Resource.ResourceType != tt.resourceType {
+				t.Errorf("resource.resourceType = %s, want %s", entry.Resource.ResourceType, tt.resourceType)
+			}
+			if tt.expectedSubject == "" {
+				return
+			}
+			if entry.Resource.Subject == nil || entry.Resource.Subject.Reference != tt.expectedSubject {
+				t.Errorf("resource.subject = %v, want %s", entry.Resource.Subject, tt.expectedSubject)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"time"
+)
+
+type MedicationReminderUseCase interface {
+	Add(ctx context.Context, reminder entity.MedicationReminder) (*entity.MedicationReminder, error)
+	AddFromPrescriptionProduct(ctx context.Context, prescriptionProductId int64, reminder entity.MedicationReminder) (*entity.MedicationReminder, error)
+	GetById(ctx context.Context, id int64) (*entity.MedicationReminder, error)
+	GetAllMine(ctx context.Context) ([]*entity.MedicationReminder, error)
+	Remove(ctx context.Context, id int64) error
+	LogDose(ctx context.Context, doseId int64, status string) (*entity.MedicationReminderDose, error)
+}
+
+type MedicationReminderUseCaseImpl struct {
+	repo             repository.MedicationReminderRepository
+	prescriptionRepo repository.PrescriptionRepository
+	productRepo      repository.ProductRepository
+	dependentRepo    repository.DependentRepository
+}
+
+func NewMedicationReminderUseCaseImpl(
+	repo repository.MedicationReminderRepository,
+	prescriptionRepo repository.PrescriptionRepository,
+	productRepo repository.ProductRepository,
+	dependentRepo repository.DependentRepository,
+) *MedicationReminderUseCaseImpl {
+	return &MedicationReminderUseCaseImpl{
+		repo:             repo,
+		prescriptionRepo: prescriptionRepo,
+		productRepo:      productRepo,
+		dependentRepo:    dependentRepo,
+	}
+}
+
+func (uc *MedicationReminderUseCaseImpl) Add(ctx context.Context, reminder entity.MedicationReminder) (*entity.MedicationReminder, error) {
+	reminder.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	err := validateDependentOfUser(ctx, uc.dependentRepo, reminder.UserId, reminder.DependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.create(ctx, reminder)
+}
+
+func (uc *MedicationReminderUseCaseImpl) AddFromPrescriptionProduct(ctx context.Context, prescriptionProductId int64, reminder entity.MedicationReminder) (*entity.MedicationReminder, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	prescriptionProduct, err := uc.prescriptionRepo.FindPrescriptionProductById(ctx, prescriptionProductId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(prescriptionProduct, "Id", prescriptionProductId)
+		}
+		return nil, err
+	}
+	if prescriptionProduct.Prescription.User.Id != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	if prescriptionProduct.RedeemedQuantity <= 0 {
+		return nil, apperror.ErrMedicationReminderPrescriptionNotRedeemed
+	}
+
+	product, err := uc.productRepo.FindById(ctx, prescriptionProduct.ProductId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(product, "Id", prescriptionProduct.ProductId)
+		}
+		return nil, err
+	}
+
+	reminder.UserId = userId
+	reminder.DependentId = prescriptionProduct.Prescription.DependentId
+	reminder.PrescriptionProductId = appdb.NewSqlNullInt64(prescriptionProduct.Id)
+	reminder.MedicationName = product.Name
+	reminder.Instruction = prescriptionProduct.GetDoseInstruction()
+	if len(reminder.TimesOfDay) == 0 {
+		reminder.TimesOfDay = prescriptionProduct.GetDefaultDoseTimes()
+	}
+	// a product prescribed with only a note has no schedule to take the reminder from
+	if !prescriptionProduct.DurationDays.Valid || len(reminder.TimesOfDay) == 0 {
+		return nil, apperror.ErrMedicationReminderPrescriptionNoSchedule
+	}
+	reminder.DurationDays = prescriptionProduct.DurationDays.Int32
+	if reminder.StartDate.IsZero() {
+		reminder.StartDate = time.Now()
+	}
+	if reminder.DurationDays > appconstant.MedicationReminderMaxDurationDays {
+		reminder.DurationDays = appconstant.MedicationReminderMaxDurationDays
+	}
+
+	return uc.create(ctx, reminder)
+}
+
+func (uc *MedicationReminderUseCaseImpl) create(ctx context.Context, reminder entity.MedicationReminder) (*entity.MedicationReminder, error) {
+	reminder.Doses = reminder.GenerateDoses()
+
+	created, err := uc.repo.Create(ctx, reminder)
+	if err != nil {
+		return nil, err
+	}
+	return uc.GetById(ctx, created.Id)
+}
+
+func (uc *MedicationReminderUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.MedicationReminder, error) {
+	reminder, err := uc.findMine(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	doses, err := uc.repo.FindAllDosesByReminderId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	reminder.Doses = doses
+	return reminder, nil
+}
+
+func (uc *MedicationReminderUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.MedicationReminder, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.FindAllByUserId(ctx, userId)
+}
+
+func (uc *MedicationReminderUseCaseImpl) Remove(ctx context.Context, id int64) error {
+	if _, err := uc.findMine(ctx, id); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *MedicationReminderUseCaseImpl) LogDose(ctx context.Context, doseId int64, status string) (*entity.MedicationReminderDose, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	dose, err := uc.repo.FindDoseById(ctx, doseId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(dose, "Id", doseId)
+		}
+		return nil, err
+	}
+	if dose.MedicationReminder.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+	if !dose.IsLoggable() {
+		return nil, apperror.ErrMedicationReminderDoseNotDue
+	}
+
+	dose.Status = status
+	return uc.repo.UpdateDoseStatus(ctx, *dose)
+}
+
+func (uc *MedicationReminderUseCaseImpl) findMine(ctx context.Context, id int64) (*entity.MedicationReminder, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	reminder, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(reminder, "Id", id)
+		}
+		return nil, err
+	}
+	if reminder.UserId != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	return reminder, nil
+}
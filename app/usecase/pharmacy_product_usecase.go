@@ -1,13 +1,21 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/repository"
+	"io"
+	"strconv"
+	"strings"
 )
 
 type PharmacyProductUseCase interface {
@@ -16,6 +24,9 @@ type PharmacyProductUseCase interface {
 	GetAllByProductId(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	GetAllByPharmacy(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	Edit(ctx context.Context, id int64, pharmacyProduct entity.PharmacyProduct) (*entity.PharmacyProduct, error)
+	Import(ctx context.Context, pharmacyId int64, file io.Reader, isDryRun bool) (*entity.PharmacyProductImportResult, error)
+	Export(ctx context.Context, pharmacyId int64) ([]byte, error)
+	GetAllPriceHistories(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 }
 
 type PharmacyProductUseCaseImpl struct {
@@ -51,7 +62,8 @@ func (uc *PharmacyProductUseCaseImpl) Add(ctx context.Context, pharmacyProduct e
 		return nil, err
 	}
 
-	created, err := uc.pharmacyProductRepo.Create(ctx, pharmacyProduct)
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	created, err := uc.pharmacyProductRepo.Create(ctx, pharmacyProduct, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -150,9 +162,245 @@ func (uc *PharmacyProductUseCaseImpl) Edit(ctx context.Context, id int64, pharma
 		return nil, err
 	}
 	pharmacyProduct.Id = id
-	updated, err := uc.pharmacyProductRepo.Update(ctx, pharmacyProduct)
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	updated, err := uc.pharmacyProductRepo.Update(ctx, pharmacyProduct, userId)
 	if err != nil {
 		return nil, err
 	}
 	return updated, nil
 }
+
+// Import upserts the inventory of the pharmacy from a csv, rows are matched to products by product_id or product_code.
+// The rows are written all together, so a single invalid row leaves the inventory untouched
+func (uc *PharmacyProductUseCaseImpl) Import(ctx context.Context, pharmacyId int64, file io.Reader, isDryRun bool) (*entity.PharmacyProductImportResult, error) {
+	if err := uc.checkPharmacyAdmin(ctx, pharmacyId); err != nil {
+		return nil, err
+	}
+
+	rows, err := readPharmacyProductImportRows(file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.PharmacyProductImportResult{PharmacyId: pharmacyId, IsDryRun: isDryRun, Rows: rows}
+	hasFailedRow := false
+	rowNumberByProductId := make(map[int64]int32)
+	for _, row := range rows {
+		if row.Status != appconstant.PharmacyProductImportRowStatusFailed {
+			if err := uc.resolveImportRowProduct(ctx, row); err != nil {
+				return nil, err
+			}
+		}
+		if row.ProductId != 0 {
+			if firstRow, ok := rowNumberByProductId[row.ProductId]; ok {
+				row.AddError(fmt.Sprintf("duplicate of row %d", firstRow))
+			} else {
+				rowNumberByProductId[row.ProductId] = row.RowNumber
+			}
+		}
+		if row.Status == appconstant.PharmacyProductImportRowStatusFailed {
+			hasFailedRow = true
+		}
+	}
+	if hasFailedRow {
+		return result, nil
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if err := uc.pharmacyProductRepo.UpsertAllByPharmacyId(ctx, pharmacyId, rows, userId, isDryRun); err != nil {
+		return nil, err
+	}
+	result.IsApplied = !isDryRun
+	return result, nil
+}
+
+func (uc *PharmacyProductUseCaseImpl) Export(ctx context.Context, pharmacyId int64) ([]byte, error) {
+	if err := uc.checkPharmacyAdmin(ctx, pharmacyId); err != nil {
+		return nil, err
+	}
+
+	pharmacyProducts, err := uc.pharmacyProductRepo.FindAllByPharmacyIdJoinProduct(ctx, pharmacyId)
+	if err != nil {
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	writer := csv.NewWriter(&buff)
+	if err := writer.Write(appconstant.PharmacyInventoryColumns); err != nil {
+		return nil, err
+	}
+	for _, pharmacyProduct := range pharmacyProducts {
+		record := []string{
+			strconv.FormatInt(pharmacyProduct.ProductId, 10),
+			pharmacyProduct.Product.Code.String,
+			pharmacyProduct.Product.Name,
+			pharmacyProduct.Price.String(),
+			strconv.FormatInt(int64(pharmacyProduct.Stock), 10),
+			strconv.FormatBool(pharmacyProduct.IsActive),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// GetAllPriceHistories lists every price the pharmacy product was set to, pharmacy admins can only see the prices of
+// their own pharmacies
+func (uc *PharmacyProductUseCaseImpl) GetAllPriceHistories(ctx context.Context, id int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	pharmacyProduct, err := uc.pharmacyProductRepo.FindByIdJoinPharmacy(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(pharmacyProduct, "Id", id)
+		}
+		return nil, err
+	}
+	roleId := ctx.Value(appconstant.ContextKeyRoleId).(int64)
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if roleId == appconstant.UserRoleIdPharmacyAdmin && pharmacyProduct.Pharmacy.PharmacyAdminId != userId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	histories, err := uc.pharmacyProductRepo.FindAllPriceHistoriesById(ctx, id, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := uc.pharmacyProductRepo.CountFindAllPriceHistoriesById(ctx, id, param)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		totalItems,
+		totalPages,
+		int64(len(histories)),
+		int64(*param.PageId),
+		histories,
+	)
+	return paginatedItems, nil
+}
+
+func (uc *PharmacyProductUseCaseImpl) checkPharmacyAdmin(ctx context.Context, pharmacyId int64) error {
+	pharmacy, err := uc.pharmacyRepo.FindById(ctx, pharmacyId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(pharmacy, "Id", pharmacyId)
+		}
+		return err
+	}
+	if pharmacy.PharmacyAdminId != ctx.Value(appconstant.ContextKeyUserId).(int64) {
+		return apperror.ErrForbiddenModifyEntity
+	}
+	return nil
+}
+
+func (uc *PharmacyProductUseCaseImpl) resolveImportRowProduct(ctx context.Context, row *entity.PharmacyProductImportRow) error {
+	var (
+		product *entity.Product
+		err     error
+	)
+	if row.ProductId != 0 {
+		product, err = uc.productRepo.FindById(ctx, row.ProductId)
+	} else {
+		product, err = uc.productRepo.FindByCode(ctx, row.ProductCode)
+	}
+	if errors.Is(err, apperror.ErrRecordNotFound) {
+		if row.ProductId != 0 {
+			row.AddError(fmt.Sprintf("product with id %d does not exist", row.ProductId))
+		} else {
+			row.AddError(fmt.Sprintf("product with code '%s' does not exist", row.ProductCode))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	row.ProductId = product.Id
+	return nil
+}
+
+func readPharmacyProductImportRows(file io.Reader) ([]*entity.PharmacyProductImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.ErrPharmacyProductImportInvalidFile
+	}
+	columnIndexes := make(map[string]int)
+	for index, column := range header {
+		columnIndexes[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = index
+	}
+	for _, column := range []string{"price", "stock", "is_active"} {
+		if _, ok := columnIndexes[column]; !ok {
+			return nil, apperror.ErrPharmacyProductImportInvalidFile
+		}
+	}
+	_, hasProductId := columnIndexes["product_id"]
+	_, hasProductCode := columnIndexes["product_code"]
+	if !hasProductId && !hasProductCode {
+		return nil, apperror.ErrPharmacyProductImportInvalidFile
+	}
+
+	rows := make([]*entity.PharmacyProductImportRow, 0)
+	for rowNumber := int32(2); ; rowNumber++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) == appconstant.PharmacyProductImportMaxRows {
+			return nil, apperror.ErrPharmacyProductImportTooManyRows
+		}
+
+		row := &entity.PharmacyProductImportRow{RowNumber: rowNumber}
+		rows = append(rows, row)
+		if err != nil {
+			row.AddError(err.Error())
+			continue
+		}
+
+		value := func(column string) string {
+			index, ok := columnIndexes[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		row.ProductCode = value("product_code")
+		if productId := value("product_id"); productId != "" {
+			if row.ProductId, err = strconv.ParseInt(productId, 10, 64); err != nil || row.ProductId <= 0 {
+				row.AddError("product_id must be a positive number")
+			}
+		} else if row.ProductCode == "" {
+			row.AddError("product_id or product_code is required")
+		}
+
+		if row.Price, err = decimal.NewFromString(value("price")); err != nil || !row.Price.IsPositive() {
+			row.AddError("price must be a number greater than 0")
+		}
+		stock, err := strconv.ParseInt(value("stock"), 10, 32)
+		if err != nil || stock < 0 {
+			row.AddError("stock must be a whole number of at least 0")
+		}
+		row.Stock = int32(stock)
+		if row.IsActive, err = strconv.ParseBool(value("is_active")); err != nil {
+			row.AddError("is_active must be true or false")
+		}
+	}
+	if len(rows) == 0 {
+		return nil, apperror.ErrPharmacyProductImportInvalidFile
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type PrescriptionTemplateUseCase interface {
+	Add(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error)
+	GetById(ctx context.Context, id int64) (*entity.PrescriptionTemplate, error)
+	GetAllMine(ctx context.Context) ([]*entity.PrescriptionTemplate, error)
+	Edit(ctx context.Context, id int64, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error)
+	Remove(ctx context.Context, id int64) error
+	Apply(ctx context.Context, id int64, prescription entity.Prescription) (*entity.Prescription, error)
+
+	AddFavoriteProduct(ctx context.Context, productId int64) error
+	RemoveFavoriteProduct(ctx context.Context, productId int64) error
+	GetAllFavoriteProducts(ctx context.Context) ([]*entity.Product, error)
+}
+
+type PrescriptionTemplateUseCaseImpl struct {
+	templateRepo   repository.PrescriptionTemplateRepository
+	productRepo    repository.ProductRepository
+	prescriptionUC PrescriptionUseCase
+}
+
+func NewPrescriptionTemplateUseCaseImpl(
+	templateRepo repository.PrescriptionTemplateRepository,
+	productRepo repository.ProductRepository,
+	prescriptionUC PrescriptionUseCase,
+) *PrescriptionTemplateUseCaseImpl {
+	return &PrescriptionTemplateUseCaseImpl{
+		templateRepo:   templateRepo,
+		productRepo:    productRepo,
+		prescriptionUC: prescriptionUC,
+	}
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) Add(ctx context.Context, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error) {
+	if err := uc.validateTemplateProducts(ctx, template); err != nil {
+		return nil, err
+	}
+
+	template.DoctorId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+	added, err := uc.templateRepo.Create(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	return uc.templateRepo.FindById(ctx, added.Id)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.PrescriptionTemplate, error) {
+	return uc.findOwnedTemplate(ctx, id)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.PrescriptionTemplate, error) {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.templateRepo.FindAllByDoctorId(ctx, doctorId)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) Edit(ctx context.Context, id int64, template entity.PrescriptionTemplate) (*entity.PrescriptionTemplate, error) {
+	if _, err := uc.findOwnedTemplate(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateTemplateProducts(ctx, template); err != nil {
+		return nil, err
+	}
+
+	template.Id = id
+	edited, err := uc.templateRepo.Update(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	return uc.templateRepo.FindById(ctx, edited.Id)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) Remove(ctx context.Context, id int64) error {
+	if _, err := uc.findOwnedTemplate(ctx, id); err != nil {
+		return err
+	}
+	return uc.templateRepo.Delete(ctx, id)
+}
+
+// Apply prescribes the template's products to a session, going through the same checks as a hand written prescription
+func (uc *PrescriptionTemplateUseCaseImpl) Apply(ctx context.Context, id int64, prescription entity.Prescription) (*entity.Prescription, error) {
+	template, err := uc.findOwnedTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	prescription.PrescriptionProducts = template.ToPrescriptionProducts()
+	return uc.prescriptionUC.Add(ctx, prescription)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) AddFavoriteProduct(ctx context.Context, productId int64) error {
+	product, err := uc.productRepo.FindById(ctx, productId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(product, "Id", productId)
+		}
+		return err
+	}
+
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.templateRepo.CreateFavoriteProduct(ctx, doctorId, productId)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) RemoveFavoriteProduct(ctx context.Context, productId int64) error {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	err := uc.templateRepo.DeleteFavoriteProduct(ctx, doctorId, productId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(&entity.Product{}, "Id", productId)
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) GetAllFavoriteProducts(ctx context.Context) ([]*entity.Product, error) {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.templateRepo.FindAllFavoriteProductsByDoctorId(ctx, doctorId)
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) findOwnedTemplate(ctx context.Context, id int64) (*entity.PrescriptionTemplate, error) {
+	template, err := uc.templateRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(template, "Id", id)
+		}
+		return nil, err
+	}
+
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if template.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	return template, nil
+}
+
+func (uc *PrescriptionTemplateUseCaseImpl) validateTemplateProducts(ctx context.Context, template entity.PrescriptionTemplate) error {
+	if err := validatePrescriptionProducts(template.ToPrescriptionProducts()); err != nil {
+		return err
+	}
+
+	for _, templateProduct := range template.Products {
+		product, err := uc.productRepo.FindById(ctx, templateProduct.ProductId)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return apperror.NewNotFound(product, "Id", templateProduct.ProductId)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -3,25 +3,48 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/repository"
+	"strings"
+	"time"
 )
 
 type PrescriptionUseCase interface {
 	Add(ctx context.Context, prescription entity.Prescription) (*entity.Prescription, error)
 	GetBySessionId(ctx context.Context, sessionId int64) (*entity.Prescription, error)
 	EditBySessionId(ctx context.Context, sessionId int64, prescription entity.Prescription) (*entity.Prescription, error)
+	RedeemBySessionId(ctx context.Context, sessionId int64) (*entity.PaginatedItems, error)
 }
 
 type PrescriptionUseCaseImpl struct {
-	prescriptionRepo repository.PrescriptionRepository
-	sessionRepo      repository.ConsultationSessionRepository
+	prescriptionRepo   repository.PrescriptionRepository
+	sessionRepo        repository.ConsultationSessionRepository
+	cartItemRepo       repository.CartItemRepository
+	productRepo        repository.ProductRepository
+	interactionRepo    repository.DrugInteractionRepository
+	medicalProfileRepo repository.MedicalProfileRepository
 }
 
-func NewPrescriptionUseCaseImpl(prescriptionRepo repository.PrescriptionRepository, sessionRepo repository.ConsultationSessionRepository) *PrescriptionUseCaseImpl {
-	return &PrescriptionUseCaseImpl{prescriptionRepo: prescriptionRepo, sessionRepo: sessionRepo}
+func NewPrescriptionUseCaseImpl(
+	prescriptionRepo repository.PrescriptionRepository,
+	sessionRepo repository.ConsultationSessionRepository,
+	cartItemRepo repository.CartItemRepository,
+	productRepo repository.ProductRepository,
+	interactionRepo repository.DrugInteractionRepository,
+	medicalProfileRepo repository.MedicalProfileRepository,
+) *PrescriptionUseCaseImpl {
+	return &PrescriptionUseCaseImpl{
+		prescriptionRepo:   prescriptionRepo,
+		sessionRepo:        sessionRepo,
+		cartItemRepo:       cartItemRepo,
+		productRepo:        productRepo,
+		interactionRepo:    interactionRepo,
+		medicalProfileRepo: medicalProfileRepo,
+	}
 }
 
 func (uc *PrescriptionUseCaseImpl) Add(ctx context.Context, prescription entity.Prescription) (*entity.Prescription, error) {
@@ -38,11 +61,28 @@ func (uc *PrescriptionUseCaseImpl) Add(ctx context.Context, prescription entity.
 		return nil, apperror.ErrForbiddenModifyEntity
 	}
 
+	if err := validatePrescriptionProducts(prescription.PrescriptionProducts); err != nil {
+		return nil, err
+	}
+
+	warnings, err := uc.checkInteractions(ctx, prescription, sessionDb)
+	if err != nil {
+		return nil, err
+	}
+
+	prescription.ExpiredAt = time.Now().AddDate(0, 0, appconstant.PrescriptionValidityDays)
+
 	added, err := uc.prescriptionRepo.Create(ctx, prescription)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GetBySessionId(ctx, added.SessionId)
+
+	addedDetailed, err := uc.GetBySessionId(ctx, added.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	addedDetailed.Warnings = warnings
+	return addedDetailed, nil
 }
 
 func (uc *PrescriptionUseCaseImpl) GetBySessionId(ctx context.Context, sessionId int64) (*entity.Prescription, error) {
@@ -97,11 +137,278 @@ func (uc *PrescriptionUseCaseImpl) EditBySessionId(ctx context.Context, sessionI
 		return nil, err
 	}
 
+	for _, prescriptionProduct := range prescriptionDb.PrescriptionProducts {
+		if prescriptionProduct.RedeemedQuantity > 0 {
+			return nil, apperror.ErrPrescriptionAlreadyRedeemed
+		}
+	}
+
+	if err := validatePrescriptionProducts(prescription.PrescriptionProducts); err != nil {
+		return nil, err
+	}
+
+	warnings, err := uc.checkInteractions(ctx, prescription, sessionDb)
+	if err != nil {
+		return nil, err
+	}
+
 	prescription.SessionId = sessionId
+	prescription.ExpiredAt = prescriptionDb.ExpiredAt
 
 	edited, err := uc.prescriptionRepo.UpdateBySessionId(ctx, prescription)
 	if err != nil {
 		return nil, err
 	}
-	return uc.GetBySessionId(ctx, edited.SessionId)
+
+	editedDetailed, err := uc.GetBySessionId(ctx, edited.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	editedDetailed.Warnings = warnings
+	return editedDetailed, nil
+}
+
+func (uc *PrescriptionUseCaseImpl) RedeemBySessionId(ctx context.Context, sessionId int64) (*entity.PaginatedItems, error) {
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(&entity.Prescription{}, "SessionId", sessionId)
+		}
+		return nil, err
+	}
+
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	if sessionDb.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+
+	prescriptionDb, err := uc.prescriptionRepo.FindBySessionId(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(prescriptionDb, "SessionId", sessionId)
+		}
+		return nil, err
+	}
+
+	if prescriptionDb.IsExpired() {
+		return nil, apperror.ErrPrescriptionExpired
+	}
+
+	if prescriptionDb.IsRedeemed() {
+		return nil, apperror.ErrPrescriptionAlreadyRedeemed
+	}
+
+	for _, prescriptionProduct := range prescriptionDb.PrescriptionProducts {
+		remainingQuantity := prescriptionProduct.GetRemainingQuantity()
+		if remainingQuantity <= 0 {
+			continue
+		}
+
+		cartItem := entity.CartItem{
+			UserId:                userId,
+			ProductId:             prescriptionProduct.ProductId,
+			Quantity:              remainingQuantity,
+			PrescriptionProductId: appdb.NewSqlNullInt64(prescriptionProduct.Id),
+		}
+
+		found, err := uc.cartItemRepo.FindByUserIdAndProductId(ctx, userId, prescriptionProduct.ProductId)
+		if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			// the quantity already in the cart is kept, the cart item now redeems the prescription so it is only
+			// lowered when it holds more than what is left of it
+			if found.Quantity < remainingQuantity {
+				cartItem.Quantity = found.Quantity
+			}
+			_, err = uc.cartItemRepo.Update(ctx, cartItem)
+		} else {
+			_, err = uc.cartItemRepo.Create(ctx, cartItem)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cartItems, err := uc.cartItemRepo.FindAllByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		int64(len(cartItems)), 1, int64(len(cartItems)), 1, cartItems,
+	)
+
+	return paginatedItems, nil
+}
+
+// validatePrescriptionProductRedemption checks that the prescription product can be bought by the user for the given product and quantity
+func validatePrescriptionProductRedemption(prescriptionProduct *entity.PrescriptionProduct, userId int64, productId int64, quantity int32) error {
+	if prescriptionProduct.Prescription.User.Id != userId {
+		return apperror.ErrForbiddenModifyEntity
+	}
+	if prescriptionProduct.ProductId != productId {
+		return apperror.ErrPrescriptionProductMismatch
+	}
+	if prescriptionProduct.Prescription.IsExpired() {
+		return apperror.ErrPrescriptionExpired
+	}
+	if prescriptionProduct.GetRemainingQuantity() <= 0 {
+		return apperror.ErrPrescriptionAlreadyRedeemed
+	}
+	if quantity > prescriptionProduct.GetRemainingQuantity() {
+		return apperror.ErrPrescriptionProductQuantityExceeded
+	}
+	return nil
+}
+
+func validatePrescriptionProducts(prescriptionProducts []*entity.PrescriptionProduct) error {
+	if len(prescriptionProducts) == 0 {
+		return apperror.ErrPrescriptionMustHaveAtLeastOneProduct
+	}
+
+	for _, prescriptionProduct := range prescriptionProducts {
+		// the structured dosage is optional, only the parts that were given are checked
+		if prescriptionProduct.Quantity <= 0 ||
+			(prescriptionProduct.DoseAmount.Valid && !prescriptionProduct.DoseAmount.Decimal.IsPositive()) ||
+			(prescriptionProduct.Frequency.Valid && prescriptionProduct.Frequency.Int32 <= 0) ||
+			(prescriptionProduct.DurationDays.Valid && prescriptionProduct.DurationDays.Int32 <= 0) {
+			return apperror.ErrPrescriptionProductInvalidDosage
+		}
+
+		if prescriptionProduct.Frequency.Int32 > appconstant.PrescriptionMaxFrequencyPerDay {
+			return apperror.ErrPrescriptionProductFrequencyTooHigh
+		}
+
+		if _, ok := appconstant.PrescriptionRouteInstructions[prescriptionProduct.Route.String]; prescriptionProduct.Route.Valid && !ok {
+			return apperror.ErrPrescriptionProductInvalidRoute
+		}
+
+		if _, ok := appconstant.PrescriptionMealTimingInstructions[prescriptionProduct.MealTiming.String]; prescriptionProduct.MealTiming.Valid && !ok {
+			return apperror.ErrPrescriptionProductInvalidMealTiming
+		}
+	}
+	return nil
+}
+
+// checkInteractions returns warnings for duplicate generics, patient allergies and known interactions between the
+// prescribed products and the session patient's current medications, severe interactions and allergies are rejected
+// unless the doctor gives an override reason
+func (uc *PrescriptionUseCaseImpl) checkInteractions(ctx context.Context, prescription entity.Prescription, session *entity.ConsultationSession) ([]*entity.PrescriptionWarning, error) {
+	warnings := make([]*entity.PrescriptionWarning, 0)
+
+	productIdsByGenericName := make(map[string][]int64)
+	genericNames := make([]string, 0)
+	for _, prescriptionProduct := range prescription.PrescriptionProducts {
+		product, err := uc.productRepo.FindById(ctx, prescriptionProduct.ProductId)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound(product, "Id", prescriptionProduct.ProductId)
+			}
+			return nil, err
+		}
+
+		genericName := entity.NormalizeGenericName(product.GenericName)
+		if _, ok := productIdsByGenericName[genericName]; !ok {
+			genericNames = append(genericNames, genericName)
+		}
+		productIdsByGenericName[genericName] = append(productIdsByGenericName[genericName], product.Id)
+	}
+
+	for _, genericName := range genericNames {
+		productIds := productIdsByGenericName[genericName]
+		if len(productIds) < 2 {
+			continue
+		}
+		warnings = append(warnings, &entity.PrescriptionWarning{
+			Type:         appconstant.PrescriptionWarningTypeDuplicateGeneric,
+			GenericNames: []string{genericName},
+			ProductIds:   productIds,
+			Message:      fmt.Sprintf("%d products contain the same generic %s", len(productIds), genericName),
+		})
+	}
+
+	medicalProfile, err := findMedicalProfileByPatient(ctx, uc.medicalProfileRepo, session.UserId, session.DependentId)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make([]string, 0)
+	for _, allergy := range medicalProfile.Allergies {
+		productIds, ok := productIdsByGenericName[allergy.GenericName]
+		if allergy.GenericName == "" || !ok {
+			continue
+		}
+
+		message := fmt.Sprintf("patient is allergic to %s", allergy.Allergen)
+		if allergy.Reaction != "" {
+			message = fmt.Sprintf("%s (%s)", message, allergy.Reaction)
+		}
+		warnings = append(warnings, &entity.PrescriptionWarning{
+			Type:         appconstant.PrescriptionWarningTypeAllergy,
+			SeverityId:   appconstant.DrugInteractionSeverityIdSevere,
+			GenericNames: []string{allergy.GenericName},
+			ProductIds:   productIds,
+			Message:      message,
+		})
+		blocked = append(blocked, fmt.Sprintf("allergy to %s", allergy.GenericName))
+	}
+
+	lookupGenericNames := append(make([]string, 0), genericNames...)
+	for _, medication := range medicalProfile.Medications {
+		if medication.GenericName == "" {
+			continue
+		}
+
+		if productIds, ok := productIdsByGenericName[medication.GenericName]; ok {
+			warnings = append(warnings, &entity.PrescriptionWarning{
+				Type:         appconstant.PrescriptionWarningTypeDuplicateGeneric,
+				GenericNames: []string{medication.GenericName},
+				ProductIds:   productIds,
+				Message:      fmt.Sprintf("patient is already taking %s which contains the same generic %s", medication.Name, medication.GenericName),
+			})
+			continue
+		}
+		lookupGenericNames = append(lookupGenericNames, medication.GenericName)
+	}
+
+	if len(lookupGenericNames) >= 2 {
+		interactions, err := uc.interactionRepo.FindAllByGenericNames(ctx, lookupGenericNames)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, interaction := range interactions {
+			productIdsA, prescribedA := productIdsByGenericName[interaction.GenericNameA]
+			productIdsB, prescribedB := productIdsByGenericName[interaction.GenericNameB]
+			if !prescribedA && !prescribedB {
+				continue
+			}
+
+			productIds := make([]int64, 0)
+			productIds = append(productIds, productIdsA...)
+			productIds = append(productIds, productIdsB...)
+
+			warnings = append(warnings, &entity.PrescriptionWarning{
+				Type:         appconstant.PrescriptionWarningTypeInteraction,
+				SeverityId:   interaction.DrugInteractionSeverityId,
+				GenericNames: []string{interaction.GenericNameA, interaction.GenericNameB},
+				ProductIds:   productIds,
+				Message:      interaction.Description,
+			})
+
+			if interaction.IsSevere() {
+				blocked = append(blocked, fmt.Sprintf("%s + %s", interaction.GenericNameA, interaction.GenericNameB))
+			}
+		}
+	}
+
+	if len(blocked) > 0 && prescription.InteractionOverrideReason == "" {
+		return nil, apperror.NewWrapper(
+			apperror.ErrPrescriptionSevereInteraction,
+			fmt.Sprintf("%s: %s", apperror.ErrPrescriptionSevereInteraction.Error(), strings.Join(blocked, ", ")),
+		)
+	}
+
+	return warnings, nil
 }
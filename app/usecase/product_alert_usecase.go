@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/applogger"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"halodeksik-be/app/util"
+)
+
+type ProductAlertUseCase interface {
+	Add(ctx context.Context, alert entity.ProductAlert) (*entity.ProductAlert, error)
+	GetAllMine(ctx context.Context) ([]*entity.ProductAlert, error)
+	Remove(ctx context.Context, id int64) error
+	NotifyMatches(ctx context.Context) error
+}
+
+type ProductAlertUseCaseImpl struct {
+	repo          repository.ProductAlertRepository
+	productRepo   repository.ProductRepository
+	addressRepo   repository.UserAddressRepository
+	alertNotifier util.ProductAlertNotifier
+}
+
+func NewProductAlertUseCaseImpl(
+	repo repository.ProductAlertRepository,
+	productRepo repository.ProductRepository,
+	addressRepo repository.UserAddressRepository,
+	alertNotifier util.ProductAlertNotifier,
+) *ProductAlertUseCaseImpl {
+	return &ProductAlertUseCaseImpl{
+		repo:          repo,
+		productRepo:   productRepo,
+		addressRepo:   addressRepo,
+		alertNotifier: alertNotifier,
+	}
+}
+
+// Add subscribes the user to the product around their main address, the address is looked up again on every match
+// so changing the main address moves the alert along with it
+func (uc *ProductAlertUseCaseImpl) Add(ctx context.Context, alert entity.ProductAlert) (*entity.ProductAlert, error) {
+	alert.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	product, err := uc.productRepo.FindById(ctx, alert.ProductId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(product, "Id", alert.ProductId)
+		}
+		return nil, err
+	}
+
+	address, err := uc.addressRepo.FindMainByUserId(ctx, alert.UserId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.ErrMainAddressNotFound
+		}
+		return nil, err
+	}
+
+	created, err := uc.repo.Create(ctx, alert, address.Latitude, address.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	created.Product = product
+	return created, nil
+}
+
+func (uc *ProductAlertUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.ProductAlert, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.FindAllByUserId(ctx, userId)
+}
+
+func (uc *ProductAlertUseCaseImpl) Remove(ctx context.Context, id int64) error {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	alert, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(alert, "Id", id)
+		}
+		return err
+	}
+	if alert.UserId != userId {
+		return apperror.ErrForbiddenModifyEntity
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+// NotifyMatches tells users about products that came back in stock or got cheaper around their main address since
+// they were last told. The in-app notifications are stored together with the new availability, emails are sent
+// afterwards and a failed email is only logged so it never makes the same change be notified twice
+func (uc *ProductAlertUseCaseImpl) NotifyMatches(ctx context.Context) error {
+	matches, lastChangeId, err := uc.repo.FindAllMatches(ctx)
+	if err != nil {
+		return err
+	}
+	if lastChangeId == 0 {
+		return nil
+	}
+
+	notifications := make([]*entity.UserNotification, 0)
+	emails := make([]util.ProductAlertNotification, 0)
+	for _, match := range matches {
+		notification := match.ToUserNotification()
+		if notification == nil {
+			continue
+		}
+		notifications = append(notifications, notification)
+		if match.Alert.NotifyByEmail {
+			emails = append(emails, util.ProductAlertNotification{
+				Email:   match.Email,
+				Title:   notification.Title,
+				Message: notification.Message,
+			})
+		}
+	}
+
+	err = uc.repo.SaveMatches(ctx, lastChangeId, matches, notifications)
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		if err := uc.alertNotifier.Notify(email); err != nil {
+			applogger.Log.Error(err.Error())
+		}
+	}
+	return nil
+}
@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/repository"
+	"sort"
 )
 
 type ProductCategoryUseCase interface {
@@ -14,7 +16,9 @@ type ProductCategoryUseCase interface {
 	GetById(ctx context.Context, id int64) (*entity.ProductCategory, error)
 	GetAllProductCategoriesWithoutParams(ctx context.Context) (*entity.PaginatedItems, error)
 	GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetTree(ctx context.Context) ([]*entity.ProductCategory, error)
 	Edit(ctx context.Context, id int64, category entity.ProductCategory) (*entity.ProductCategory, error)
+	Move(ctx context.Context, id int64, parentId sql.NullInt64) (*entity.ProductCategory, error)
 	Remove(ctx context.Context, id int64) error
 }
 
@@ -27,6 +31,11 @@ func NewProductCategoryUseCaseImpl(repo repository.ProductCategoryRepository) *P
 }
 
 func (uc *ProductCategoryUseCaseImpl) Add(ctx context.Context, category entity.ProductCategory) (*entity.ProductCategory, error) {
+	if category.ParentId.Valid {
+		if _, err := uc.GetById(ctx, category.ParentId.Int64); err != nil {
+			return nil, err
+		}
+	}
 	created, err := uc.repo.Create(ctx, category)
 	if err != nil {
 		return nil, err
@@ -85,6 +94,15 @@ func (uc *ProductCategoryUseCaseImpl) GetAll(ctx context.Context, param *querypa
 	return paginatedItems, nil
 }
 
+// GetTree returns the root categories with their descendants nested in Children
+func (uc *ProductCategoryUseCaseImpl) GetTree(ctx context.Context) ([]*entity.ProductCategory, error) {
+	categories, err := uc.repo.FindAllWithoutParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newProductCategoryTree(categories), nil
+}
+
 func (uc *ProductCategoryUseCaseImpl) Edit(ctx context.Context, id int64, category entity.ProductCategory) (*entity.ProductCategory, error) {
 	if _, err := uc.GetById(ctx, id); err != nil {
 		return nil, err
@@ -97,6 +115,26 @@ func (uc *ProductCategoryUseCaseImpl) Edit(ctx context.Context, id int64, catego
 	return updated, nil
 }
 
+// Move moves the category with its whole subtree under another parent, or to the root when parentId is not valid.
+// The repository rejects a parent that lies inside the subtree of the category in the same statement as the move
+func (uc *ProductCategoryUseCaseImpl) Move(ctx context.Context, id int64, parentId sql.NullInt64) (*entity.ProductCategory, error) {
+	if _, err := uc.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if parentId.Valid {
+		if _, err := uc.GetById(ctx, parentId.Int64); err != nil {
+			return nil, err
+		}
+	}
+
+	moved, err := uc.repo.UpdateParent(ctx, id, parentId)
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
 func (uc *ProductCategoryUseCaseImpl) Remove(ctx context.Context, id int64) error {
 	if _, err := uc.GetById(ctx, id); err != nil {
 		return err
@@ -107,3 +145,53 @@ func (uc *ProductCategoryUseCaseImpl) Remove(ctx context.Context, id int64) erro
 	}
 	return nil
 }
+
+// newProductCategoryTree links every category to its children and returns the roots, siblings are sorted by name
+func newProductCategoryTree(categories []*entity.ProductCategory) []*entity.ProductCategory {
+	categoryById := make(map[int64]*entity.ProductCategory)
+	for _, category := range categories {
+		categoryById[category.Id] = category
+	}
+
+	roots := make([]*entity.ProductCategory, 0)
+	for _, category := range categories {
+		parent, ok := categoryById[category.ParentId.Int64]
+		if !category.ParentId.Valid || !ok {
+			roots = append(roots, category)
+			continue
+		}
+		parent.Children = append(parent.Children, category)
+	}
+
+	sortByName := func(siblings []*entity.ProductCategory) {
+		sort.Slice(siblings, func(i, j int) bool {
+			return siblings[i].Name < siblings[j].Name
+		})
+	}
+	sortByName(roots)
+	for _, category := range categories {
+		sortByName(category.Children)
+	}
+	return roots
+}
+
+// productCategoryPaths maps the id of every category to its breadcrumb, from the root down to the category itself
+func productCategoryPaths(categories []*entity.ProductCategory) map[int64][]*entity.ProductCategory {
+	categoryById := make(map[int64]*entity.ProductCategory)
+	for _, category := range categories {
+		categoryById[category.Id] = category
+	}
+
+	paths := make(map[int64][]*entity.ProductCategory)
+	for _, category := range categories {
+		path := make([]*entity.ProductCategory, 0)
+		for current, ok := category, true; ok && len(path) < len(categories); current, ok = categoryById[current.ParentId.Int64] {
+			path = append([]*entity.ProductCategory{current}, path...)
+			if !current.ParentId.Valid {
+				break
+			}
+		}
+		paths[category.Id] = path
+	}
+	return paths
+}
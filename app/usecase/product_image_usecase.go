@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"halodeksik-be/app/appcloud"
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"halodeksik-be/app/util"
+	"mime/multipart"
+)
+
+type ProductImageUseCase interface {
+	Add(ctx context.Context, productId int64, fileHeader *multipart.FileHeader) (*entity.ProductImage, error)
+	Reorder(ctx context.Context, productId int64, imageIds []int64) ([]*entity.ProductImage, error)
+	Remove(ctx context.Context, productId, id int64) error
+}
+
+type ProductImageUseCaseImpl struct {
+	imageRepo   repository.ProductImageRepository
+	productRepo repository.ProductRepository
+	uploader    appcloud.FileUploader
+	imageUtil   util.ImageUtil
+	cloudFolder string
+}
+
+func NewProductImageUseCaseImpl(
+	imageRepo repository.ProductImageRepository,
+	productRepo repository.ProductRepository,
+	uploader appcloud.FileUploader,
+	imageUtil util.ImageUtil,
+) *ProductImageUseCaseImpl {
+	return &ProductImageUseCaseImpl{
+		imageRepo:   imageRepo,
+		productRepo: productRepo,
+		uploader:    uploader,
+		imageUtil:   imageUtil,
+		cloudFolder: appconfig.Config.GcloudStorageFolderProducts,
+	}
+}
+
+func (uc *ProductImageUseCaseImpl) Add(ctx context.Context, productId int64, fileHeader *multipart.FileHeader) (*entity.ProductImage, error) {
+	if err := uc.checkProduct(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	image, err := uploadProductImageRenditions(ctx, uc.uploader, uc.imageUtil, uc.cloudFolder, fileHeader)
+	if err != nil {
+		return nil, err
+	}
+	image.ProductId = productId
+
+	created, err := uc.imageRepo.Create(ctx, *image)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *ProductImageUseCaseImpl) Reorder(ctx context.Context, productId int64, imageIds []int64) ([]*entity.ProductImage, error) {
+	if err := uc.checkProduct(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	images, err := uc.imageRepo.UpdatePositions(ctx, productId, imageIds)
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+func (uc *ProductImageUseCaseImpl) Remove(ctx context.Context, productId, id int64) error {
+	image, err := uc.imageRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(image, "Id", id)
+		}
+		return err
+	}
+	if image.ProductId != productId {
+		return apperror.NewNotFound(image, "Id", id)
+	}
+
+	return uc.imageRepo.Delete(ctx, *image)
+}
+
+func (uc *ProductImageUseCaseImpl) checkProduct(ctx context.Context, productId int64) error {
+	product, err := uc.productRepo.FindById(ctx, productId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(product, "Id", productId)
+		}
+		return err
+	}
+	return nil
+}
+
+// uploadProductImageRenditions uploads the original, medium and thumbnail jpeg renditions of an uploaded image.
+// The renditions are generated by the server so none of them keeps the metadata of the uploaded file
+func uploadProductImageRenditions(
+	ctx context.Context,
+	uploader appcloud.FileUploader,
+	imageUtil util.ImageUtil,
+	cloudFolder string,
+	fileHeader *multipart.FileHeader,
+) (*entity.ProductImage, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	renditions, err := imageUtil.GenerateJpegRenditions(
+		file,
+		appconstant.ProductImageOriginalMaxSize,
+		appconstant.ProductImageMediumMaxSize,
+		appconstant.ProductImageThumbnailSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	imageUUID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	suffixes := []string{"", appconstant.ProductImageMediumSuffix, appconstant.ProductImageThumbnailSuffix}
+	urls := make([]string, 0, len(renditions))
+	for index, rendition := range renditions {
+		fileName := fmt.Sprintf("%s%s%s", imageUUID.String(), suffixes[index], appconstant.ProductImageExtension)
+		url, err := uploader.UploadFromBytes(ctx, rendition, cloudFolder, fileName, appconstant.ContentTypeJpeg)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	return &entity.ProductImage{OriginalUrl: urls[0], MediumUrl: urls[1], ThumbnailUrl: urls[2]}, nil
+}
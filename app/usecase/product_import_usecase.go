@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/applogger"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"strings"
+)
+
+type ProductImportUseCase interface {
+	Add(ctx context.Context, productImport entity.ProductImport, rows []*entity.ProductImportRow) (*entity.ProductImport, error)
+	GetById(ctx context.Context, id int64) (*entity.ProductImport, error)
+	GetAllMine(ctx context.Context) ([]*entity.ProductImport, error)
+}
+
+type ProductImportUseCaseImpl struct {
+	importRepo             repository.ProductImportRepository
+	productRepo            repository.ProductRepository
+	manufacturerRepo       repository.ManufacturerRepository
+	drugClassificationRepo repository.DrugClassificationRepository
+	productCategoryRepo    repository.ProductCategoryRepository
+}
+
+func NewProductImportUseCaseImpl(
+	importRepo repository.ProductImportRepository,
+	productRepo repository.ProductRepository,
+	manufacturerRepo repository.ManufacturerRepository,
+	drugClassificationRepo repository.DrugClassificationRepository,
+	productCategoryRepo repository.ProductCategoryRepository,
+) *ProductImportUseCaseImpl {
+	return &ProductImportUseCaseImpl{
+		importRepo:             importRepo,
+		productRepo:            productRepo,
+		manufacturerRepo:       manufacturerRepo,
+		drugClassificationRepo: drugClassificationRepo,
+		productCategoryRepo:    productCategoryRepo,
+	}
+}
+
+// Add records the import and processes its rows in the background, the progress is read back through GetById.
+// Rows that already failed validation are only reported
+func (uc *ProductImportUseCaseImpl) Add(ctx context.Context, productImport entity.ProductImport, rows []*entity.ProductImportRow) (*entity.ProductImport, error) {
+	productImport.AdminId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+	productImport.Status = appconstant.ProductImportStatusProcessing
+	productImport.TotalRows = int32(len(rows))
+
+	created, err := uc.importRepo.Create(ctx, productImport)
+	if err != nil {
+		return nil, err
+	}
+
+	go uc.process(context.Background(), *created, rows)
+
+	return created, nil
+}
+
+func (uc *ProductImportUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.ProductImport, error) {
+	productImport, err := uc.importRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(productImport, "Id", id)
+		}
+		return nil, err
+	}
+	if productImport.AdminId != ctx.Value(appconstant.ContextKeyUserId).(int64) {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+	return productImport, nil
+}
+
+func (uc *ProductImportUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.ProductImport, error) {
+	return uc.importRepo.FindAllByAdminId(ctx, ctx.Value(appconstant.ContextKeyUserId).(int64))
+}
+
+func (uc *ProductImportUseCaseImpl) process(ctx context.Context, productImport entity.ProductImport, rows []*entity.ProductImportRow) {
+	fail := func(err error) {
+		applogger.Log.Errorf("error processing product import %d: %v", productImport.Id, err)
+		productImport.Status = appconstant.ProductImportStatusFailed
+		productImport.ErrorMessage = err.Error()
+		if err := uc.importRepo.UpdateProgress(ctx, productImport, nil); err != nil {
+			applogger.Log.Errorf("error marking product import %d as failed: %v", productImport.Id, err)
+		}
+	}
+
+	// the import runs outside of the request, a panic would otherwise take the server down and leave it processing
+	defer func() {
+		if r := recover(); r != nil {
+			fail(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	manufacturerIds, drugClassificationIds, productCategoryIds, err := uc.findIdsByName(ctx)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	seenProducts := make(map[string]int32)
+	for start := 0; start < len(rows); start += appconstant.ProductImportProgressBatchSize {
+		end := start + appconstant.ProductImportProgressBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		batch := rows[start:end]
+		for _, row := range batch {
+			if err := uc.processRow(ctx, productImport.IsDryRun, row, manufacturerIds, drugClassificationIds, productCategoryIds, seenProducts); err != nil {
+				fail(err)
+				return
+			}
+			if row.Status == appconstant.ProductImportRowStatusFailed {
+				productImport.FailedRows++
+			} else {
+				productImport.SucceededRows++
+			}
+		}
+
+		productImport.ProcessedRows = int32(end)
+		if end == len(rows) {
+			productImport.Status = appconstant.ProductImportStatusCompleted
+		}
+		if err := uc.importRepo.UpdateProgress(ctx, productImport, batch); err != nil {
+			fail(err)
+			return
+		}
+	}
+}
+
+// processRow resolves the names of the row and creates its product unless the row fails or the import is a dry run.
+// Only unexpected errors are returned, problems of the row itself are added to the row errors
+func (uc *ProductImportUseCaseImpl) processRow(
+	ctx context.Context,
+	isDryRun bool,
+	row *entity.ProductImportRow,
+	manufacturerIds, drugClassificationIds map[string]int64, productCategoryIds map[string][]int64,
+	seenProducts map[string]int32,
+) error {
+	product := row.Product
+	if id, ok := manufacturerIds[strings.ToLower(row.ManufacturerName)]; ok {
+		product.ManufacturerId = id
+	} else if row.ManufacturerName != "" {
+		row.AddError(fmt.Sprintf("manufacturer '%s' does not exist", row.ManufacturerName))
+	}
+	if id, ok := drugClassificationIds[strings.ToLower(row.DrugClassificationName)]; ok {
+		product.DrugClassificationId = id
+	} else if row.DrugClassificationName != "" {
+		row.AddError(fmt.Sprintf("drug classification '%s' does not exist", row.DrugClassificationName))
+	}
+	if ids := productCategoryIds[productCategoryImportKey(row.ProductCategoryName)]; len(ids) == 1 {
+		product.ProductCategoryId = ids[0]
+	} else if len(ids) > 1 {
+		row.AddError(fmt.Sprintf(
+			"product category '%s' matches %d categories, use its full path e.g. 'Parent%sChild'",
+			row.ProductCategoryName, len(ids), appconstant.ProductImportCategoryPathSeparator,
+		))
+	} else if row.ProductCategoryName != "" {
+		row.AddError(fmt.Sprintf("product category '%s' does not exist", row.ProductCategoryName))
+	}
+
+	if product.ManufacturerId != 0 {
+		key := fmt.Sprintf("%s|%s|%s|%d", product.Name, product.GenericName, product.Content, product.ManufacturerId)
+		if firstRow, ok := seenProducts[key]; ok {
+			row.AddError(fmt.Sprintf("duplicate of row %d", firstRow))
+		} else {
+			seenProducts[key] = row.RowNumber
+		}
+
+		existing, err := uc.productRepo.FindByUniqueKey(ctx, product)
+		if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+			return err
+		}
+		if existing != nil {
+			row.AddError(fmt.Sprintf("product already exists with id %d", existing.Id))
+		}
+	}
+
+	if len(row.Errors) > 0 {
+		row.Status = appconstant.ProductImportRowStatusFailed
+		return nil
+	}
+	if isDryRun {
+		row.Status = appconstant.ProductImportRowStatusValid
+		return nil
+	}
+
+	created, err := uc.productRepo.Create(ctx, product)
+	if err != nil {
+		if errors.Is(err, apperror.ErrProductUniqueConstraint) {
+			row.AddError(apperror.ErrProductUniqueConstraint.Error())
+			row.Status = appconstant.ProductImportRowStatusFailed
+			return nil
+		}
+		return err
+	}
+	row.Status = appconstant.ProductImportRowStatusCreated
+	row.ProductId.Int64, row.ProductId.Valid = created.Id, true
+	return nil
+}
+
+func (uc *ProductImportUseCaseImpl) findIdsByName(ctx context.Context) (map[string]int64, map[string]int64, map[string][]int64, error) {
+	manufacturers, err := uc.manufacturerRepo.FindAllWithoutParams(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	drugClassifications, err := uc.drugClassificationRepo.FindAllWithoutParams(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	productCategories, err := uc.productCategoryRepo.FindAllWithoutParams(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	manufacturerIds := make(map[string]int64)
+	for _, manufacturer := range manufacturers {
+		manufacturerIds[strings.ToLower(manufacturer.Name)] = manufacturer.Id
+	}
+	drugClassificationIds := make(map[string]int64)
+	for _, drugClassification := range drugClassifications {
+		drugClassificationIds[strings.ToLower(drugClassification.Name)] = drugClassification.Id
+	}
+	return manufacturerIds, drugClassificationIds, productCategoryIdsByName(productCategories), nil
+}
+
+// productCategoryIdsByName makes every category reachable by its own name and by its full path from the root,
+// a name shared by categories under different parents maps to all of them so the row has to use the path instead
+func productCategoryIdsByName(categories []*entity.ProductCategory) map[string][]int64 {
+	productCategoryIds := make(map[string][]int64)
+	for id, path := range productCategoryPaths(categories) {
+		names := make([]string, 0, len(path))
+		for _, category := range path {
+			names = append(names, category.Name)
+		}
+		name := productCategoryImportKey(path[len(path)-1].Name)
+		productCategoryIds[name] = append(productCategoryIds[name], id)
+		if len(path) > 1 {
+			fullPath := productCategoryImportKey(strings.Join(names, appconstant.ProductImportCategoryPathSeparator))
+			productCategoryIds[fullPath] = append(productCategoryIds[fullPath], id)
+		}
+	}
+	return productCategoryIds
+}
+
+// productCategoryImportKey normalizes a category name or path so that casing and the spacing around the path separator
+// do not matter, e.g. "obat>Vitamin" and "Obat > vitamin" give the same key
+func productCategoryImportKey(name string) string {
+	parts := strings.Split(name, strings.TrimSpace(appconstant.ProductImportCategoryPathSeparator))
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.ToLower(strings.Join(parts, appconstant.ProductImportCategoryPathSeparator))
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"database/sql"
+	"halodeksik-be/app/entity"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestProductCategoryIdsByName(t *testing.T) {
+	categories := []*entity.ProductCategory{
+		{Id: 1, Name: "Obat"},
+		{Id: 2, Name: "Suplemen"},
+		{Id: 3, Name: "Vitamin", ParentId: sql.NullInt64{Int64: 1, Valid: true}},
+		{Id: 4, Name: "Vitamin", ParentId: sql.NullInt64{Int64: 2, Valid: true}},
+		{Id: 5, Name: "Anak", ParentId: sql.NullInt64{Int64: 3, Valid: true}},
+	}
+	productCategoryIds := productCategoryIdsByName(categories)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []int64
+	}{
+		{name: "unique root name", input: "Obat", expected: []int64{1}},
+		{name: "unique child name", input: "anak", expected: []int64{5}},
+		{name: "name shared by two parents", input: "Vitamin", expected: []int64{3, 4}},
+		{name: "full path", input: "Obat > Vitamin", expected: []int64{3}},
+		{name: "full path with loose spacing and casing", input: "suplemen>VITAMIN ", expected: []int64{4}},
+		{name: "nested full path", input: "Obat > Vitamin > Anak", expected: []int64{5}},
+		{name: "path that does not exist", input: "Suplemen > Anak", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := productCategoryIds[productCategoryImportKey(tt.input)]
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ids of %q = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"halodeksik-be/app/appcloud"
+	"halodeksik-be/app/appconfig"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/dto/queryparamdto"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+	"halodeksik-be/app/util"
+	"mime/multipart"
+)
+
+type ProductReviewUseCase interface {
+	Add(ctx context.Context, review entity.ProductReview, photos []*multipart.FileHeader) (*entity.ProductReview, error)
+	GetAllByProductId(ctx context.Context, productId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	EditStatus(ctx context.Context, id int64, review entity.ProductReview) (*entity.ProductReview, error)
+}
+
+type ProductReviewUseCaseImpl struct {
+	repo        repository.ProductReviewRepository
+	productRepo repository.ProductRepository
+	uploader    appcloud.FileUploader
+	imageUtil   util.ImageUtil
+	cloudFolder string
+}
+
+func NewProductReviewUseCaseImpl(
+	repo repository.ProductReviewRepository,
+	productRepo repository.ProductRepository,
+	uploader appcloud.FileUploader,
+	imageUtil util.ImageUtil,
+) *ProductReviewUseCaseImpl {
+	return &ProductReviewUseCaseImpl{
+		repo:        repo,
+		productRepo: productRepo,
+		uploader:    uploader,
+		imageUtil:   imageUtil,
+		cloudFolder: appconfig.Config.GcloudStorageFolderProducts,
+	}
+}
+
+// Add publishes the review of an order detail the user bought, the photos are only uploaded once the user is known
+// to be allowed to review it
+func (uc *ProductReviewUseCaseImpl) Add(ctx context.Context, review entity.ProductReview, photos []*multipart.FileHeader) (*entity.ProductReview, error) {
+	review.UserId = ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	orderDetail, err := uc.repo.FindReviewableOrderDetail(ctx, review.UserId, review.OrderDetailId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.ErrProductReviewNotAllowed
+		}
+		return nil, err
+	}
+	review.ProductId = orderDetail.ProductId
+	review.Status = appconstant.ProductReviewStatusPublished
+
+	for _, fileHeader := range photos {
+		url, err := uc.uploadPhoto(ctx, fileHeader)
+		if err != nil {
+			return nil, err
+		}
+		review.Photos = append(review.Photos, &entity.ProductReviewPhoto{Url: url})
+	}
+
+	created, err := uc.repo.Create(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.FindById(ctx, created.Id)
+}
+
+func (uc *ProductReviewUseCaseImpl) GetAllByProductId(ctx context.Context, productId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	product, err := uc.productRepo.FindById(ctx, productId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(product, "Id", productId)
+		}
+		return nil, err
+	}
+	return uc.GetAll(ctx, param)
+}
+
+func (uc *ProductReviewUseCaseImpl) GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	reviews, err := uc.repo.FindAll(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := uc.repo.CountFindAll(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		totalItems, totalPages, int64(len(reviews)), int64(*param.PageId), reviews,
+	)
+	return paginatedItems, nil
+}
+
+func (uc *ProductReviewUseCaseImpl) EditStatus(ctx context.Context, id int64, review entity.ProductReview) (*entity.ProductReview, error) {
+	found, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(found, "Id", id)
+		}
+		return nil, err
+	}
+
+	review.Id = id
+	return uc.repo.UpdateStatus(ctx, review)
+}
+
+// uploadPhoto re-encodes the photo on the server so the location and camera metadata of the buyer are never published
+func (uc *ProductReviewUseCaseImpl) uploadPhoto(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	renditions, err := uc.imageUtil.GenerateJpegRenditions(file, appconstant.ProductReviewPhotoMaxSize)
+	if err != nil {
+		return "", err
+	}
+
+	photoUUID, err := uuid.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf("review-%s%s", photoUUID.String(), appconstant.ProductImageExtension)
+	return uc.uploader.UploadFromBytes(ctx, renditions[0], uc.cloudFolder, fileName, appconstant.ContentTypeJpeg)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/google/uuid"
 	"halodeksik-be/app/appcloud"
 	"halodeksik-be/app/appconfig"
 	"halodeksik-be/app/appconstant"
@@ -15,7 +14,6 @@ import (
 	"halodeksik-be/app/repository"
 	"halodeksik-be/app/util"
 	"mime/multipart"
-	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -23,29 +21,44 @@ import (
 type ProductUseCase interface {
 	Add(ctx context.Context, product entity.Product) (*entity.Product, error)
 	GetById(ctx context.Context, id int64) (*entity.Product, error)
-	GetByIdForUser(ctx context.Context, id int64, params *queryparamdto.GetAllParams) (*entity.Product, error)
+	GetByIdForUser(ctx context.Context, id int64, params, relatedParams *queryparamdto.GetAllParams) (*entity.Product, error)
 	GetAll(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	GetAllForUser(ctx context.Context, lat, long string, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetAllFacetsForUser(ctx context.Context, lat, long string, params *queryparamdto.ProductFacetParams) (*entity.ProductFacets, error)
 	GetAllForAdminByPharmacyId(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	Edit(ctx context.Context, id int64, product entity.Product) (*entity.Product, error)
 	Remove(ctx context.Context, id int64) error
 }
 
 type ProductUseCaseImpl struct {
-	productRepo  repository.ProductRepository
-	pharmacyRepo repository.PharmacyRepository
-	uploader     appcloud.FileUploader
-	cloudUrl     string
-	cloudFolder  string
+	productRepo        repository.ProductRepository
+	pharmacyRepo       repository.PharmacyRepository
+	categoryRepo       repository.ProductCategoryRepository
+	imageRepo          repository.ProductImageRepository
+	recommendationRepo repository.ProductRecommendationRepository
+	uploader           appcloud.FileUploader
+	imageUtil          util.ImageUtil
+	cloudFolder        string
 }
 
-func NewProductUseCaseImpl(productRepo repository.ProductRepository, pharmacyRepo repository.PharmacyRepository, uploader appcloud.FileUploader) *ProductUseCaseImpl {
+func NewProductUseCaseImpl(
+	productRepo repository.ProductRepository,
+	pharmacyRepo repository.PharmacyRepository,
+	categoryRepo repository.ProductCategoryRepository,
+	imageRepo repository.ProductImageRepository,
+	recommendationRepo repository.ProductRecommendationRepository,
+	uploader appcloud.FileUploader,
+	imageUtil util.ImageUtil,
+) *ProductUseCaseImpl {
 	return &ProductUseCaseImpl{
-		productRepo:  productRepo,
-		pharmacyRepo: pharmacyRepo,
-		uploader:     uploader,
-		cloudUrl:     appconfig.Config.GcloudStorageCdn,
-		cloudFolder:  appconfig.Config.GcloudStorageFolderProducts,
+		productRepo:        productRepo,
+		pharmacyRepo:       pharmacyRepo,
+		categoryRepo:       categoryRepo,
+		imageRepo:          imageRepo,
+		recommendationRepo: recommendationRepo,
+		uploader:           uploader,
+		imageUtil:          imageUtil,
+		cloudFolder:        appconfig.Config.GcloudStorageFolderProducts,
 	}
 }
 
@@ -54,29 +67,23 @@ func (uc *ProductUseCaseImpl) Add(ctx context.Context, product entity.Product) (
 	if fileHeader == nil {
 		return nil, apperror.ErrProductImageDoesNotExistInContext
 	}
-	file, err := fileHeader.Open()
+	image, err := uploadProductImageRenditions(ctx, uc.uploader, uc.imageUtil, uc.cloudFolder, fileHeader)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	product.Image = image.OriginalUrl
 
-	extension := filepath.Ext(fileHeader.Filename)
-	createUUID, err := uuid.NewUUID()
-	if err != nil {
-		return nil, err
-	}
-	fileName := fmt.Sprintf("%s%s", createUUID.String(), extension)
-
-	err = uc.uploader.SendToBucket(ctx, file, fmt.Sprintf("%s/", uc.cloudFolder), fileName)
+	created, err := uc.productRepo.Create(ctx, product)
 	if err != nil {
 		return nil, err
 	}
-	product.Image = fmt.Sprintf("%s/%s/%s", uc.cloudUrl, uc.cloudFolder, fileName)
 
-	created, err := uc.productRepo.Create(ctx, product)
+	image.ProductId = created.Id
+	createdImage, err := uc.imageRepo.Create(ctx, *image)
 	if err != nil {
 		return nil, err
 	}
+	created.Images = []*entity.ProductImage{createdImage}
 
 	return created, nil
 }
@@ -89,10 +96,13 @@ func (uc *ProductUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.Pr
 		}
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, product); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
-func (uc *ProductUseCaseImpl) GetByIdForUser(ctx context.Context, id int64, params *queryparamdto.GetAllParams) (*entity.Product, error) {
+func (uc *ProductUseCaseImpl) GetByIdForUser(ctx context.Context, id int64, params, relatedParams *queryparamdto.GetAllParams) (*entity.Product, error) {
 	product, err := uc.productRepo.FindByIdForUser(ctx, id, params)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
@@ -100,6 +110,14 @@ func (uc *ProductUseCaseImpl) GetByIdForUser(ctx context.Context, id int64, para
 		}
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, product); err != nil {
+		return nil, err
+	}
+
+	product.RelatedProducts, err = uc.recommendationRepo.FindAllRelatedForUser(ctx, []int64{id}, appconstant.RelatedProductsLimit, relatedParams)
+	if err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
@@ -108,6 +126,9 @@ func (uc *ProductUseCaseImpl) GetAll(ctx context.Context, param *queryparamdto.G
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, products...); err != nil {
+		return nil, err
+	}
 
 	totalItems, err := uc.productRepo.CountFindAll(ctx, param)
 	if err != nil {
@@ -127,42 +148,49 @@ func (uc *ProductUseCaseImpl) GetAll(ctx context.Context, param *queryparamdto.G
 	return paginatedItems, nil
 }
 
-func (uc *ProductUseCaseImpl) GetAllForUser(ctx context.Context, lat, long string, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
-	pharmacyParam := queryparamdto.NewGetAllParams()
-	pharmacy := new(entity.Pharmacy)
-	latColName := pharmacy.GetSqlColumnFromField("Latitude")
-	lonColName := pharmacy.GetSqlColumnFromField("Longitude")
-
-	pharmacyParam.WhereClauses = append(
-		pharmacyParam.WhereClauses,
-		appdb.NewWhere(
-			fmt.Sprintf("distance(%s, %s, '%s', '%s')", latColName, lonColName, lat, long),
-			appdb.LessOrEqualTo,
-			appconstant.ClosestPharmacyRangeRadius,
-		),
-	)
-	pharmacies, err := uc.pharmacyRepo.FindAll(ctx, pharmacyParam)
+// getAllForDoctor lists the global catalogue with the doctor's favorites and most recently prescribed products first
+func (uc *ProductUseCaseImpl) getAllForDoctor(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	products, err := uc.productRepo.FindAllForDoctor(ctx, doctorId, param)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, products...); err != nil {
+		return nil, err
+	}
 
-	pharmacyIds := ""
-	for _, p := range pharmacies {
-		pharmacyIds += strconv.Itoa(int(p.Id)) + ","
+	totalItems, err := uc.productRepo.CountFindAll(ctx, param)
+	if err != nil {
+		return nil, err
 	}
-	if !util.IsEmptyString(pharmacyIds) {
-		pp := entity.PharmacyProduct{}
-		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(pp.GetSqlColumnFromField("PharmacyId"), appdb.In, strings.TrimSuffix(pharmacyIds, ",")))
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
 	}
-	if util.IsEmptyString(pharmacyIds) {
-		pp := entity.PharmacyProduct{}
-		param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(pp.GetSqlColumnFromField("PharmacyId"), appdb.In, appconstant.EmptyIdInString))
+
+	paginatedItems := new(entity.PaginatedItems)
+	paginatedItems.Items = products
+	paginatedItems.TotalItems = totalItems
+	paginatedItems.TotalPages = totalPages
+	paginatedItems.CurrentPageTotalItems = int64(len(products))
+	paginatedItems.CurrentPage = int64(*param.PageId)
+	return paginatedItems, nil
+}
+
+func (uc *ProductUseCaseImpl) GetAllForUser(ctx context.Context, lat, long string, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	nearbyClause, err := uc.nearbyPharmacyClause(ctx, lat, long)
+	if err != nil {
+		return nil, err
 	}
+	param.WhereClauses = append(param.WhereClauses, nearbyClause)
 
 	products, err := uc.productRepo.FindAllForUser(ctx, param)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, products...); err != nil {
+		return nil, err
+	}
 
 	totalItems, err := uc.productRepo.CountFindAllForUser(ctx, param)
 	if err != nil {
@@ -182,7 +210,53 @@ func (uc *ProductUseCaseImpl) GetAllForUser(ctx context.Context, lat, long strin
 	return paginatedItems, nil
 }
 
+func (uc *ProductUseCaseImpl) GetAllFacetsForUser(ctx context.Context, lat, long string, params *queryparamdto.ProductFacetParams) (*entity.ProductFacets, error) {
+	nearbyClause, err := uc.nearbyPharmacyClause(ctx, lat, long)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range []*queryparamdto.GetAllParams{params.DrugClassification, params.ProductCategory, params.Manufacturer, params.DrugForm, params.PriceRange} {
+		param.WhereClauses = append(param.WhereClauses, nearbyClause)
+	}
+
+	return uc.productRepo.FindAllFacetsForUser(ctx, params)
+}
+
+// nearbyPharmacyClause limits the pharmacy products to the pharmacies within reach of the user's location
+func (uc *ProductUseCaseImpl) nearbyPharmacyClause(ctx context.Context, lat, long string) (appdb.WhereClause, error) {
+	pharmacyParam := queryparamdto.NewGetAllParams()
+	pharmacy := new(entity.Pharmacy)
+	latColName := pharmacy.GetSqlColumnFromField("Latitude")
+	lonColName := pharmacy.GetSqlColumnFromField("Longitude")
+
+	pharmacyParam.WhereClauses = append(
+		pharmacyParam.WhereClauses,
+		appdb.NewWhere(
+			fmt.Sprintf("distance(%s, %s, '%s', '%s')", latColName, lonColName, lat, long),
+			appdb.LessOrEqualTo,
+			appconstant.ClosestPharmacyRangeRadius,
+		),
+	)
+	pharmacies, err := uc.pharmacyRepo.FindAll(ctx, pharmacyParam)
+	if err != nil {
+		return appdb.WhereClause{}, err
+	}
+
+	pharmacyIds := ""
+	for _, p := range pharmacies {
+		pharmacyIds += strconv.Itoa(int(p.Id)) + ","
+	}
+	pp := entity.PharmacyProduct{}
+	if util.IsEmptyString(pharmacyIds) {
+		return appdb.NewWhere(pp.GetSqlColumnFromField("PharmacyId"), appdb.In, appconstant.EmptyIdInString), nil
+	}
+	return appdb.NewWhere(pp.GetSqlColumnFromField("PharmacyId"), appdb.In, strings.TrimSuffix(pharmacyIds, ",")), nil
+}
+
 func (uc *ProductUseCaseImpl) GetAllForAdminByPharmacyId(ctx context.Context, pharmacyId int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	if roleId, ok := ctx.Value(appconstant.ContextKeyRoleId).(int64); ok && roleId == appconstant.UserRoleIdDoctor && pharmacyId <= 0 {
+		return uc.getAllForDoctor(ctx, param)
+	}
 	if pharmacyId <= 0 {
 		return uc.GetAll(ctx, param)
 	}
@@ -205,6 +279,9 @@ func (uc *ProductUseCaseImpl) GetAllForAdminByPharmacyId(ctx context.Context, ph
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.attachProductDetails(ctx, products...); err != nil {
+		return nil, err
+	}
 
 	totalItems, err := uc.productRepo.CountFindAllForAdmin(ctx, pharmacyId, param)
 	if err != nil {
@@ -230,31 +307,29 @@ func (uc *ProductUseCaseImpl) Edit(ctx context.Context, id int64, product entity
 	product.Id = id
 	product.Image = productDb.Image
 
+	// a new image replaces the cover of the gallery, the rest of the gallery is managed through the product images
+	var cover *entity.ProductImage
 	fileHeaderAny := ctx.Value(appconstant.FormImage)
 	if fileHeaderAny != nil {
-		fileHeader := fileHeaderAny.(*multipart.FileHeader)
-		file, err := fileHeader.Open()
+		cover, err = uploadProductImageRenditions(ctx, uc.uploader, uc.imageUtil, uc.cloudFolder, fileHeaderAny.(*multipart.FileHeader))
 		if err != nil {
 			return nil, err
 		}
-		defer file.Close()
+		product.Image = cover.OriginalUrl
+	}
 
-		extension := filepath.Ext(fileHeader.Filename)
-		updateUUID, err := uuid.NewUUID()
-		if err != nil {
-			return nil, err
-		}
-		fileName := fmt.Sprintf("%s%s", updateUUID.String(), extension)
+	updated, err := uc.productRepo.Update(ctx, product)
+	if err != nil {
+		return nil, err
+	}
 
-		err = uc.uploader.SendToBucket(ctx, file, fmt.Sprintf("%s/", uc.cloudFolder), fileName)
-		if err != nil {
+	if cover != nil {
+		cover.ProductId = id
+		if _, err := uc.imageRepo.ReplaceCover(ctx, *cover); err != nil {
 			return nil, err
 		}
-		product.Image = fmt.Sprintf("%s/%s/%s", uc.cloudUrl, uc.cloudFolder, fileName)
 	}
-
-	updated, err := uc.productRepo.Update(ctx, product)
-	if err != nil {
+	if err := uc.attachProductDetails(ctx, updated); err != nil {
 		return nil, err
 	}
 	return updated, nil
@@ -271,3 +346,34 @@ func (uc *ProductUseCaseImpl) Remove(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// attachProductDetails sets the breadcrumb of the category and the image gallery of every product
+func (uc *ProductUseCaseImpl) attachProductDetails(ctx context.Context, products ...*entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	categories, err := uc.categoryRepo.FindAllWithoutParams(ctx)
+	if err != nil {
+		return err
+	}
+	paths := productCategoryPaths(categories)
+
+	productIds := make([]int64, 0, len(products))
+	for _, product := range products {
+		productIds = append(productIds, product.Id)
+	}
+	images, err := uc.imageRepo.FindAllByProductIds(ctx, productIds)
+	if err != nil {
+		return err
+	}
+	imagesByProductId := make(map[int64][]*entity.ProductImage)
+	for _, image := range images {
+		imagesByProductId[image.ProductId] = append(imagesByProductId[image.ProductId], image)
+	}
+
+	for _, product := range products {
+		product.ProductCategoryPath = paths[product.ProductCategoryId]
+		product.Images = imagesByProductId[product.Id]
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type ReferralUseCase interface {
+	Add(ctx context.Context, referral entity.Referral) (*entity.Referral, error)
+	GetById(ctx context.Context, id int64) (*entity.Referral, error)
+	GetByReferredSessionId(ctx context.Context, sessionId int64) (*entity.Referral, error)
+	GetAllMine(ctx context.Context) ([]*entity.Referral, error)
+	AddSession(ctx context.Context, id int64, doctorId int64) (*entity.ConsultationSession, error)
+}
+
+type ReferralUseCaseImpl struct {
+	repo               repository.ReferralRepository
+	sessionRepo        repository.ConsultationSessionRepository
+	profileRepo        repository.ProfileRepository
+	specializationRepo repository.DoctorSpecializationRepository
+	prescriptionRepo   repository.PrescriptionRepository
+	clinicalNoteRepo   repository.ClinicalNoteRepository
+}
+
+func NewReferralUseCaseImpl(
+	repo repository.ReferralRepository,
+	sessionRepo repository.ConsultationSessionRepository,
+	profileRepo repository.ProfileRepository,
+	specializationRepo repository.DoctorSpecializationRepository,
+	prescriptionRepo repository.PrescriptionRepository,
+	clinicalNoteRepo repository.ClinicalNoteRepository,
+) *ReferralUseCaseImpl {
+	return &ReferralUseCaseImpl{
+		repo:               repo,
+		sessionRepo:        sessionRepo,
+		profileRepo:        profileRepo,
+		specializationRepo: specializationRepo,
+		prescriptionRepo:   prescriptionRepo,
+		clinicalNoteRepo:   clinicalNoteRepo,
+	}
+}
+
+func (uc *ReferralUseCaseImpl) Add(ctx context.Context, referral entity.Referral) (*entity.Referral, error) {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	sessionDb, err := uc.sessionRepo.FindById(ctx, referral.SessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", referral.SessionId)
+		}
+		return nil, err
+	}
+	if sessionDb.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+	if sessionDb.ConsultationSessionStatusId != appconstant.ConsultationSessionStatusOngoing {
+		return nil, apperror.ErrChatAlreadyEnded
+	}
+
+	if referral.TargetDoctorId.Valid {
+		if referral.TargetDoctorId.Int64 == doctorId {
+			return nil, apperror.ErrReferralToSameDoctor
+		}
+		if _, err = uc.findTargetDoctor(ctx, referral, referral.TargetDoctorId.Int64); err != nil {
+			return nil, err
+		}
+	} else {
+		specialization, err := uc.specializationRepo.FindById(ctx, referral.DoctorSpecializationId.Int64)
+		if err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound(specialization, "Id", referral.DoctorSpecializationId.Int64)
+			}
+			return nil, err
+		}
+	}
+
+	referral.ReferringDoctorId = doctorId
+	referral.UserId = sessionDb.UserId
+	referral.DependentId = sessionDb.DependentId
+	created, err := uc.repo.Create(ctx, referral)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.FindById(ctx, created.Id)
+}
+
+// GetById lets the patient, the referring doctor and the doctor of the referred session see the referral,
+// only doctors get the referring session's clinical note
+func (uc *ReferralUseCaseImpl) GetById(ctx context.Context, id int64) (*entity.Referral, error) {
+	clientId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	roleId := ctx.Value(appconstant.ContextKeyRoleId).(int64)
+
+	referral, err := uc.findById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleId == appconstant.UserRoleIdUser {
+		if referral.UserId != clientId {
+			return nil, apperror.ErrForbiddenViewEntity
+		}
+		if err = uc.attachReferringContext(ctx, referral, false); err != nil {
+			return nil, err
+		}
+		return referral, nil
+	}
+
+	if referral.ReferringDoctorId != clientId {
+		if !referral.IsUsed() {
+			return nil, apperror.ErrForbiddenViewEntity
+		}
+		referredSession, err := uc.sessionRepo.FindById(ctx, referral.ReferredSessionId.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if referredSession.DoctorId != clientId {
+			return nil, apperror.ErrForbiddenViewEntity
+		}
+	}
+
+	if err = uc.attachReferringContext(ctx, referral, true); err != nil {
+		return nil, err
+	}
+	return referral, nil
+}
+
+// GetByReferredSessionId is how the specialist finds the referral, and its context, behind one of their sessions
+func (uc *ReferralUseCaseImpl) GetByReferredSessionId(ctx context.Context, sessionId int64) (*entity.Referral, error) {
+	doctorId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	sessionDb, err := uc.sessionRepo.FindById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(sessionDb, "Id", sessionId)
+		}
+		return nil, err
+	}
+	if sessionDb.DoctorId != doctorId {
+		return nil, apperror.ErrForbiddenViewEntity
+	}
+
+	referral, err := uc.repo.FindByReferredSessionId(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(referral, "ReferredSessionId", sessionId)
+		}
+		return nil, err
+	}
+
+	if err = uc.attachReferringContext(ctx, referral, true); err != nil {
+		return nil, err
+	}
+	return referral, nil
+}
+
+func (uc *ReferralUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.Referral, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.FindAllByUserId(ctx, userId)
+}
+
+func (uc *ReferralUseCaseImpl) AddSession(ctx context.Context, id int64, doctorId int64) (*entity.ConsultationSession, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	referral, err := uc.findById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if referral.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+	if referral.IsUsed() {
+		return nil, apperror.ErrReferralAlreadyUsed
+	}
+
+	// a referral to a specific doctor always opens the session with that doctor
+	if referral.TargetDoctorId.Valid {
+		doctorId = referral.TargetDoctorId.Int64
+	} else {
+		if doctorId == 0 {
+			return nil, apperror.ErrReferralDoctorRequired
+		}
+		if doctorId == referral.ReferringDoctorId {
+			return nil, apperror.ErrReferralToSameDoctor
+		}
+		if _, err = uc.findTargetDoctor(ctx, *referral, doctorId); err != nil {
+			return nil, err
+		}
+	}
+
+	sessionDb, err := uc.sessionRepo.FindByPatientAndDoctorId(ctx, referral.UserId, referral.DependentId, doctorId)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil && sessionDb.ConsultationSessionStatusId == appconstant.ConsultationSessionStatusOngoing {
+		return sessionDb, apperror.ErrChatStillOngoing
+	}
+
+	session := entity.ConsultationSession{
+		UserId:                      referral.UserId,
+		DependentId:                 referral.DependentId,
+		DoctorId:                    doctorId,
+		ConsultationSessionStatusId: appconstant.ConsultationSessionStatusOngoing,
+	}
+	return uc.repo.CreateReferredSession(ctx, referral.Id, session)
+}
+
+func (uc *ReferralUseCaseImpl) findById(ctx context.Context, id int64) (*entity.Referral, error) {
+	referral, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(referral, "Id", id)
+		}
+		return nil, err
+	}
+	return referral, nil
+}
+
+// findTargetDoctor makes sure the doctor exists and has the referred specialization when one is named
+func (uc *ReferralUseCaseImpl) findTargetDoctor(ctx context.Context, referral entity.Referral, doctorId int64) (*entity.User, error) {
+	doctor, err := uc.profileRepo.FindDoctorProfileByUserId(ctx, doctorId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(doctor, "Id", doctorId)
+		}
+		return nil, err
+	}
+	if referral.DoctorSpecializationId.Valid &&
+		doctor.DoctorProfile.DoctorSpecializationId != referral.DoctorSpecializationId.Int64 {
+		return nil, apperror.ErrReferralDoctorSpecializationMismatch
+	}
+	return doctor, nil
+}
+
+func (uc *ReferralUseCaseImpl) attachReferringContext(ctx context.Context, referral *entity.Referral, withClinicalNote bool) error {
+	prescription, err := uc.prescriptionRepo.FindBySessionId(ctx, referral.SessionId)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return err
+	}
+	referral.ReferringPrescription = prescription
+
+	if !withClinicalNote {
+		return nil
+	}
+	clinicalNote, err := uc.clinicalNoteRepo.FindBySessionId(ctx, referral.SessionId)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return err
+	}
+	referral.ReferringClinicalNote = clinicalNote
+	return nil
+}
@@ -15,6 +15,9 @@ type ReportUseCase interface {
 
 	GetSellsAllPharmacyMonthly(ctx context.Context, year int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 	GetSellsAllAdminPharmacyMonthly(ctx context.Context, year int64, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+
+	GetSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
+	GetSellPricesAdminPharmacy(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error)
 }
 
 type ReportUseCaseImpl struct {
@@ -126,6 +129,39 @@ func (uc *ReportUseCaseImpl) GetSellsAllAdminPharmacyMonthly(ctx context.Context
 	return paginatedItems, nil
 }
 
+func (uc *ReportUseCaseImpl) GetSellPrices(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	reports, err := uc.reportRepository.FindSellPrices(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := uc.reportRepository.CountSellPrices(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := totalItems / int64(*param.PageSize)
+	if totalItems%int64(*param.PageSize) != 0 || totalPages == 0 {
+		totalPages += 1
+	}
+
+	paginatedItems := entity.NewPaginationInfo(
+		totalItems, totalPages, int64(len(reports)), int64(*param.PageId), reports,
+	)
+
+	return paginatedItems, nil
+}
+
+func (uc *ReportUseCaseImpl) GetSellPricesAdminPharmacy(ctx context.Context, param *queryparamdto.GetAllParams) (*entity.PaginatedItems, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	pharmacy := new(entity.Pharmacy)
+	column := pharmacy.GetSqlColumnFromField("PharmacyAdminId")
+	param.WhereClauses = append(param.WhereClauses, appdb.NewWhere(column, appdb.EqualTo, userId))
+
+	return uc.GetSellPrices(ctx, param)
+}
+
 func NewReportUseCaseImpl(repo repository.ReportRepository) *ReportUseCaseImpl {
 	return &ReportUseCaseImpl{reportRepository: repo}
 }
@@ -3,17 +3,23 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"halodeksik-be/app/appconfig"
 	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/entity"
 	"halodeksik-be/app/repository"
+	"halodeksik-be/app/util"
+	"strings"
 )
 
 type SickLeaveFormUseCase interface {
 	Add(ctx context.Context, form entity.SickLeaveForm) (*entity.SickLeaveForm, error)
 	GetBySessionId(ctx context.Context, sessionId int64) (*entity.SickLeaveForm, error)
 	EditBySessionId(ctx context.Context, sessionId int64, form entity.SickLeaveForm) (*entity.SickLeaveForm, error)
+	GetCertificateBySessionId(ctx context.Context, sessionId int64) ([]byte, error)
+	Verify(ctx context.Context, code string, verification entity.SickLeaveFormVerification) (*entity.SickLeaveForm, error)
 }
 
 type SickLeaveFormUseCaseImpl struct {
@@ -21,6 +27,8 @@ type SickLeaveFormUseCaseImpl struct {
 	sessionRepo      repository.ConsultationSessionRepository
 	prescriptionRepo repository.PrescriptionRepository
 	messageRepo      repository.ConsultationMessageRepository
+	signatureUtil    util.SignatureUtil
+	pdfUtil          util.PdfUtil
 }
 
 func NewSickLeaveFormUseCaseImpl(
@@ -28,9 +36,12 @@ func NewSickLeaveFormUseCaseImpl(
 	sessionRepo repository.ConsultationSessionRepository,
 	prescriptionRepo repository.PrescriptionRepository,
 	messageRepo repository.ConsultationMessageRepository,
+	signatureUtil util.SignatureUtil,
+	pdfUtil util.PdfUtil,
 ) *SickLeaveFormUseCaseImpl {
 	return &SickLeaveFormUseCaseImpl{
 		formRepo: formRepo, sessionRepo: sessionRepo, prescriptionRepo: prescriptionRepo, messageRepo: messageRepo,
+		signatureUtil: signatureUtil, pdfUtil: pdfUtil,
 	}
 }
 
@@ -162,3 +173,68 @@ func (uc *SickLeaveFormUseCaseImpl) EditBySessionId(ctx context.Context, session
 
 	return edited, nil
 }
+
+func (uc *SickLeaveFormUseCaseImpl) GetCertificateBySessionId(ctx context.Context, sessionId int64) ([]byte, error) {
+	form, err := uc.GetBySessionId(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationCode := uc.getVerificationCode(form)
+	certificate := util.SickLeaveCertificate{
+		CertificateNumber:    form.GetCertificateNumber(),
+		PatientName:          form.User.UserProfile.Name,
+		PatientDateOfBirth:   form.User.UserProfile.DateOfBirth,
+		DoctorName:           form.Doctor.DoctorProfile.Name,
+		DoctorSpecialization: form.Doctor.DoctorProfile.DoctorSpecialization.Name,
+		StartingDate:         form.StartingDate,
+		EndingDate:           form.EndingDate,
+		Description:          form.Description,
+		IssuedAt:             form.UpdatedAt,
+		VerificationCode:     verificationCode,
+	}
+	if !util.IsEmptyString(appconfig.Config.ApiUrl) {
+		certificate.VerificationUrl = fmt.Sprintf(
+			appconstant.SickLeaveCertificateVerificationUrlPath, appconfig.Config.ApiUrl, verificationCode,
+		)
+	}
+
+	return uc.pdfUtil.GenerateSickLeaveCertificate(certificate)
+}
+
+func (uc *SickLeaveFormUseCaseImpl) Verify(ctx context.Context, code string, verification entity.SickLeaveFormVerification) (*entity.SickLeaveForm, error) {
+	idString, signature, found := strings.Cut(code, appconstant.SickLeaveCertificateVerificationSep)
+	if !found {
+		return nil, apperror.ErrSickLeaveCertificateInvalid
+	}
+
+	id, err := util.ParseInt64(idString)
+	if err != nil {
+		return nil, apperror.ErrSickLeaveCertificateInvalid
+	}
+
+	form, err := uc.formRepo.FindByIdDetailed(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.ErrSickLeaveCertificateInvalid
+		}
+		return nil, err
+	}
+
+	verification.SickLeaveFormId = form.Id
+	verification.IsValid = uc.signatureUtil.Verify(form.GetSignaturePayload(), signature)
+	_, err = uc.formRepo.CreateVerification(ctx, verification)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verification.IsValid {
+		return nil, apperror.ErrSickLeaveCertificateInvalid
+	}
+	return form, nil
+}
+
+func (uc *SickLeaveFormUseCaseImpl) getVerificationCode(form *entity.SickLeaveForm) string {
+	signature := uc.signatureUtil.Sign(form.GetSignaturePayload())
+	return fmt.Sprintf("%d%s%s", form.Id, appconstant.SickLeaveCertificateVerificationSep, signature)
+}
@@ -48,6 +48,10 @@ func (uc *DoctorSpecializationUseCaseImpl) Add(ctx context.Context, specializati
 		specialization.Image = fileName
 	}
 
+	if specialization.SessionDurationMinutes == 0 {
+		specialization.SessionDurationMinutes = appconstant.DefaultConsultationSessionDurationMinutes
+	}
+
 	created, err := uc.repo.Create(ctx, specialization)
 	if err != nil {
 		return nil, err
@@ -116,6 +120,9 @@ func (uc *DoctorSpecializationUseCaseImpl) Edit(ctx context.Context, id int64, s
 
 	specialization.Id = id
 	specialization.Image = specializationDb.Image
+	if specialization.SessionDurationMinutes == 0 {
+		specialization.SessionDurationMinutes = specializationDb.SessionDurationMinutes
+	}
 
 	fileHeader := ctx.Value(appconstant.FormImage)
 
@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/shopspring/decimal"
 	"halodeksik-be/app/appcloud"
 	"halodeksik-be/app/appconfig"
 	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/appdb"
 	"halodeksik-be/app/apperror"
 	"halodeksik-be/app/dto/queryparamdto"
 	"halodeksik-be/app/dto/requestdto"
@@ -28,16 +30,20 @@ type TransactionUseCaseImpl struct {
 	transactionRepository     repository.TransactionRepository
 	addressRepository         repository.UserAddressRepository
 	pharmacyProductRepository repository.PharmacyProductRepository
+	prescriptionRepository    repository.PrescriptionRepository
+	dependentRepository       repository.DependentRepository
 	uploader                  appcloud.FileUploader
 	cloudFolderPaymentProof   string
 }
 
-func NewTransactionUseCaseImpl(transRepo repository.TransactionRepository, addressRepo repository.UserAddressRepository, pharmacyProdRepo repository.PharmacyProductRepository, uploader appcloud.FileUploader) *TransactionUseCaseImpl {
+func NewTransactionUseCaseImpl(transRepo repository.TransactionRepository, addressRepo repository.UserAddressRepository, pharmacyProdRepo repository.PharmacyProductRepository, prescriptionRepo repository.PrescriptionRepository, dependentRepo repository.DependentRepository, uploader appcloud.FileUploader) *TransactionUseCaseImpl {
 
 	return &TransactionUseCaseImpl{
 		transactionRepository:     transRepo,
 		addressRepository:         addressRepo,
 		pharmacyProductRepository: pharmacyProdRepo,
+		prescriptionRepository:    prescriptionRepo,
+		dependentRepository:       dependentRepo,
 		uploader:                  uploader,
 		cloudFolderPaymentProof:   appconfig.Config.GcloudStoragePaymentProofs,
 	}
@@ -168,6 +174,15 @@ func (uc *TransactionUseCaseImpl) AddTransaction(ctx context.Context, transactio
 			return nil, err
 		}
 
+		var dependentId sql.NullInt64
+		if order.DependentId != 0 {
+			dependentId = appdb.NewSqlNullInt64(order.DependentId)
+		}
+		err = validateDependentOfUser(ctx, uc.dependentRepository, userId, dependentId)
+		if err != nil {
+			return nil, err
+		}
+
 		decShippingCost, _ := decimal.NewFromString(order.ShippingCost)
 		argOrder := entity.Order{
 			PharmacyId:       firstPharProd.PharmacyId,
@@ -175,6 +190,7 @@ func (uc *TransactionUseCaseImpl) AddTransaction(ctx context.Context, transactio
 			PharmacyAddress:  firstPharProd.Pharmacy.Address,
 			ShippingMethodId: order.ShippingMethodId,
 			ShippingCost:     decShippingCost,
+			DependentId:      dependentId,
 		}
 
 		var orderDetailsPerOrder []*entity.OrderDetail
@@ -197,6 +213,16 @@ func (uc *TransactionUseCaseImpl) AddTransaction(ctx context.Context, transactio
 				Image:       pharProd.Product.Image,
 				Price:       pharProd.Price,
 			}
+			if pharProd.Product.DrugClassificationId == appconstant.DrugClassificationIdObatKeras && detail.PrescriptionProductId == 0 {
+				return nil, apperror.ErrPrescriptionRequired
+			}
+			if detail.PrescriptionProductId != 0 {
+				err = uc.validatePrescriptionProduct(ctx, userId, dependentId, detail.PrescriptionProductId, pharProd.ProductId, detail.Quantity)
+				if err != nil {
+					return nil, err
+				}
+				argDetail.PrescriptionProductId = appdb.NewSqlNullInt64(detail.PrescriptionProductId)
+			}
 			paymentPerItem := decimal.NewFromInt32(detail.Quantity).Mul(pharProd.Price)
 			orderTotalPayment = orderTotalPayment.Add(paymentPerItem)
 			orderDetailsPerOrder = append(orderDetailsPerOrder, &argDetail)
@@ -294,3 +320,18 @@ func (uc *TransactionUseCaseImpl) CancelTransaction(ctx context.Context, id int6
 
 	return updatedTransaction, nil
 }
+
+func (uc *TransactionUseCaseImpl) validatePrescriptionProduct(ctx context.Context, userId int64, dependentId sql.NullInt64, prescriptionProductId int64, productId int64, quantity int32) error {
+	prescriptionProduct, err := uc.prescriptionRepository.FindPrescriptionProductById(ctx, prescriptionProductId)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFound(prescriptionProduct, "Id", prescriptionProductId)
+		}
+		return err
+	}
+
+	if prescriptionProduct.Prescription.DependentId != dependentId {
+		return apperror.ErrPrescriptionPatientMismatch
+	}
+	return validatePrescriptionProductRedemption(prescriptionProduct, userId, productId, quantity)
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"halodeksik-be/app/appconstant"
+	"halodeksik-be/app/apperror"
+	"halodeksik-be/app/entity"
+	"halodeksik-be/app/repository"
+)
+
+type UserNotificationUseCase interface {
+	GetAllMine(ctx context.Context) ([]*entity.UserNotification, error)
+	EditAsRead(ctx context.Context, id int64) (*entity.UserNotification, error)
+}
+
+type UserNotificationUseCaseImpl struct {
+	repo repository.UserNotificationRepository
+}
+
+func NewUserNotificationUseCaseImpl(repo repository.UserNotificationRepository) *UserNotificationUseCaseImpl {
+	return &UserNotificationUseCaseImpl{repo: repo}
+}
+
+func (uc *UserNotificationUseCaseImpl) GetAllMine(ctx context.Context) ([]*entity.UserNotification, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+	return uc.repo.FindAllByUserId(ctx, userId, appconstant.UserNotificationsLimit)
+}
+
+func (uc *UserNotificationUseCaseImpl) EditAsRead(ctx context.Context, id int64) (*entity.UserNotification, error) {
+	userId := ctx.Value(appconstant.ContextKeyUserId).(int64)
+
+	notification, err := uc.repo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound(notification, "Id", id)
+		}
+		return nil, err
+	}
+	if notification.UserId != userId {
+		return nil, apperror.ErrForbiddenModifyEntity
+	}
+	return uc.repo.UpdateAsRead(ctx, id)
+}
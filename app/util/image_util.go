@@ -0,0 +1,209 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"halodeksik-be/app/apperror"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+)
+
+type ImageUtil interface {
+	// GenerateJpegRenditions decodes a png or jpeg image and encodes one jpeg for every max size,
+	// each rendition fits within a square of that size and a max size of 0 keeps the original dimensions.
+	// Re-encoding drops every metadata of the source such as its exif data, the exif orientation is applied first
+	GenerateJpegRenditions(file io.Reader, maxSizes ...int) ([][]byte, error)
+}
+
+func NewImageUtil(maxPixels, quality int) ImageUtil {
+	return &ImageUtilImpl{maxPixels: maxPixels, quality: quality}
+}
+
+type ImageUtilImpl struct {
+	maxPixels int
+	quality   int
+}
+
+func (u *ImageUtilImpl) GenerateJpegRenditions(file io.Reader, maxSizes ...int) ([][]byte, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.ErrInvalidImage
+	}
+	if config.Width*config.Height > u.maxPixels {
+		return nil, apperror.ErrImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.ErrInvalidImage
+	}
+
+	// jpeg has no alpha channel, transparent pixels end up on a white background
+	flattened := image.NewRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), decoded, decoded.Bounds().Min, draw.Over)
+	orientation := jpegExifOrientation(data)
+
+	renditions := make([][]byte, 0, len(maxSizes))
+	for _, maxSize := range maxSizes {
+		// the max size is a square so resizing first fits the same way, only the smaller rendition is rotated
+		rendition := resizeImageToFit(flattened, maxSize)
+		if orientation != 1 {
+			rendition = orientImage(rendition, orientation)
+		}
+
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, rendition, &jpeg.Options{Quality: u.quality}); err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, buffer.Bytes())
+	}
+	return renditions, nil
+}
+
+// resizeImageToFit scales the image down by averaging every block of source pixels into one pixel
+func resizeImageToFit(src *image.RGBA, maxSize int) *image.RGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	if maxSize <= 0 || (width <= maxSize && height <= maxSize) {
+		return src
+	}
+
+	scale := float64(maxSize) / math.Max(float64(width), float64(height))
+	dstWidth := int(math.Max(1, math.Round(float64(width)*scale)))
+	dstHeight := int(math.Max(1, math.Round(float64(height)*scale)))
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		srcY0, srcY1 := y*height/dstHeight, (y+1)*height/dstHeight
+		if srcY1 <= srcY0 {
+			srcY1 = srcY0 + 1
+		}
+		for x := 0; x < dstWidth; x++ {
+			srcX0, srcX1 := x*width/dstWidth, (x+1)*width/dstWidth
+			if srcX1 <= srcX0 {
+				srcX1 = srcX0 + 1
+			}
+
+			var sum [4]int
+			for sy := srcY0; sy < srcY1; sy++ {
+				offset := sy*src.Stride + srcX0*4
+				for sx := srcX0; sx < srcX1; sx++ {
+					for channel := 0; channel < 4; channel++ {
+						sum[channel] += int(src.Pix[offset+channel])
+					}
+					offset += 4
+				}
+			}
+
+			count := (srcY1 - srcY0) * (srcX1 - srcX0)
+			dstOffset := y*dst.Stride + x*4
+			for channel := 0; channel < 4; channel++ {
+				dst.Pix[dstOffset+channel] = uint8(sum[channel] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// orientImage rotates and flips the image so it is displayed upright without its exif orientation tag
+func orientImage(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstBounds := image.Rect(0, 0, width, height)
+	if orientation >= 5 {
+		dstBounds = image.Rect(0, 0, height, width)
+	}
+	dst := image.NewRGBA(dstBounds)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dstX, dstY int
+			switch orientation {
+			case 2:
+				dstX, dstY = width-1-x, y
+			case 3:
+				dstX, dstY = width-1-x, height-1-y
+			case 4:
+				dstX, dstY = x, height-1-y
+			case 5:
+				dstX, dstY = y, x
+			case 6:
+				dstX, dstY = height-1-y, x
+			case 7:
+				dstX, dstY = height-1-y, width-1-x
+			case 8:
+				dstX, dstY = y, width-1-x
+			}
+			copy(dst.Pix[dstY*dst.Stride+dstX*4:dstY*dst.Stride+dstX*4+4], src.Pix[y*src.Stride+x*4:y*src.Stride+x*4+4])
+		}
+	}
+	return dst
+}
+
+// jpegExifOrientation reads the orientation tag of the exif data of a jpeg, it returns 1 (upright) when there is none
+func jpegExifOrientation(data []byte) int {
+	const (
+		markerStartOfScan = 0xDA
+		markerApp1        = 0xE1
+		tagOrientation    = 0x0112
+	)
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if marker == markerStartOfScan || length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == markerApp1 && len(segment) >= 14 && string(segment[:6]) == "Exif\x00\x00" {
+			tiff := segment[6:]
+			var order binary.ByteOrder
+			switch string(tiff[:2]) {
+			case "II":
+				order = binary.LittleEndian
+			case "MM":
+				order = binary.BigEndian
+			default:
+				return 1
+			}
+
+			ifdOffset := int(order.Uint32(tiff[4:8]))
+			if ifdOffset+2 > len(tiff) {
+				return 1
+			}
+			entries := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+			for index := 0; index < entries; index++ {
+				entry := ifdOffset + 2 + index*12
+				if entry+12 > len(tiff) {
+					return 1
+				}
+				if order.Uint16(tiff[entry:entry+2]) == tagOrientation {
+					return int(order.Uint16(tiff[entry+8 : entry+10]))
+				}
+			}
+			return 1
+		}
+		offset += 2 + length
+	}
+	return 1
+}
@@ -0,0 +1,98 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-pdf/fpdf"
+	"halodeksik-be/app/appconstant"
+	"time"
+)
+
+type SickLeaveCertificate struct {
+	CertificateNumber    string
+	PatientName          string
+	PatientDateOfBirth   time.Time
+	DoctorName           string
+	DoctorSpecialization string
+	StartingDate         time.Time
+	EndingDate           time.Time
+	Description          string
+	IssuedAt             time.Time
+	VerificationCode     string
+	VerificationUrl      string
+}
+
+type PdfUtil interface {
+	GenerateSickLeaveCertificate(certificate SickLeaveCertificate) ([]byte, error)
+}
+
+func NewPdfUtil() PdfUtil {
+	return &PdfUtilImpl{}
+}
+
+type PdfUtilImpl struct{}
+
+func (u *PdfUtilImpl) GenerateSickLeaveCertificate(certificate SickLeaveCertificate) ([]byte, error) {
+	const (
+		fontFamily = "Helvetica"
+		lineHeight = 7
+		labelWidth = 50
+	)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Sick Leave Certificate %s", certificate.CertificateNumber), false)
+	pdf.AddPage()
+
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 12, "SICK LEAVE CERTIFICATE", "", 1, "C", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, lineHeight, fmt.Sprintf("No. %s", certificate.CertificateNumber), "", 1, "C", false, 0, "")
+	pdf.Ln(lineHeight)
+
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.MultiCell(0, lineHeight, "The undersigned doctor hereby certifies that the following patient has been examined and is in need of rest for the period stated below.", "", "L", false)
+	pdf.Ln(lineHeight / 2)
+
+	rows := [][2]string{
+		{"Patient Name", certificate.PatientName},
+		{"Date of Birth", certificate.PatientDateOfBirth.Format(appconstant.TimeFormatQueryParam)},
+		{"Starting Date", certificate.StartingDate.Format(appconstant.TimeFormatQueryParam)},
+		{"Ending Date", certificate.EndingDate.Format(appconstant.TimeFormatQueryParam)},
+		{"Total Days", fmt.Sprintf("%d", int(certificate.EndingDate.Sub(certificate.StartingDate).Hours()/24)+1)},
+	}
+	for _, row := range rows {
+		pdf.SetFont(fontFamily, "B", 11)
+		pdf.CellFormat(labelWidth, lineHeight, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 11)
+		pdf.CellFormat(0, lineHeight, fmt.Sprintf(": %s", row[1]), "", 1, "L", false, 0, "")
+	}
+
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(labelWidth, lineHeight, "Description", "", 0, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.MultiCell(0, lineHeight, fmt.Sprintf(": %s", certificate.Description), "", "L", false)
+	pdf.Ln(lineHeight * 2)
+
+	pdf.CellFormat(0, lineHeight, fmt.Sprintf("Issued on %s", certificate.IssuedAt.Format(appconstant.TimeFormatQueryParam)), "", 1, "R", false, 0, "")
+	pdf.Ln(lineHeight * 2)
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(0, lineHeight, certificate.DoctorName, "", 1, "R", false, 0, "")
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.CellFormat(0, lineHeight, certificate.DoctorSpecialization, "", 1, "R", false, 0, "")
+	pdf.Ln(lineHeight * 2)
+
+	pdf.SetFont(fontFamily, "", 8)
+	pdf.MultiCell(0, 5, "This certificate is digitally signed. Any modification invalidates the signature. Verify its authenticity using the code below.", "T", "L", false)
+	pdf.SetFont("Courier", "", 8)
+	pdf.MultiCell(0, 5, certificate.VerificationCode, "", "L", false)
+	if !IsEmptyString(certificate.VerificationUrl) {
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.MultiCell(0, 5, certificate.VerificationUrl, "", "L", false)
+	}
+
+	var buff bytes.Buffer
+	if err := pdf.Output(&buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
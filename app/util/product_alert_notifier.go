@@ -0,0 +1,31 @@
+package util
+
+import "fmt"
+
+type ProductAlertNotification struct {
+	Email   string
+	Title   string
+	Message string
+}
+
+// ProductAlertNotifier sends product alerts outside the app, the in-app notification is always stored regardless
+// of the notifier
+type ProductAlertNotifier interface {
+	Notify(notification ProductAlertNotification) error
+}
+
+func NewEmailProductAlertNotifier(emailUtil EmailUtil) ProductAlertNotifier {
+	return &EmailProductAlertNotifierImpl{emailUtil: emailUtil}
+}
+
+type EmailProductAlertNotifierImpl struct {
+	emailUtil EmailUtil
+}
+
+func (n EmailProductAlertNotifierImpl) Notify(notification ProductAlertNotification) error {
+	message := fmt.Sprintf(
+		"<p>%s</p><p>Open the app to order it before it runs out again.</p>",
+		notification.Message,
+	)
+	return n.emailUtil.SendEmail([]string{notification.Email}, nil, notification.Title, message)
+}
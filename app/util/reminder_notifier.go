@@ -0,0 +1,39 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReminderNotification struct {
+	Email          string
+	PatientName    string
+	MedicationName string
+	Instruction    string
+	ScheduledAt    time.Time
+}
+
+// ReminderNotifier is the channel medication reminders are dispatched through, more channels can be added
+// by implementing it and wiring the implementation into the cron use case
+type ReminderNotifier interface {
+	Notify(notification ReminderNotification) error
+}
+
+func NewEmailReminderNotifier(emailUtil EmailUtil) ReminderNotifier {
+	return &EmailReminderNotifierImpl{emailUtil: emailUtil}
+}
+
+type EmailReminderNotifierImpl struct {
+	emailUtil EmailUtil
+}
+
+func (n EmailReminderNotifierImpl) Notify(notification ReminderNotification) error {
+	subject := fmt.Sprintf("Medication reminder: %s", notification.MedicationName)
+	message := fmt.Sprintf(
+		"<p>Hi, it is time for %s to take <b>%s</b> (scheduled at %s).</p><p>%s</p>"+
+			"<p>Please log the dose as taken or skipped in the app.</p>",
+		notification.PatientName, notification.MedicationName,
+		notification.ScheduledAt.Format("02 Jan 2006 15:04"), notification.Instruction,
+	)
+	return n.emailUtil.SendEmail([]string{notification.Email}, nil, subject, message)
+}
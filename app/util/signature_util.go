@@ -0,0 +1,44 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+var ErrDocumentSigningKeyNotConfigured = errors.New("document signing key is not configured")
+
+type SignatureUtil interface {
+	Sign(payload string) string
+	Verify(payload, signature string) bool
+}
+
+// NewSignatureUtil refuses an empty key, anyone could forge the signatures made with it
+func NewSignatureUtil(key string) (SignatureUtil, error) {
+	if key == "" {
+		return nil, ErrDocumentSigningKeyNotConfigured
+	}
+	return &SignatureUtilImpl{key: []byte(key)}, nil
+}
+
+type SignatureUtilImpl struct {
+	key []byte
+}
+
+func (u *SignatureUtilImpl) Sign(payload string) string {
+	mac := hmac.New(sha256.New, u.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (u *SignatureUtilImpl) Verify(payload, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, u.key)
+	mac.Write([]byte(payload))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -3,34 +3,68 @@ package ws
 import (
 	"halodeksik-be/app/appconstant"
 	"halodeksik-be/app/dto/responsedto"
+	"halodeksik-be/app/entity"
+	"time"
 )
 
 type ConsultationSession struct {
 	Id        int64             `json:"id"`
 	DoctorId  int64             `json:"doctor_id"`
 	PatientId int64             `json:"patient_id"`
+	ExpiresAt time.Time         `json:"expires_at"`
 	Clients   map[int64]*Client `json:"clients"`
+
+	isExpiryWarned    bool
+	isExpiredNotified bool
+}
+
+func NewConsultationSession(session *entity.ConsultationSession) *ConsultationSession {
+	return &ConsultationSession{
+		Id:        session.Id,
+		DoctorId:  session.DoctorId,
+		PatientId: session.UserId,
+		ExpiresAt: session.ExpiresAt.Time,
+		Clients:   make(map[int64]*Client),
+	}
 }
 
+// ConsultationSessionDeadline moves the expiry of an open room, e.g. after an extension was accepted
+type ConsultationSessionDeadline struct {
+	SessionId int64
+	ExpiresAt time.Time
+}
+
+// Hub owns the rooms, ConsultationSessions must only be touched from Run so handlers open rooms through CreateRoom
 type Hub struct {
 	ConsultationSessions map[int64]*ConsultationSession
+	CreateRoom           chan *ConsultationSession
 	Register             chan *Client
 	Unregister           chan *Client
 	Broadcast            chan *responsedto.WsConsultationMessage
+	Deadline             chan *ConsultationSessionDeadline
 }
 
 func NewHub() *Hub {
 	return &Hub{
 		ConsultationSessions: make(map[int64]*ConsultationSession),
+		CreateRoom:           make(chan *ConsultationSession),
 		Register:             make(chan *Client),
 		Unregister:           make(chan *Client),
 		Broadcast:            make(chan *responsedto.WsConsultationMessage, appconstant.BroadcastChannelBufferSize),
+		Deadline:             make(chan *ConsultationSessionDeadline, appconstant.BroadcastChannelBufferSize),
 	}
 }
 
 func (h *Hub) Run() {
+	countdown := time.NewTicker(appconstant.ConsultationSessionCountdownInterval)
+	defer countdown.Stop()
+
 	for {
 		select {
+		case room := <-h.CreateRoom:
+			if _, isRoomExist := h.ConsultationSessions[room.Id]; !isRoomExist {
+				h.ConsultationSessions[room.Id] = room
+			}
 		case client := <-h.Register:
 			if _, isRoomExist := h.ConsultationSessions[client.SessionId]; isRoomExist {
 				r := h.ConsultationSessions[client.SessionId]
@@ -53,6 +87,62 @@ func (h *Hub) Run() {
 					client.Message <- message
 				}
 			}
+		case deadline := <-h.Deadline:
+			if r, isRoomExist := h.ConsultationSessions[deadline.SessionId]; isRoomExist {
+				r.ExpiresAt = deadline.ExpiresAt
+				r.isExpiryWarned = false
+				r.isExpiredNotified = false
+			}
+		case now := <-countdown.C:
+			for _, r := range h.ConsultationSessions {
+				h.sendCountdown(r, now)
+			}
+		}
+	}
+}
+
+// sendCountdown pushes the remaining time and warns once before and once at the expiry, ending the session itself
+// is left to the cron so sessions nobody is connected to end as well
+func (h *Hub) sendCountdown(r *ConsultationSession, now time.Time) {
+	if r.ExpiresAt.IsZero() || len(r.Clients) == 0 {
+		return
+	}
+
+	remaining := r.ExpiresAt.Sub(now)
+	if remaining <= 0 {
+		if !r.isExpiredNotified {
+			r.isExpiredNotified = true
+			h.sendSystemMessage(r, appconstant.MessageTypeAlert, appconstant.MessageConsultationSessionExpired, now)
+		}
+		return
+	}
+
+	if remaining <= appconstant.ConsultationSessionExpiryWarning && !r.isExpiryWarned {
+		r.isExpiryWarned = true
+		h.sendSystemMessage(r, appconstant.MessageTypeAlert, appconstant.MessageConsultationSessionExpiring, now)
+	}
+	h.sendSystemMessage(r, appconstant.MessageTypeCountdown, "", now)
+}
+
+// sendSystemMessage drops the message for clients whose buffer is full, the next countdown tick catches them up
+func (h *Hub) sendSystemMessage(r *ConsultationSession, messageType int64, message string, now time.Time) {
+	expiresAt := r.ExpiresAt
+	msg := &responsedto.WsConsultationMessage{
+		MessageType:      messageType,
+		Message:          message,
+		CreatedAt:        now,
+		SessionId:        r.Id,
+		ExpiresAt:        &expiresAt,
+		RemainingSeconds: int64(r.ExpiresAt.Sub(now).Seconds()),
+	}
+	if msg.RemainingSeconds < 0 {
+		msg.RemainingSeconds = 0
+	}
+
+	for _, client := range r.Clients {
+		select {
+		case client.Message <- msg:
+		default:
 		}
 	}
 }